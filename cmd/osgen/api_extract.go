@@ -21,8 +21,9 @@ import (
 
 // Content types and schema key suffixes used during extraction.
 const (
-	contentJSON   = "application/json"
-	contentNDJSON = "application/x-ndjson"
+	contentJSON        = "application/json"
+	contentNDJSON      = "application/x-ndjson"
+	contentEventStream = "text/event-stream"
 
 	// Schema key suffixes appended to the group name when a schema is inline
 	// (not a $ref to components/schemas/).
@@ -87,8 +88,10 @@ type apiOperation struct {
 
 	// Dispatch routes for this operation (primary flat + optional deprecated nested).
 	DispatchRoutes []dispatchRoute
-	IsPointerReq   bool // true when all path fields are optional (pointer req convention)
-	IsNoBody       bool // true for HEAD-only operations (returns *opensearch.Response)
+	IsPointerReq   bool   // true when all path fields are optional (pointer req convention)
+	IsNoBody       bool   // true for HEAD-only operations (returns *opensearch.Response)
+	IsEventStream  bool   // true when the 2xx response is text/event-stream (returns *opensearch.EventStream)
+	EventRef       string // schema key for each event's data (e.g. "ml._common___PredictResponse")
 
 	// PathBuilder holds the analyzed trie ops for path construction, used by
 	// test generation to simulate Build() without importing internal/path.
@@ -468,6 +471,13 @@ func buildAPIOperation(group string, ops []struct {
 		break
 	}
 
+	// Server-sent event responses have no JSON body to walk; the generated
+	// method streams them instead, decoding each event into the schema the
+	// spec declares for the stream.
+	if apiOp.ResponseRef == "" {
+		apiOp.IsEventStream, apiOp.EventRef = eventStreamRef(ops, spec)
+	}
+
 	// Resolve dispatch routes and request style.
 	apiOp.DispatchRoutes = resolveDispatchRoutes(group)
 	apiOp.IsPointerReq = !hasRequiredScalarPath(apiOp.PathFields)
@@ -476,6 +486,39 @@ func buildAPIOperation(group string, ops []struct {
 	return apiOp, paramExc
 }
 
+// eventStreamRef reports whether any variant in ops answers a 2xx with a
+// text/event-stream body, and returns the schema key its events decode into.
+// The key is "" when the stream's schema is inline or absent, in which case
+// the events are surfaced as raw JSON.
+func eventStreamRef(ops []struct {
+	method string
+	op     *openapi3.Operation
+	path   *openapi3.PathItem
+	url    string
+}, spec *openapi3.T,
+) (bool, string) {
+	for _, o := range ops {
+		if o.op.Responses == nil {
+			continue
+		}
+		for _, code := range successResponseCodes {
+			resp := o.op.Responses.Value(strconv.Itoa(code))
+			if resp == nil || resp.Value == nil || resp.Value.Content == nil {
+				continue
+			}
+			mt := resp.Value.Content.Get(contentEventStream)
+			if mt == nil {
+				continue
+			}
+			if mt.Schema == nil || mt.Schema.Ref == "" {
+				return true, ""
+			}
+			return true, resolveSchemaAlias(refToSchemaKey(mt.Schema.Ref), spec)
+		}
+	}
+	return false, ""
+}
+
 type pathParam struct {
 	name   string
 	isList bool
@@ -596,6 +596,53 @@ func buildTestSpecWithResponseSchema(t *testing.T) string {
 	return writeTestSpec(t, spec)
 }
 
+func TestBuildAPIOperation_EventStream(t *testing.T) {
+	t.Parallel()
+
+	spec := writeTestSpec(t, map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": "Test", "version": "1.0.0"},
+		"paths": map[string]any{
+			"/_plugins/_ml/models/{model_id}/_predict/stream": map[string]any{
+				"post": map[string]any{
+					"x-operation-group": "ml.predict_model_stream",
+					"x-version-added":   "3.3",
+					"parameters": []any{
+						map[string]any{"name": "model_id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "",
+							"content": map[string]any{
+								"text/event-stream": map[string]any{
+									"schema": map[string]any{"$ref": "#/components/schemas/ml._common___PredictResponse"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]any{
+			"schemas": map[string]any{
+				"ml._common___PredictResponse": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"status": map[string]any{"type": "string"}},
+				},
+			},
+		},
+	})
+	//nolint:dogsled // test only cares about ops + err
+	ops, _, _, _, err := extractOperations(spec, map[string]bool{"ml.predict_model_stream": true}, VersionRange{})
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+
+	require.True(t, ops[0].IsEventStream)
+	require.Equal(t, "ml._common___PredictResponse", ops[0].EventRef)
+	require.Empty(t, ops[0].ResponseRef)
+	require.Nil(t, convertOperation(&ops[0]).Response)
+}
+
 func buildTestSpecWithQueryParams(t *testing.T) string {
 	t.Helper()
 	spec := map[string]any{
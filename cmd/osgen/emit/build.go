@@ -132,6 +132,11 @@ func Build(spec *ir.Spec, cfg BuildConfig) []Target {
 		targets = append(targets, t)
 	}
 
+	// Per-operation version table (core package).
+	if t := NewVersionsFile(cfg.OutDir, cfg.CorePkg, spec.Operations); t != nil {
+		targets = append(targets, t)
+	}
+
 	// Shared types and union types (core package).
 	if t := NewSharedTypesFile(cfg.OutDir, cfg.CorePkg, spec.Types); t != nil {
 		targets = append(targets, t)
@@ -185,7 +190,7 @@ func Build(spec *ir.Spec, cfg BuildConfig) []Target {
 
 		// Plugin client file.
 		byGroup := cfg.PluginSubClients[pkg]
-		if t := NewPluginClientFile(pi.dir, pkg, pi.ops, byGroup); t != nil {
+		if t := NewPluginClientFile(pi.dir, pkg, pi.ops, byGroup, spec.Registry); t != nil {
 			targets = append(targets, t)
 		}
 
@@ -639,10 +644,11 @@ func buildIntegTestFrag(op *ir.Operation, pkg, importPath string, cfg BuildConfi
 
 func classifyOpIR(op *ir.Operation, pkg, corePkg string, isPlugin bool, buildCfg BuildConfig) IntegTestConfig {
 	cfg := IntegTestConfig{
-		TypePrefix:  op.TypePrefix,
-		IsNoBody:    op.IsNoBody,
-		IsPlugin:    isPlugin,
-		CorePkgName: corePkg,
+		TypePrefix:    op.TypePrefix,
+		IsNoBody:      op.IsNoBody,
+		IsEventStream: op.IsEventStream,
+		IsPlugin:      isPlugin,
+		CorePkgName:   corePkg,
 	}
 
 	versionAdded := op.VersionAdded
@@ -761,9 +767,20 @@ func classifyOpIR(op *ir.Operation, pkg, corePkg string, isPlugin bool, buildCfg
 		cfg.CallExpr = addParamField(cfg.CallExpr, op, pkg, paramStr)
 	}
 
+	if op.IsEventStream {
+		cfg.CallExpr = withStreamOptions(cfg.CallExpr)
+		cfg.FailCallExpr = withStreamOptions(cfg.FailCallExpr)
+	}
+
 	return cfg
 }
 
+// withStreamOptions appends the nil *opensearch.StreamOptions argument that
+// event-stream methods take after the request.
+func withStreamOptions(callExpr string) string {
+	return strings.TrimSuffix(callExpr, ")") + ", nil)"
+}
+
 func primaryRouteIR(op *ir.Operation) ir.DispatchRoute {
 	for _, route := range op.DispatchRoutes {
 		if !route.Deprecated {
@@ -1480,6 +1497,10 @@ func buildRoundtripTestFrag(op *ir.Operation, pkg, importPath string, _ BuildCon
 
 	callExpr := callPrefix + "(t.Context(), " + reqExpr + ")"
 	errCallExpr := errPrefix + "(t.Context(), " + reqExpr + ")"
+	if op.IsEventStream {
+		callExpr = withStreamOptions(callExpr)
+		errCallExpr = withStreamOptions(errCallExpr)
+	}
 
 	needsStrings := strings.Contains(reqExpr, "strings.NewReader")
 
@@ -1490,21 +1511,22 @@ func buildRoundtripTestFrag(op *ir.Operation, pkg, importPath string, _ BuildCon
 	case ir.RespShapeStruct, ir.RespShapeMap, ir.RespShapeRaw:
 		fixture = "{}"
 	}
-	if op.IsNoBody {
+	if op.IsNoBody || op.IsEventStream {
 		fixture = ""
 	}
 
 	return &RoundtripTestFragment{
-		PkgName:      pkg,
-		ImportPath:   importPath,
-		TypePrefix:   op.TypePrefix,
-		RespFixture:  fixture,
-		IsNoBody:     op.IsNoBody,
-		IsPlugin:     op.IsPlugin,
-		CallExpr:     callExpr,
-		ErrCallExpr:  errCallExpr,
-		NeedsBody:    needsBody,
-		NeedsStrings: needsStrings,
+		PkgName:       pkg,
+		ImportPath:    importPath,
+		TypePrefix:    op.TypePrefix,
+		RespFixture:   fixture,
+		IsNoBody:      op.IsNoBody,
+		IsEventStream: op.IsEventStream,
+		IsPlugin:      op.IsPlugin,
+		CallExpr:      callExpr,
+		ErrCallExpr:   errCallExpr,
+		NeedsBody:     needsBody,
+		NeedsStrings:  needsStrings,
 	}
 }
 
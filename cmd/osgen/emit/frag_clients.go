@@ -135,6 +135,8 @@ var noBody *opensearch.NoBody //nolint:gochecknoglobals // package-internal sent
 type Client struct {
 	Client *opensearch.Client
 	errors *errMaskWidth
+	cache  *responseCache
+	gate   *versionGate
 {{- range .TopLevel}}
 	{{- $typeName := .TypeName}}
 	{{.FieldName}} {{.TypeName}}
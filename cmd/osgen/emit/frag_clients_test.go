@@ -38,6 +38,8 @@ func TestClientsFragment_Body(t *testing.T) {
 		{name: "Client struct", want: "type Client struct"},
 		{name: "Client field", want: "Client *opensearch.Client"},
 		{name: "errors mask field", want: "errors *errMaskWidth"},
+		{name: "response cache field", want: "cache  *responseCache"},
+		{name: "version gate field", want: "gate   *versionGate"},
 		{name: "top-level Cat", want: "Cat CatClient"},
 		{name: "top-level Indices", want: "Indices IndicesClient"},
 		{name: "clientInit", want: "func clientInit(rootClient *opensearch.Client, mask errmask.ErrorMask) *Client"},
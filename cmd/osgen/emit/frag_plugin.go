@@ -27,6 +27,8 @@ type PluginClientOp struct {
 	TypePrefix        string
 	IsPointerReq      bool
 	IsNoBody          bool
+	IsEventStream     bool
+	EventType         string           // Go type each event decodes into (e.g. "opensearchapi.MLPredictResponse")
 	HTTPMethod        string           // Go expression for the primary HTTP method (e.g. "http.MethodGet")
 	SubClient         *PluginSubClient // nil for root Client ops; set for sub-client dispatch
 	Group             string
@@ -45,16 +47,21 @@ type PluginClientOp struct {
 type PluginClientFragment struct {
 	Ops        []PluginClientOp
 	SubClients []PluginSubClient
+
+	// EventImports lists the packages the event-stream operations' event
+	// types come from.
+	EventImports []Import
 }
 
 // Imports returns the imports the plugin Client fragment needs.
 func (f *PluginClientFragment) Imports() []Import {
-	return []Import{
+	imps := []Import{
 		{Path: "context"},
 		{Path: "fmt"},
 		{Path: "net/http"},
 		{Path: "github.com/opensearch-project/opensearch-go/v5"},
 	}
+	return append(imps, f.EventImports...)
 }
 
 // Body renders the plugin Client struct, its sub-clients, and dispatch methods.
@@ -121,9 +128,22 @@ func pluginMethodComment(op PluginClientOp) string {
 	})
 }
 
+// pluginResultType returns the Go type a plugin client method returns for op.
+func pluginResultType(op PluginClientOp) string {
+	switch {
+	case op.IsEventStream:
+		return "*opensearch.EventStream[" + op.EventType + "]"
+	case op.IsNoBody:
+		return "*opensearch.Response"
+	default:
+		return "*" + op.TypePrefix + "Resp"
+	}
+}
+
 //nolint:gochecknoglobals // const-ish read-only template
 var pluginClientFragTmpl = template.Must(template.New("pluginClient").Funcs(template.FuncMap{
 	"methodComment": pluginMethodComment,
+	"resultType":    pluginResultType,
 }).Parse(`// Client provides methods for this plugin API.
 type Client struct {
 	Client *opensearch.Client
@@ -177,12 +197,14 @@ type {{.TypeName}} struct {
 {{- range .RootOps}}
 {{- if .IsPointerReq}}
 {{methodComment .}}
-func (c *Client) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req) ({{- ""}}
-	{{- if .IsNoBody}}*opensearch.Response{{else}}*{{.TypePrefix}}Resp{{end}}, error) {
+func (c *Client) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req{{if .IsEventStream}}, opts *opensearch.StreamOptions{{end}}) ({{- ""}}
+	{{- resultType .}}, error) {
 	if req == nil {
 		req = &{{.TypePrefix}}Req{}
 	}
-{{- if .IsNoBody}}
+{{- if .IsEventStream}}
+	return opensearch.ExecuteEventStream[{{.EventType}}](ctx, c.Client, {{.HTTPMethod}}, *req, opts)
+{{- else if .IsNoBody}}
 	return request(ctx, c, {{.HTTPMethod}}, *req, noBody)
 {{- else}}
 	var resp {{.TypePrefix}}Resp
@@ -195,9 +217,11 @@ func (c *Client) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req) (
 }
 {{- else}}
 {{methodComment .}}
-func (c *Client) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req) ({{- ""}}
-	{{- if .IsNoBody}}*opensearch.Response{{else}}*{{.TypePrefix}}Resp{{end}}, error) {
-{{- if .IsNoBody}}
+func (c *Client) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req{{if .IsEventStream}}, opts *opensearch.StreamOptions{{end}}) ({{- ""}}
+	{{- resultType .}}, error) {
+{{- if .IsEventStream}}
+	return opensearch.ExecuteEventStream[{{.EventType}}](ctx, c.Client, {{.HTTPMethod}}, req, opts)
+{{- else if .IsNoBody}}
 	return request(ctx, c, {{.HTTPMethod}}, req, noBody)
 {{- else}}
 	var resp {{.TypePrefix}}Resp
@@ -213,12 +237,14 @@ func (c *Client) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req) ({
 {{- range .SubClientOps}}
 {{- if .IsPointerReq}}
 {{methodComment .}}
-func (c {{.SubClient.TypeName}}) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req) ({{- ""}}
-	{{- if .IsNoBody}}*opensearch.Response{{else}}*{{.TypePrefix}}Resp{{end}}, error) {
+func (c {{.SubClient.TypeName}}) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req{{if .IsEventStream}}, opts *opensearch.StreamOptions{{end}}) ({{- ""}}
+	{{- resultType .}}, error) {
 	if req == nil {
 		req = &{{.TypePrefix}}Req{}
 	}
-{{- if .IsNoBody}}
+{{- if .IsEventStream}}
+	return opensearch.ExecuteEventStream[{{.EventType}}](ctx, c.client.Client, {{.HTTPMethod}}, *req, opts)
+{{- else if .IsNoBody}}
 	return request(ctx, c.client, {{.HTTPMethod}}, *req, noBody)
 {{- else}}
 	var resp {{.TypePrefix}}Resp
@@ -231,9 +257,11 @@ func (c {{.SubClient.TypeName}}) {{.MethodName}}(ctx context.Context, req *{{.Ty
 }
 {{- else}}
 {{methodComment .}}
-func (c {{.SubClient.TypeName}}) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req) ({{- ""}}
-	{{- if .IsNoBody}}*opensearch.Response{{else}}*{{.TypePrefix}}Resp{{end}}, error) {
-{{- if .IsNoBody}}
+func (c {{.SubClient.TypeName}}) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req{{if .IsEventStream}}, opts *opensearch.StreamOptions{{end}}) ({{- ""}}
+	{{- resultType .}}, error) {
+{{- if .IsEventStream}}
+	return opensearch.ExecuteEventStream[{{.EventType}}](ctx, c.client.Client, {{.HTTPMethod}}, req, opts)
+{{- else if .IsNoBody}}
 	return request(ctx, c.client, {{.HTTPMethod}}, req, noBody)
 {{- else}}
 	var resp {{.TypePrefix}}Resp
@@ -249,15 +277,15 @@ func (c {{.SubClient.TypeName}}) {{.MethodName}}(ctx context.Context, req {{.Typ
 {{- range .DeprecatedForwards}}
 {{- if .IsPointerReq}}
 // Deprecated: use Client.{{.SubClientField}}.{{.MethodName}} instead.
-func (c *Client) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req) ({{- ""}}
-	{{- if .IsNoBody}}*opensearch.Response{{else}}*{{.TypePrefix}}Resp{{end}}, error) {
-	return c.{{.SubClientField}}.{{.MethodName}}(ctx, req)
+func (c *Client) {{.MethodName}}(ctx context.Context, req *{{.TypePrefix}}Req{{if .IsEventStream}}, opts *opensearch.StreamOptions{{end}}) ({{- ""}}
+	{{- resultType .PluginClientOp}}, error) {
+	return c.{{.SubClientField}}.{{.MethodName}}(ctx, req{{if .IsEventStream}}, opts{{end}})
 }
 {{- else}}
 // Deprecated: use Client.{{.SubClientField}}.{{.MethodName}} instead.
-func (c *Client) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req) ({{- ""}}
-	{{- if .IsNoBody}}*opensearch.Response{{else}}*{{.TypePrefix}}Resp{{end}}, error) {
-	return c.{{.SubClientField}}.{{.MethodName}}(ctx, req)
+func (c *Client) {{.MethodName}}(ctx context.Context, req {{.TypePrefix}}Req{{if .IsEventStream}}, opts *opensearch.StreamOptions{{end}}) ({{- ""}}
+	{{- resultType .PluginClientOp}}, error) {
+	return c.{{.SubClientField}}.{{.MethodName}}(ctx, req{{if .IsEventStream}}, opts{{end}})
 }
 {{- end}}
 {{end}}
@@ -353,8 +381,10 @@ func VerifyInspect(t *testing.T, inspect {{.CorePkg}}.Inspect) {
 
 // NewPluginClientFile builds a Target for a plugin's client_gen.go.
 // The byGroup map (group -> *PluginSubClient) determines which ops dispatch
-// through a sub-client. Operations not in the map stay flat on Client.
-func NewPluginClientFile(outDir, pkg string, ops []*ir.Operation, byGroup map[string]*PluginSubClient) Target {
+// through a sub-client. Operations not in the map stay flat on Client. The
+// registry resolves the event types of event-stream operations; it may be nil
+// when there are none.
+func NewPluginClientFile(outDir, pkg string, ops []*ir.Operation, byGroup map[string]*PluginSubClient, reg *ir.TypeRegistry) Target {
 	if len(ops) == 0 {
 		return nil
 	}
@@ -374,13 +404,17 @@ func NewPluginClientFile(outDir, pkg string, ops []*ir.Operation, byGroup map[st
 		return subClients[i].FieldName < subClients[j].FieldName
 	})
 
-	var clientOps []PluginClientOp
+	var (
+		clientOps    []PluginClientOp
+		eventImports importSet
+	)
 	for _, op := range ops {
 		pco := PluginClientOp{
 			MethodName:        op.MethodName,
 			TypePrefix:        op.TypePrefix,
 			IsPointerReq:      op.IsPointerReq,
 			IsNoBody:          op.IsNoBody,
+			IsEventStream:     op.IsEventStream,
 			HTTPMethod:        HTTPMethodConst(PrimaryMethod(op)),
 			SubClient:         byGroup[op.Group],
 			Group:             op.Group,
@@ -393,6 +427,14 @@ func NewPluginClientFile(outDir, pkg string, ops []*ir.Operation, byGroup map[st
 			ExcludedDistros:   op.ExcludedDistros,
 			DocsURL:           op.DocsURL,
 		}
+		if op.IsEventStream {
+			pco.EventType = eventGoType(op, reg)
+			if pco.EventType == "json.RawMessage" {
+				eventImports.Add("encoding/json")
+			} else if strings.HasPrefix(pco.EventType, reg.CorePkg+".") {
+				eventImports.Add(reg.CoreImport)
+			}
+		}
 		clientOps = append(clientOps, pco)
 	}
 
@@ -400,8 +442,26 @@ func NewPluginClientFile(outDir, pkg string, ops []*ir.Operation, byGroup map[st
 		FilePath:   outDir + "/client_gen.go",
 		Package:    pkg,
 		PackageDoc: pluginPackageDoc(pkg, subClients),
-		Fragments:  []Fragment{&PluginClientFragment{Ops: clientOps, SubClients: subClients}},
+		Fragments: []Fragment{&PluginClientFragment{
+			Ops:          clientOps,
+			SubClients:   subClients,
+			EventImports: eventImports.Sorted(),
+		}},
+	}
+}
+
+// eventGoType returns the Go type, as referenced from a plugin package, that
+// each event of an event-stream operation decodes into. Events whose schema is
+// not a registered type are surfaced as raw JSON.
+func eventGoType(op *ir.Operation, reg *ir.TypeRegistry) string {
+	if reg == nil || op.EventRef == "" {
+		return "json.RawMessage"
+	}
+	t, ok := reg.Lookup(op.EventRef)
+	if !ok {
+		return "json.RawMessage"
 	}
+	return qualifyType(t.Name, reg)
 }
 
 // pluginPackageDoc builds the package doc comment for a plugin package that has
@@ -108,7 +108,7 @@ func TestNewPluginClientFile_Render(t *testing.T) {
 		{Group: "security.create_role", MethodName: "CreateRole", TypePrefix: "SecurityCreateRole", IsPointerReq: false},
 	}
 
-	target := emit.NewPluginClientFile("/tmp/test", "ossecurity", ops, nil)
+	target := emit.NewPluginClientFile("/tmp/test", "ossecurity", ops, nil, nil)
 	require.NotNil(t, target)
 
 	src, err := target.Render()
@@ -199,7 +199,7 @@ func TestNewPluginClientFile_WithSubClients(t *testing.T) {
 		"security.create_role": roleSC,
 	}
 
-	target := emit.NewPluginClientFile("/tmp/test", "ossecurity", ops, byGroup)
+	target := emit.NewPluginClientFile("/tmp/test", "ossecurity", ops, byGroup, nil)
 	require.NotNil(t, target)
 
 	src, err := target.Render()
@@ -220,3 +220,46 @@ func TestNewPluginClientFile_WithSubClients(t *testing.T) {
 	// treats it as the package documentation rather than a floating comment.
 	require.Regexp(t, `(?m)^// Package ossecurity wraps[\s\S]*?\npackage ossecurity\n`, output)
 }
+
+func TestNewPluginClientFile_EventStream(t *testing.T) {
+	t.Parallel()
+
+	reg := ir.NewTypeRegistry(ir.DefaultCorePkgName, ir.DefaultCoreImportPath)
+	reg.Register(&ir.Type{
+		Name: "MLPredictResponse", SchemaRef: "ml._common___PredictResponse",
+		Kind: ir.TypeStruct, Scope: ir.ScopeShared, ImportPath: ir.DefaultCoreImportPath,
+	})
+	agentSC := &emit.PluginSubClient{TypeName: "AgentClient", FieldName: "Agent"}
+
+	ops := []*ir.Operation{
+		{
+			Group: "ml.predict_model_stream", MethodName: "PredictModelStream", TypePrefix: "PredictModelStream",
+			HTTPMethods: []string{http.MethodPost}, IsEventStream: true, EventRef: "ml._common___PredictResponse",
+		},
+		{
+			Group: "ml.execute_agent_stream", MethodName: "ExecuteAgentStream", TypePrefix: "ExecuteAgentStream",
+			HTTPMethods: []string{http.MethodPost}, IsEventStream: true, IsPointerReq: true,
+		},
+	}
+
+	target := emit.NewPluginClientFile("/tmp/test", "ml", ops, map[string]*emit.PluginSubClient{
+		"ml.execute_agent_stream": agentSC,
+	}, reg)
+	require.NotNil(t, target)
+
+	src, err := target.Render()
+	require.NoError(t, err)
+
+	output := string(src)
+	require.Contains(t, output, `"encoding/json"`)
+	require.Contains(t, output, `"github.com/opensearch-project/opensearch-go/v5/opensearchapi"`)
+	require.Contains(t, output, "func (c *Client) PredictModelStream(ctx context.Context, req PredictModelStreamReq, opts *opensearch.StreamOptions) "+
+		"(*opensearch.EventStream[opensearchapi.MLPredictResponse], error) {")
+	require.Contains(t, output, "return opensearch.ExecuteEventStream[opensearchapi.MLPredictResponse](ctx, c.Client, http.MethodPost, req, opts)")
+	// An event schema that is not a registered type falls back to raw JSON.
+	require.Contains(t, output, "func (c AgentClient) ExecuteAgentStream(ctx context.Context, req *ExecuteAgentStreamReq, opts *opensearch.StreamOptions) "+
+		"(*opensearch.EventStream[json.RawMessage], error) {")
+	require.Contains(t, output, "return opensearch.ExecuteEventStream[json.RawMessage](ctx, c.client.Client, http.MethodPost, *req, opts)")
+	require.Contains(t, output, "return c.Agent.ExecuteAgentStream(ctx, req, opts)")
+	require.NotContains(t, output, "PredictModelStreamResp")
+}
@@ -25,6 +25,11 @@ type RoundtripTestFragment struct {
 	// IsNoBody is true when the operation returns *opensearch.Response.
 	IsNoBody bool
 
+	// IsEventStream is true when the operation returns an
+	// *opensearch.EventStream; the mock server then answers with one
+	// server-sent event.
+	IsEventStream bool
+
 	// IsPlugin selects the client construction style: plugin clients wrap an
 	// opensearch.Client (pkg.NewClient(osClient)), whereas the core client takes
 	// a config (pkg.NewClient(pkg.Config{...})).
@@ -78,7 +83,13 @@ var roundtripTestFragTmpl = template.Must(template.New("roundtripTest").Funcs(te
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+{{- if .IsEventStream}}
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, "data: {}\n\n")
+{{- else}}
 			w.WriteHeader(http.StatusOK)
+{{- end}}
 {{- if .RespFixture}}
 			_, _ = io.WriteString(w, ` + "`" + `{{.RespFixture}}` + "`" + `)
 {{- end}}
@@ -96,7 +107,16 @@ var roundtripTestFragTmpl = template.Must(template.New("roundtripTest").Funcs(te
 		require.NoError(t, err)
 		t.Cleanup(func() { _ = client.Close() })
 {{- end}}
-
+{{if .IsEventStream}}
+		stream, err := {{.CallExpr}}
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = stream.Close() })
+		require.Equal(t, http.StatusOK, stream.Response().StatusCode)
+		_, err = stream.Next()
+		require.NoError(t, err)
+		_, err = stream.Next()
+		require.ErrorIs(t, err, io.EOF)
+{{- else}}
 		resp, err := {{.CallExpr}}
 		require.NoError(t, err)
 		require.NotNil(t, resp)
@@ -104,6 +124,7 @@ var roundtripTestFragTmpl = template.Must(template.New("roundtripTest").Funcs(te
 		require.Greater(t, resp.StatusCode, 0)
 {{- else}}
 		require.NotNil(t, resp.Inspect().Response)
+{{- end}}
 {{- end}}
 	})
 
@@ -130,6 +151,9 @@ var roundtripTestFragTmpl = template.Must(template.New("roundtripTest").Funcs(te
 		resp, err := {{.ErrCallExpr}}
 		require.Error(t, err)
 		require.NotNil(t, resp)
+{{- if .IsEventStream}}
+		require.Equal(t, http.StatusBadRequest, resp.Response().StatusCode)
+{{- end}}
 	})
 }
 `))
@@ -289,6 +289,11 @@ type IntegTestConfig struct {
 	// the test uses.
 	IsNoBody bool
 
+	// IsEventStream is true when the operation returns an
+	// *opensearch.EventStream. The test drains the stream instead of comparing
+	// raw and parsed JSON.
+	IsEventStream bool
+
 	// IsPlugin is true when the operation belongs to a plugin package rather
 	// than the core opensearchapi package. Affects client construction and imports.
 	IsPlugin bool
@@ -344,7 +349,7 @@ func (f *IntegTestFragment) Imports() []Import {
 		imps = append(imps, Import{Path: "time"})
 	}
 
-	needImportPkg := cfg.FixtureCode != "" || strings.Contains(cfg.CallExpr, f.PkgName+".")
+	needImportPkg := cfg.FixtureCode != "" || cfg.IsEventStream || strings.Contains(cfg.CallExpr, f.PkgName+".")
 	if needImportPkg {
 		imps = append(imps, Import{Path: f.ImportPath})
 	}
@@ -353,7 +358,7 @@ func (f *IntegTestFragment) Imports() []Import {
 		imps = append(imps, Import{Path: coreImportPath(f.CorePkg, f.ModulePath)})
 	}
 
-	needTestutil := !cfg.IsPlugin || cfg.VersionAdded != "" || !cfg.IsNoBody || cfg.FixtureCode != "" ||
+	needTestutil := !cfg.IsPlugin || cfg.VersionAdded != "" || (!cfg.IsNoBody && !cfg.IsEventStream) || cfg.FixtureCode != "" ||
 		cfg.NeedsIndex || cfg.NeedsDocID || cfg.NeedsName || cfg.HasFlag(TestWaitReady)
 	if needTestutil {
 		imps = append(imps, Import{Path: coreImportPath(f.CorePkg, f.ModulePath) + "/testutil"})
@@ -433,7 +438,14 @@ var integTestFragTmpl = template.Must(template.New("integTest").Funcs(template.F
 {{- end}}
 
 	t.Run("success", func(t *testing.T) {
-{{- if .Config.IsNoBody}}
+{{- if .Config.IsEventStream}}
+		stream, err := {{.Config.CallExpr}}
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = stream.Close() })
+		for _, err := range stream.All() {
+			require.NoError(t, err)
+		}
+{{- else if .Config.IsNoBody}}
 		resp, err := {{.Config.CallExpr}}
 		require.NoError(t, err)
 		require.NotNil(t, resp)
@@ -458,10 +470,12 @@ var integTestFragTmpl = template.Must(template.New("integTest").Funcs(template.F
 		res, err := {{.Config.FailCallExpr}}
 		require.Error(t, err)
 		require.NotNil(t, res)
+{{- $inspect := "res.Inspect()"}}
+{{- if .Config.IsEventStream}}{{$inspect = printf "%s.Inspect{Response: res.Response()}" .PkgName}}{{end}}
 {{- if .Config.IsPlugin}}
-		plugintest.VerifyInspect(t, res.Inspect())
+		plugintest.VerifyInspect(t, {{$inspect}})
 {{- else}}
-		osapitest.VerifyInspect(t, res.Inspect())
+		osapitest.VerifyInspect(t, {{$inspect}})
 {{- end}}
 	})
 {{- end}}
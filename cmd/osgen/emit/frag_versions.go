@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package emit
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/opensearch-project/opensearch-go/v5/cmd/osgen/ir"
+)
+
+// versionFloor is the oldest OpenSearch release. Anything added at or
+// before it is available on every server and is left out of the table.
+const versionFloor = "1.0.0"
+
+// VersionsFragment renders operationVersions for versions_gen.go: a type
+// switch from each core Req type to the server version the operation
+// needs, plus the query parameters and top-level body fields added after
+// it. The hand-written version gate in the core package consults it before
+// sending a request.
+//
+// Operations available on every version with no newer parameters or fields
+// are omitted, so the switch falls through to nil for them.
+type VersionsFragment struct {
+	Operations []*ir.Operation
+}
+
+// versionedOp is one row of the rendered table.
+type versionedOp struct {
+	ReqType string
+	Group   string
+	Added   string // "" when the operation itself predates versionFloor
+	Params  []versionedName
+	Fields  []versionedName
+}
+
+// versionedName is a query parameter or body field newer than its operation.
+type versionedName struct {
+	Name     string
+	Added    string
+	Required bool
+}
+
+// Imports returns the imports the versions fragment needs.
+func (f *VersionsFragment) Imports() []Import {
+	return []Import{
+		{Path: "github.com/opensearch-project/opensearch-go/v5"},
+	}
+}
+
+// Body renders operationVersions. Returns an empty string when no core
+// operation is version-gated so the file is suppressed.
+func (f *VersionsFragment) Body() (string, error) {
+	rows := f.rows()
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`// operationVersions returns the OpenSearch versions the parts of req need,
+// or nil when the operation, its query parameters, and its body fields are
+// available on every version.
+func operationVersions(req opensearch.Request) *operationVersion {
+	switch req.(type) {
+`)
+	for _, r := range rows {
+		fmt.Fprintf(&sb, "\tcase %[1]s, *%[1]s:\n", r.ReqType)
+		fmt.Fprintf(&sb, "\t\treturn &operationVersion{\n\t\t\tName: %q,\n", r.Group)
+		if r.Added != "" {
+			fmt.Fprintf(&sb, "\t\t\tAdded: %q,\n", r.Added)
+		}
+		writeVersionedNames(&sb, "Params", r.Params)
+		writeVersionedNames(&sb, "Fields", r.Fields)
+		sb.WriteString("\t\t}\n")
+	}
+	sb.WriteString("\t}\n\treturn nil\n}\n")
+	return sb.String(), nil
+}
+
+func writeVersionedNames(sb *strings.Builder, field string, names []versionedName) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\t\t\t%s: []versionedName{\n", field)
+	for _, n := range names {
+		if n.Required {
+			fmt.Fprintf(sb, "\t\t\t\t{Name: %q, Added: %q, Required: true},\n", n.Name, n.Added)
+		} else {
+			fmt.Fprintf(sb, "\t\t\t\t{Name: %q, Added: %q},\n", n.Name, n.Added)
+		}
+	}
+	sb.WriteString("\t\t\t},\n")
+}
+
+// rows collects the version-gated core operations in spec order.
+func (f *VersionsFragment) rows() []versionedOp {
+	var rows []versionedOp
+	for _, op := range f.Operations {
+		if op.IsPlugin {
+			continue
+		}
+		row := versionedOp{ReqType: op.TypePrefix + "Req", Group: op.Group}
+		base := versionFloor
+		if newerThan(op.VersionAdded, base) {
+			row.Added = NormalizeSemver(op.VersionAdded)
+			base = row.Added
+		}
+		for _, p := range op.QueryParams {
+			if newerThan(p.VersionAdded, base) {
+				row.Params = append(row.Params, versionedName{
+					Name: p.WireName, Added: NormalizeSemver(p.VersionAdded), Required: p.Required,
+				})
+			}
+		}
+		if op.HasTypedBody && op.RequestBody != nil {
+			for _, fld := range op.RequestBody.Fields {
+				if !fld.IsEmbed && fld.JSONName != "" && newerThan(fld.VersionAdded, base) {
+					row.Fields = append(row.Fields, versionedName{
+						Name: fld.JSONName, Added: NormalizeSemver(fld.VersionAdded),
+					})
+				}
+			}
+		}
+		if row.Added != "" || len(row.Params) > 0 || len(row.Fields) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// newerThan reports whether the spec version v is set and later than base.
+func newerThan(v, base string) bool {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return false
+	}
+	return semver.Compare("v"+NormalizeSemver(v), "v"+NormalizeSemver(base)) > 0
+}
+
+// NewVersionsFile returns the versions_gen.go target for the core package,
+// or nil when no core operation is version-gated.
+func NewVersionsFile(outDir, pkg string, ops []*ir.Operation) Target {
+	frag := &VersionsFragment{Operations: ops}
+	if len(frag.rows()) == 0 {
+		return nil
+	}
+	return &File{
+		FilePath:  outDir + "/versions_gen.go",
+		Package:   pkg,
+		Fragments: []Fragment{frag},
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package emit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/cmd/osgen/emit"
+	"github.com/opensearch-project/opensearch-go/v5/cmd/osgen/ir"
+)
+
+func versionedOps() []*ir.Operation {
+	return []*ir.Operation{
+		{
+			Group: "bulk_stream", TypePrefix: "BulkStream", VersionAdded: "2.17",
+			QueryParams: []ir.QueryParam{{WireName: "refresh", VersionAdded: "2.17"}},
+		},
+		{
+			Group: "search", TypePrefix: "Search", VersionAdded: "1.0",
+			QueryParams: []ir.QueryParam{
+				{WireName: "q", VersionAdded: "1.0"},
+				{WireName: "phase_took", VersionAdded: "2.12"},
+				{WireName: "search_pipeline", VersionAdded: "2.9", Required: true},
+			},
+			HasTypedBody: true,
+			RequestBody: &ir.Type{Fields: []ir.Field{
+				{GoName: "Query", JSONName: "query"},
+				{GoName: "Ext", JSONName: "ext", VersionAdded: "2.10.0"},
+			}},
+		},
+		{Group: "index", TypePrefix: "Index", VersionAdded: "1.0"},
+		{Group: "security.get_user", TypePrefix: "GetUser", VersionAdded: "2.0", IsPlugin: true},
+	}
+}
+
+func TestVersionsFragment_Body(t *testing.T) {
+	t.Parallel()
+
+	frag := &emit.VersionsFragment{Operations: versionedOps()}
+	body, err := frag.Body()
+	require.NoError(t, err)
+
+	checks := []struct {
+		name   string
+		want   string
+		absent bool
+	}{
+		{name: "function", want: "func operationVersions(req opensearch.Request) *operationVersion {"},
+		{name: "value and pointer case", want: "case BulkStreamReq, *BulkStreamReq:"},
+		{name: "operation version", want: `Added: "2.17.0",`},
+		{name: "param as old as its operation", want: `"refresh"`, absent: true},
+		{name: "operation at the floor", want: `Added: "1.0.0"`, absent: true},
+		{name: "newer param", want: `{Name: "phase_took", Added: "2.12.0"},`},
+		{name: "required param", want: `{Name: "search_pipeline", Added: "2.9.0", Required: true},`},
+		{name: "param at the floor", want: `"q"`, absent: true},
+		{name: "newer body field", want: `{Name: "ext", Added: "2.10.0"},`},
+		{name: "body field at the floor", want: `"query"`, absent: true},
+		{name: "ungated operation", want: "IndexReq", absent: true},
+		{name: "plugin operation", want: "GetUserReq", absent: true},
+	}
+
+	for _, tc := range checks {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if tc.absent {
+				require.NotContains(t, body, tc.want, "should not contain %q", tc.want)
+			} else {
+				require.Contains(t, body, tc.want, "missing %q", tc.want)
+			}
+		})
+	}
+}
+
+func TestNewVersionsFile_Render(t *testing.T) {
+	t.Parallel()
+
+	target := emit.NewVersionsFile("/tmp/test", ir.DefaultCorePkgName, versionedOps())
+	require.NotNil(t, target)
+
+	src, err := target.Render()
+	require.NoError(t, err)
+
+	output := string(src)
+	require.Contains(t, output, "package "+ir.DefaultCorePkgName)
+	require.Contains(t, output, `"github.com/opensearch-project/opensearch-go/v5"`)
+}
+
+func TestNewVersionsFile_NilWhenUngated(t *testing.T) {
+	t.Parallel()
+
+	ops := []*ir.Operation{{Group: "index", TypePrefix: "Index", VersionAdded: "1.0"}}
+	require.Nil(t, emit.NewVersionsFile("/tmp/test", ir.DefaultCorePkgName, ops))
+}
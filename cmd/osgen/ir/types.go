@@ -91,6 +91,13 @@ type Operation struct {
 	IsPointerReq   bool
 	IsNoBody       bool
 
+	// IsEventStream marks an operation whose success response is a
+	// text/event-stream. Its client method returns an event stream instead of a
+	// buffered Resp, and EventRef names the schema each event's data decodes
+	// into ("" when the spec gives none).
+	IsEventStream bool
+	EventRef      string
+
 	// ErrorWrappers lists the partial-failure wrapper-schema names this
 	// operation may surface alongside its primary success response.
 	// Mirrors the proposed `x-error-responses` OpenAPI extension; until
@@ -56,6 +56,8 @@ func convertOperation(op *apiOperation) *ir.Operation {
 		IsNDJSON:          op.IsNDJSON,
 		IsPointerReq:      op.IsPointerReq,
 		IsNoBody:          op.IsNoBody,
+		IsEventStream:     op.IsEventStream,
+		EventRef:          op.EventRef,
 		IsPlugin:          !coreGroups[groupPrefix(op.Group)],
 		ResponseRef:       op.ResponseRef,
 		ErrorWrappers:     resolveErrorWrappers(op),
@@ -112,9 +114,10 @@ func convertOperation(op *apiOperation) *ir.Operation {
 	}
 
 	// Convert response fields into a Type if present, or create an empty
-	// response type for non-NoBody operations (the template always adds the
-	// private response field).
-	if !op.IsNoBody {
+	// response type for operations with a buffered body (the template always
+	// adds the private response field). Event streams return their events
+	// through *opensearch.EventStream and have no Resp type.
+	if !op.IsNoBody && !op.IsEventStream {
 		respType := &ir.Type{
 			Name:       op.TypePrefix + "Resp",
 			SchemaRef:  op.ResponseRef,
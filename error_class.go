@@ -0,0 +1,432 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearch
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrorClass is a class of OpenSearch error responses, such as a missing index
+// or a rejected execution. The errors returned by [ParseError] match their
+// class with errors.Is, whatever the shape of the response body:
+//
+//	if errors.Is(err, opensearch.ErrIndexNotFound) { ... }
+//
+// A class also carries retry hints, reported by the parsed error as well.
+type ErrorClass struct {
+	name      string
+	types     []string
+	retryable bool
+	temporary bool
+}
+
+// Error returns the name of the class.
+func (c *ErrorClass) Error() string {
+	return "opensearch: " + c.name
+}
+
+// Retryable reports whether resending the same request may succeed.
+func (c *ErrorClass) Retryable() bool {
+	return c.retryable
+}
+
+// Temporary reports whether the condition is expected to clear without
+// intervention, such as an overloaded node shedding load.
+func (c *ErrorClass) Temporary() bool {
+	return c.temporary
+}
+
+// Types returns the OpenSearch exception types, as in the error "type" field,
+// that belong to the class.
+func (c *ErrorClass) Types() []string {
+	return append([]string(nil), c.types...)
+}
+
+// Error classes. Each matches the exception types listed with it, found in
+// the type, root_cause, or caused_by chain of the response.
+var (
+	// ErrIndexNotFound: index_not_found_exception.
+	ErrIndexNotFound = &ErrorClass{name: "index not found", types: []string{"index_not_found_exception"}}
+	// ErrIndexClosed: index_closed_exception.
+	ErrIndexClosed = &ErrorClass{name: "index closed", types: []string{"index_closed_exception"}}
+	// ErrResourceNotFound: resource_not_found_exception.
+	ErrResourceNotFound = &ErrorClass{name: "resource not found", types: []string{"resource_not_found_exception"}}
+	// ErrDocumentMissing: document_missing_exception, from an update of a
+	// missing document.
+	ErrDocumentMissing = &ErrorClass{name: "document missing", types: []string{"document_missing_exception"}}
+	// ErrVersionConflict: version_conflict_engine_exception. The request
+	// succeeds only after re-reading the document, so it is not retryable
+	// as is.
+	ErrVersionConflict = &ErrorClass{name: "version conflict", types: []string{"version_conflict_engine_exception"}}
+	// ErrResourceAlreadyExists: resource_already_exists_exception.
+	ErrResourceAlreadyExists = &ErrorClass{name: "resource already exists", types: []string{"resource_already_exists_exception"}}
+	// ErrInvalidIndexName: invalid_index_name_exception.
+	ErrInvalidIndexName = &ErrorClass{name: "invalid index name", types: []string{"invalid_index_name_exception"}}
+	// ErrMapperParsing: mapper_parsing_exception, strict_dynamic_mapping_exception,
+	// and document_parsing_exception, from a document that does not fit the
+	// mapping.
+	ErrMapperParsing = &ErrorClass{name: "mapper parsing", types: []string{
+		"mapper_parsing_exception", "strict_dynamic_mapping_exception", "document_parsing_exception",
+	}}
+	// ErrParsing: parsing_exception and x_content_parse_exception, from a
+	// malformed request body.
+	ErrParsing = &ErrorClass{name: "parsing", types: []string{"parsing_exception", "x_content_parse_exception"}}
+	// ErrIllegalArgument: illegal_argument_exception.
+	ErrIllegalArgument = &ErrorClass{name: "illegal argument", types: []string{"illegal_argument_exception"}}
+	// ErrTooManyClauses: too_many_clauses and too_many_nested_clauses, from a
+	// query over the clause limit.
+	ErrTooManyClauses = &ErrorClass{name: "too many clauses", types: []string{"too_many_clauses", "too_many_nested_clauses"}}
+	// ErrSecurityUnauthorized: a security_exception with status 401, or a
+	// security plugin UNAUTHORIZED response.
+	ErrSecurityUnauthorized = &ErrorClass{name: "security unauthorized"}
+	// ErrSecurityForbidden: a security_exception with any other status, or a
+	// security plugin FORBIDDEN response.
+	ErrSecurityForbidden = &ErrorClass{name: "security forbidden", types: []string{"security_exception"}}
+
+	// ErrCircuitBreaking: circuit_breaking_exception, from a node short of
+	// memory.
+	ErrCircuitBreaking = &ErrorClass{
+		name:      "circuit breaking",
+		types:     []string{"circuit_breaking_exception"},
+		retryable: true,
+		temporary: true,
+	}
+	// ErrRejectedExecution: rejected_execution_exception and its
+	// es_/opensearch_ variants, from a full thread pool queue.
+	ErrRejectedExecution = &ErrorClass{
+		name:      "rejected execution",
+		types:     []string{"rejected_execution_exception", "es_rejected_execution_exception", "opensearch_rejected_execution_exception"},
+		retryable: true,
+		temporary: true,
+	}
+	// ErrClusterBlock: cluster_block_exception, such as the read-only block
+	// set when a node runs out of disk.
+	ErrClusterBlock = &ErrorClass{
+		name:      "cluster block",
+		types:     []string{"cluster_block_exception"},
+		retryable: true,
+		temporary: true,
+	}
+	// ErrShardUnavailable: no_shard_available_action_exception,
+	// unavailable_shards_exception, and primary_missing_action_exception.
+	ErrShardUnavailable = &ErrorClass{
+		name:      "shard unavailable",
+		types:     []string{"no_shard_available_action_exception", "unavailable_shards_exception", "primary_missing_action_exception"},
+		retryable: true,
+		temporary: true,
+	}
+	// ErrNodeDisconnected: node_disconnected_exception,
+	// node_not_connected_exception, connect_transport_exception, and
+	// receive_timeout_transport_exception, from a node leaving the cluster.
+	ErrNodeDisconnected = &ErrorClass{
+		name: "node disconnected",
+		types: []string{
+			"node_disconnected_exception", "node_not_connected_exception",
+			"connect_transport_exception", "receive_timeout_transport_exception",
+		},
+		retryable: true,
+		temporary: true,
+	}
+	// ErrClusterEventTimeout: process_cluster_event_timeout_exception, from a
+	// busy cluster manager.
+	ErrClusterEventTimeout = &ErrorClass{
+		name:      "cluster event timeout",
+		types:     []string{"process_cluster_event_timeout_exception"},
+		retryable: true,
+		temporary: true,
+	}
+)
+
+// errorClasses indexes the classes by exception type.
+var errorClasses = func() map[string]*ErrorClass {
+	m := make(map[string]*ErrorClass)
+	for _, c := range []*ErrorClass{
+		ErrIndexNotFound, ErrIndexClosed, ErrResourceNotFound, ErrDocumentMissing,
+		ErrVersionConflict, ErrResourceAlreadyExists, ErrInvalidIndexName,
+		ErrMapperParsing, ErrParsing, ErrIllegalArgument, ErrTooManyClauses,
+		ErrSecurityForbidden, ErrCircuitBreaking, ErrRejectedExecution,
+		ErrClusterBlock, ErrShardUnavailable, ErrNodeDisconnected, ErrClusterEventTimeout,
+	} {
+		for _, typ := range c.types {
+			m[typ] = c
+		}
+	}
+	return m
+}()
+
+// ErrorClassOf returns the class of an OpenSearch exception type, such as
+// the type of a bulk item error, or nil when the type has no class.
+func ErrorClassOf(typ string) *ErrorClass {
+	return errorClasses[typ]
+}
+
+// IsRetryable reports whether err, or an error it wraps, is an OpenSearch
+// error that may succeed when the same request is sent again.
+func IsRetryable(err error) bool {
+	var r interface{ Retryable() bool }
+	return errors.As(err, &r) && r.Retryable()
+}
+
+// IsTemporary reports whether err, or an error it wraps, is an OpenSearch
+// error expected to clear without intervention.
+func IsTemporary(err error) bool {
+	var t interface{ Temporary() bool }
+	return errors.As(err, &t) && t.Temporary()
+}
+
+// Class returns the class of the error: that of its type, else of the first
+// classified root cause, else of the first classified cause. It is nil when
+// none has a class.
+func (e StructError) Class() *ErrorClass {
+	var class *ErrorClass
+	e.eachType(func(typ string) bool {
+		class = errorClasses[typ]
+		return class == nil
+	})
+	return securityClass(class, e.Status)
+}
+
+// Is reports whether target is an [ErrorClass] of the error's type, root
+// causes, or causes.
+func (e StructError) Is(target error) bool {
+	c, ok := target.(*ErrorClass)
+	if !ok {
+		return false
+	}
+	var match bool
+	e.eachType(func(typ string) bool {
+		match = securityClass(errorClasses[typ], e.Status) == c
+		return !match
+	})
+	return match
+}
+
+// Retryable reports whether resending the request may succeed, by the
+// error's class or, for an unclassified error, its status.
+func (e StructError) Retryable() bool {
+	return classRetryable(e.Class(), e.Status)
+}
+
+// Temporary reports whether the error is expected to clear without
+// intervention, by its class or, for an unclassified error, its status.
+func (e StructError) Temporary() bool {
+	return classTemporary(e.Class(), e.Status)
+}
+
+// eachType calls fn with the type, the root cause types, and the caused_by
+// chain types of the error, in order, until fn returns false.
+func (e StructError) eachType(fn func(typ string) bool) {
+	if e.Err.Type != "" && !fn(e.Err.Type) {
+		return
+	}
+	for _, rc := range e.Err.RootCause {
+		if rc.Type != "" && !fn(rc.Type) {
+			return
+		}
+	}
+	for c := e.Err.CausedBy; c != nil; c = c.CausedBy {
+		if c.Type != "" && !fn(c.Type) {
+			return
+		}
+	}
+}
+
+// Class returns the class named in the error text, or that of its status
+// for a 401 or 403. It is nil when neither has a class.
+func (e StringError) Class() *ErrorClass {
+	if c := classInText(e.Err); c != nil {
+		return securityClass(c, e.Status)
+	}
+	return statusClass(e.Status)
+}
+
+// Is reports whether target is the class of the error.
+func (e StringError) Is(target error) bool {
+	return target != nil && e.Class() == target
+}
+
+// Retryable reports whether resending the request may succeed.
+func (e StringError) Retryable() bool {
+	return classRetryable(e.Class(), e.Status)
+}
+
+// Temporary reports whether the error is expected to clear without
+// intervention.
+func (e StringError) Temporary() bool {
+	return classTemporary(e.Class(), e.Status)
+}
+
+// Class returns the class named in the error text, or nil.
+func (e Error) Class() *ErrorClass {
+	return classInText(e.Err)
+}
+
+// Is reports whether target is the class of the error.
+func (e Error) Is(target error) bool {
+	return target != nil && e.Class() == target
+}
+
+// Retryable reports whether resending the request may succeed.
+func (e Error) Retryable() bool {
+	return classRetryable(e.Class(), 0)
+}
+
+// Temporary reports whether the error is expected to clear without
+// intervention.
+func (e Error) Temporary() bool {
+	return classTemporary(e.Class(), 0)
+}
+
+// Class returns the class of the error's status, such as
+// [ErrSecurityForbidden] for FORBIDDEN, else the class named in its reason.
+func (e ReasonError) Class() *ErrorClass {
+	if c := statusNameClass(e.Status); c != nil {
+		return c
+	}
+	return classInText(e.Reason)
+}
+
+// Is reports whether target is the class of the error.
+func (e ReasonError) Is(target error) bool {
+	return target != nil && e.Class() == target
+}
+
+// Retryable reports whether resending the request may succeed.
+func (e ReasonError) Retryable() bool {
+	return classRetryable(e.Class(), statusNameCode(e.Status))
+}
+
+// Temporary reports whether the error is expected to clear without
+// intervention.
+func (e ReasonError) Temporary() bool {
+	return classTemporary(e.Class(), statusNameCode(e.Status))
+}
+
+// Class returns the class of the error's status, such as
+// [ErrSecurityForbidden] for FORBIDDEN, else the class named in its message.
+func (e MessageError) Class() *ErrorClass {
+	if c := statusNameClass(e.Status); c != nil {
+		return c
+	}
+	return classInText(e.Message)
+}
+
+// Is reports whether target is the class of the error.
+func (e MessageError) Is(target error) bool {
+	return target != nil && e.Class() == target
+}
+
+// Retryable reports whether resending the request may succeed.
+func (e MessageError) Retryable() bool {
+	return classRetryable(e.Class(), statusNameCode(e.Status))
+}
+
+// Temporary reports whether the error is expected to clear without
+// intervention.
+func (e MessageError) Temporary() bool {
+	return classTemporary(e.Class(), statusNameCode(e.Status))
+}
+
+// securityClass splits security_exception by status: a 401 is
+// [ErrSecurityUnauthorized], anything else [ErrSecurityForbidden].
+func securityClass(c *ErrorClass, status int) *ErrorClass {
+	if c == ErrSecurityForbidden && status == http.StatusUnauthorized {
+		return ErrSecurityUnauthorized
+	}
+	return c
+}
+
+// statusClass returns the class of a 401 or 403 status without a type.
+func statusClass(status int) *ErrorClass {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrSecurityUnauthorized
+	case http.StatusForbidden:
+		return ErrSecurityForbidden
+	default:
+		return nil
+	}
+}
+
+// statusNameClass returns the class of a status name, as the security
+// plugin reports it.
+func statusNameClass(status string) *ErrorClass {
+	return statusClass(statusNameCode(status))
+}
+
+// statusNameCode returns the HTTP status of a status name such as
+// "FORBIDDEN" or "TOO_MANY_REQUESTS", or 0 for an unknown name.
+func statusNameCode(status string) int {
+	switch status {
+	case "UNAUTHORIZED":
+		return http.StatusUnauthorized
+	case "FORBIDDEN":
+		return http.StatusForbidden
+	case "TOO_MANY_REQUESTS":
+		return http.StatusTooManyRequests
+	case "SERVICE_UNAVAILABLE":
+		return http.StatusServiceUnavailable
+	default:
+		return 0
+	}
+}
+
+// classInText returns the class of the first exception type named in s, as
+// in the flattened "[index_not_found_exception] no such index" form.
+func classInText(s string) *ErrorClass {
+	for {
+		i := strings.IndexByte(s, '_')
+		if i < 0 {
+			return nil
+		}
+		start := i
+		for start > 0 && isTypeByte(s[start-1]) {
+			start--
+		}
+		end := i
+		for end < len(s) && isTypeByte(s[end]) {
+			end++
+		}
+		if c := errorClasses[s[start:end]]; c != nil {
+			return c
+		}
+		s = s[end:]
+	}
+}
+
+func isTypeByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z')
+}
+
+// classRetryable returns the hint of the class or, without one, of the
+// status: 429, 502, 503, and 504 are retryable.
+func classRetryable(c *ErrorClass, status int) bool {
+	if c != nil {
+		return c.retryable
+	}
+	return transientStatus(status)
+}
+
+// classTemporary returns the hint of the class or, without one, of the
+// status.
+func classTemporary(c *ErrorClass, status int) bool {
+	if c != nil {
+		return c.temporary
+	}
+	return transientStatus(status)
+}
+
+func transientStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -336,3 +336,139 @@ func TestParseError_BodyState(t *testing.T) {
 		})
 	}
 }
+
+func TestParseError_Classes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       error
+		notWant    []error
+		retryable  bool
+	}{
+		{
+			name:       "struct error type",
+			statusCode: http.StatusNotFound,
+			body: `{"error":{"type":"index_not_found_exception","reason":"no such index [logs]",
+				"root_cause":[{"type":"index_not_found_exception","reason":"no such index [logs]"}]},"status":404}`,
+			want:    opensearch.ErrIndexNotFound,
+			notWant: []error{opensearch.ErrResourceNotFound, opensearch.ErrVersionConflict},
+		},
+		{
+			name:       "struct error root cause",
+			statusCode: http.StatusBadRequest,
+			body: `{"error":{"type":"search_phase_execution_exception","reason":"all shards failed",
+				"root_cause":[{"type":"too_many_nested_clauses","reason":"maxClauseCount is set to 1024"}]},"status":400}`,
+			want: opensearch.ErrTooManyClauses,
+		},
+		{
+			name:       "struct error caused by",
+			statusCode: http.StatusTooManyRequests,
+			body: `{"error":{"type":"remote_transport_exception","reason":"[node-1]",
+				"caused_by":{"type":"transport_exception","reason":"wrapped",
+				"caused_by":{"type":"es_rejected_execution_exception","reason":"rejected execution"}}},"status":429}`,
+			want:      opensearch.ErrRejectedExecution,
+			retryable: true,
+		},
+		{
+			name:       "circuit breaking",
+			statusCode: http.StatusTooManyRequests,
+			body:       `{"error":{"type":"circuit_breaking_exception","reason":"[parent] Data too large"},"status":429}`,
+			want:       opensearch.ErrCircuitBreaking,
+			retryable:  true,
+		},
+		{
+			name:       "version conflict",
+			statusCode: http.StatusConflict,
+			body:       `{"error":{"type":"version_conflict_engine_exception","reason":"[1]: version conflict"},"status":409}`,
+			want:       opensearch.ErrVersionConflict,
+		},
+		{
+			name:       "security exception unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":{"type":"security_exception","reason":"missing authentication credentials"},"status":401}`,
+			want:       opensearch.ErrSecurityUnauthorized,
+			notWant:    []error{opensearch.ErrSecurityForbidden},
+		},
+		{
+			name:       "security exception forbidden",
+			statusCode: http.StatusForbidden,
+			body:       `{"error":{"type":"security_exception","reason":"no permissions"},"status":403}`,
+			want:       opensearch.ErrSecurityForbidden,
+		},
+		{
+			name:       "string error names a type",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":"[mapper_parsing_exception] failed to parse field [year]","status":400}`,
+			want:       opensearch.ErrMapperParsing,
+		},
+		{
+			name:       "string error status",
+			statusCode: http.StatusForbidden,
+			body:       `{"error":"no permissions for [indices:data/read/search]","status":403}`,
+			want:       opensearch.ErrSecurityForbidden,
+		},
+		{
+			name:       "message error status name",
+			statusCode: http.StatusForbidden,
+			body:       `{"status":"FORBIDDEN","message":"no permissions for [cluster:monitor/health]"}`,
+			want:       opensearch.ErrSecurityForbidden,
+		},
+		{
+			name:       "reason error status name",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"status":"UNAUTHORIZED","reason":"authentication required"}`,
+			want:       opensearch.ErrSecurityUnauthorized,
+		},
+		{
+			name:       "error field names a type",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":"resource_already_exists_exception: index [logs] already exists"}`,
+			want:       opensearch.ErrResourceAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := opensearch.NewResponse(tt.statusCode, io.NopCloser(strings.NewReader(tt.body)), nil)
+			err := fmt.Errorf("wrapped: %w", opensearch.ParseError(resp))
+			require.ErrorIs(t, err, tt.want)
+			for _, notWant := range tt.notWant {
+				require.NotErrorIs(t, err, notWant)
+			}
+			require.Equal(t, tt.retryable, opensearch.IsRetryable(err))
+			require.Equal(t, tt.retryable, opensearch.IsTemporary(err))
+		})
+	}
+}
+
+func TestParseError_UnclassifiedHints(t *testing.T) {
+	t.Parallel()
+
+	resp := opensearch.NewResponse(http.StatusServiceUnavailable, io.NopCloser(strings.NewReader(
+		`{"error":{"type":"master_not_discovered_exception","reason":"no cluster manager"},"status":503}`)), nil)
+	err := opensearch.ParseError(resp)
+
+	var structErr *opensearch.StructError
+	require.ErrorAs(t, err, &structErr)
+	require.Nil(t, structErr.Class())
+	require.True(t, opensearch.IsRetryable(err), "a 503 without a class is retryable by status")
+
+	resp = opensearch.NewResponse(http.StatusBadRequest, io.NopCloser(strings.NewReader(
+		`{"error":{"type":"action_request_validation_exception","reason":"Validation Failed"},"status":400}`)), nil)
+	require.False(t, opensearch.IsRetryable(opensearch.ParseError(resp)))
+	require.False(t, opensearch.IsRetryable(errors.New("plain")))
+}
+
+func TestErrorClassOf(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, opensearch.ErrRejectedExecution, opensearch.ErrorClassOf("opensearch_rejected_execution_exception"))
+	require.True(t, opensearch.ErrorClassOf("cluster_block_exception").Retryable())
+	require.Nil(t, opensearch.ErrorClassOf("unknown_exception"))
+	require.Contains(t, opensearch.ErrMapperParsing.Types(), "strict_dynamic_mapping_exception")
+	require.Equal(t, "opensearch: index not found", opensearch.ErrIndexNotFound.Error())
+}
@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by [EventStream].
+var (
+	// ErrEventTimeout is returned when no event arrives within
+	// [StreamOptions.EventTimeout].
+	ErrEventTimeout = errors.New("timed out waiting for stream event")
+
+	// ErrEventStreamClosed is returned by [EventStream.Next] after the stream
+	// was closed by the caller.
+	ErrEventStreamClosed = errors.New("event stream closed")
+)
+
+// StreamOptions configures a server-sent event stream opened with
+// [ExecuteEventStream]. A nil *StreamOptions uses the defaults.
+type StreamOptions struct {
+	// EventTimeout bounds how long the stream waits for the response headers
+	// and then for each event while Next is blocked. Time spent by the caller
+	// between Next calls does not count. When it elapses the request is
+	// cancelled and Next returns [ErrEventTimeout]. Zero waits indefinitely.
+	EventTimeout time.Duration
+}
+
+// Event is a single server-sent event in the text/event-stream format.
+type Event struct {
+	// ID is the last event ID seen on the stream, which persists across
+	// events until the server sends a new one.
+	ID string
+	// Type is the event field; empty for the default "message" type.
+	Type string
+	// Data holds the event's data lines joined with "\n".
+	Data []byte
+	// Retry is the reconnection time the server requested, if any.
+	Retry time.Duration
+}
+
+// EventDecoder reads server-sent events from a text/event-stream body.
+// Lines may end in LF or CRLF. Comment lines and events without data are
+// skipped.
+type EventDecoder struct {
+	r      *bufio.Reader
+	lastID string
+}
+
+// NewEventDecoder returns a decoder reading from r.
+func NewEventDecoder(r io.Reader) *EventDecoder {
+	return &EventDecoder{r: bufio.NewReader(r)}
+}
+
+// Next returns the next event. It returns io.EOF once the stream ends; an
+// event that is not terminated by a blank line before the end is discarded.
+func (d *EventDecoder) Next() (Event, error) {
+	var (
+		ev      Event
+		data    bytes.Buffer
+		hasData bool
+	)
+	for {
+		line, err := d.r.ReadString('\n')
+		if err != nil {
+			// A partial final line without its terminator is incomplete.
+			return Event{}, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			if !hasData {
+				ev = Event{}
+				continue
+			}
+			ev.ID = d.lastID
+			ev.Data = bytes.TrimSuffix(data.Bytes(), []byte("\n"))
+			return ev, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			ev.Type = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				d.lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				ev.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// EventStream is a typed server-sent event stream. Each event's data is
+// decoded from JSON into T. The stream holds an open connection until it
+// reaches the end, fails, or is closed; callers must call Close (or range
+// over All, which closes on return).
+//
+// Next must not be called concurrently. Close may be called from another
+// goroutine to abort a blocked Next.
+type EventStream[T any] struct {
+	resp    *Response
+	body    io.ReadCloser
+	dec     *EventDecoder
+	last    Event
+	err     error
+	timeout time.Duration
+	timer   *time.Timer
+
+	ctx       context.Context //nolint:containedctx // scoped to the stream's lifetime and cancelled by Close
+	cancel    context.CancelCauseFunc
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// ExecuteEventStream performs the request through [Client.Stream] and returns
+// a stream of its server-sent events. Cancelling ctx aborts the stream.
+//
+// When the server answers with an error status, the body is buffered, the
+// connection is released, and ExecuteEventStream returns the closed stream
+// together with the [ParseError] result; the stream's Response carries the
+// status and body.
+func ExecuteEventStream[T any](ctx context.Context, c *Client, method string, req Request, opts *StreamOptions) (*EventStream[T], error) {
+	httpReq, err := req.GetRequest(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s := &EventStream[T]{}
+	s.ctx, s.cancel = context.WithCancelCause(ctx)
+	if opts != nil && opts.EventTimeout > 0 {
+		s.timeout = opts.EventTimeout
+		s.timer = time.AfterFunc(s.timeout, func() { s.cancel(ErrEventTimeout) })
+	}
+
+	httpReq = httpReq.WithContext(s.ctx)
+	c.ensureReqHeader(httpReq)
+	if httpReq.Header.Get("Accept") == "" {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+
+	resp, err := c.Stream(httpReq)
+	s.stopTimer()
+	if resp == nil {
+		s.cancel(nil)
+		if cause := context.Cause(s.ctx); errors.Is(cause, ErrEventTimeout) {
+			return nil, fmt.Errorf("%w: %w", ErrEventTimeout, err)
+		}
+		return nil, err
+	}
+
+	s.body = resp.Body
+	s.resp = &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		render:     &renderCache{},
+	}
+	if err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("status: %d, err: %w", resp.StatusCode, err)
+	}
+
+	if s.resp.IsError() {
+		data, rerr := io.ReadAll(resp.Body)
+		s.err = io.EOF
+		_ = s.Close()
+		if rerr != nil {
+			return s, fmt.Errorf("%w, status: %d, err: %w", ErrReadBody, resp.StatusCode, rerr)
+		}
+		s.resp.rawBody = data
+		s.resp.Body = io.NopCloser(bytes.NewReader(data))
+		return s, ParseError(s.resp)
+	}
+
+	s.dec = NewEventDecoder(resp.Body)
+	return s, nil
+}
+
+// Response returns the response status and headers. Its Body is nil for a
+// successful stream, since the events are read through the stream itself; for
+// an error status it holds the buffered error body.
+func (s *EventStream[T]) Response() *Response {
+	return s.resp
+}
+
+// Event returns the raw event behind the most recent value returned by Next.
+func (s *EventStream[T]) Event() Event {
+	return s.last
+}
+
+// Next returns the next event decoded into T. It returns io.EOF when the
+// server ends the stream, [ErrEventTimeout] when an event is overdue, the
+// context's cause when ctx is cancelled, and [ErrEventStreamClosed] after
+// Close. Those errors are terminal and the stream is closed. An event whose
+// data does not decode yields an error wrapping [ErrJSONUnmarshalBody] and the
+// stream stays usable.
+func (s *EventStream[T]) Next() (T, error) {
+	var zero T
+	if s.err == nil {
+		s.err = context.Cause(s.ctx)
+	}
+	if s.err != nil {
+		return zero, s.err
+	}
+
+	if s.timer != nil {
+		s.timer.Reset(s.timeout)
+	}
+	ev, err := s.dec.Next()
+	s.stopTimer()
+	if err != nil {
+		s.err = s.terminalError(err)
+		_ = s.Close()
+		return zero, s.err
+	}
+
+	s.last = ev
+	var v T
+	if err := json.Unmarshal(ev.Data, &v); err != nil {
+		return zero, fmt.Errorf("%w, event: %q, err: %w", ErrJSONUnmarshalBody, ev.Data, err)
+	}
+	return v, nil
+}
+
+// All returns an iterator over the remaining events. The iteration stops
+// silently at the end of the stream and after yielding a terminal error;
+// decode errors are yielded and iteration continues. The stream is closed
+// when the iteration returns.
+func (s *EventStream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer s.Close()
+		for {
+			v, err := s.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(v, err) || (err != nil && s.err != nil) {
+				return
+			}
+		}
+	}
+}
+
+// Close aborts the request and releases the connection. It is safe to call
+// more than once and concurrently with Next.
+func (s *EventStream[T]) Close() error {
+	s.closeOnce.Do(func() {
+		s.stopTimer()
+		s.cancel(ErrEventStreamClosed)
+		if s.body != nil {
+			s.closeErr = s.body.Close()
+		}
+	})
+	return s.closeErr
+}
+
+func (s *EventStream[T]) stopTimer() {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+}
+
+// terminalError maps a body read failure to the error Next reports: io.EOF
+// for a clean end, otherwise the reason the stream's context was cancelled.
+func (s *EventStream[T]) terminalError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	if cause := context.Cause(s.ctx); cause != nil {
+		return cause
+	}
+	return fmt.Errorf("%w: %w", ErrReadBody, err)
+}
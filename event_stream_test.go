@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearch_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+)
+
+type streamChunk struct {
+	Text string `json:"text"`
+}
+
+type streamReq struct{}
+
+func (streamReq) GetRequest(method string) (*http.Request, error) {
+	return http.NewRequest(method, "/_stream", nil)
+}
+
+// newStreamClient serves h on /_stream and answers every other request, such
+// as node discovery, with an empty object.
+func newStreamClient(t *testing.T, h http.HandlerFunc) *opensearch.Client {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_stream" {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestEventDecoder(t *testing.T) {
+	t.Parallel()
+
+	input := ": comment\r\n" +
+		"id: 1\r\n" +
+		"event: chunk\r\n" +
+		"data: {\"a\":\r\n" +
+		"data:1}\r\n" +
+		"\r\n" +
+		"retry: 250\n" +
+		"\n" +
+		"data\n" +
+		"\n" +
+		"data: trailing without blank line"
+
+	dec := opensearch.NewEventDecoder(strings.NewReader(input))
+
+	ev, err := dec.Next()
+	require.NoError(t, err)
+	require.Equal(t, opensearch.Event{ID: "1", Type: "chunk", Data: []byte("{\"a\":\n1}")}, ev)
+
+	// The retry-only block has no data and is skipped; the ID persists.
+	ev, err = dec.Next()
+	require.NoError(t, err)
+	require.Equal(t, "1", ev.ID)
+	require.Empty(t, ev.Type)
+	require.Empty(t, ev.Data)
+
+	_, err = dec.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestExecuteEventStream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decodes events in order", func(t *testing.T) {
+		t.Parallel()
+		client := newStreamClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") != "text/event-stream" {
+				t.Errorf("unexpected Accept header %q", r.Header.Get("Accept"))
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, "data: {\"text\":\"Hel\"}\n\ndata: not json\n\ndata: {\"text\":\"lo\"}\n\n")
+		})
+
+		stream, err := opensearch.ExecuteEventStream[streamChunk](t.Context(), client, http.MethodPost, streamReq{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, stream.Response().StatusCode)
+
+		var (
+			text    string
+			decErrs int
+		)
+		for chunk, err := range stream.All() {
+			if err != nil {
+				require.ErrorIs(t, err, opensearch.ErrJSONUnmarshalBody)
+				decErrs++
+				continue
+			}
+			text += chunk.Text
+		}
+		require.Equal(t, "Hello", text)
+		require.Equal(t, 1, decErrs)
+
+		_, err = stream.Next()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		t.Parallel()
+		client := newStreamClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"status":400,"error":{"type":"illegal_argument_exception","reason":"bad model"}}`)
+		})
+
+		stream, err := opensearch.ExecuteEventStream[streamChunk](t.Context(), client, http.MethodPost, streamReq{}, nil)
+		var structErr *opensearch.StructError
+		require.ErrorAs(t, err, &structErr)
+		require.Equal(t, "illegal_argument_exception", structErr.Err.Type)
+		require.NotNil(t, stream)
+		require.Equal(t, http.StatusBadRequest, stream.Response().StatusCode)
+		require.NotEmpty(t, stream.Response().RawBody())
+
+		_, err = stream.Next()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("event timeout", func(t *testing.T) {
+		t.Parallel()
+		release := make(chan struct{})
+		t.Cleanup(func() { close(release) })
+		client := newStreamClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "data: {\"text\":\"first\"}\n\n")
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		})
+
+		stream, err := opensearch.ExecuteEventStream[streamChunk](t.Context(), client, http.MethodPost, streamReq{},
+			&opensearch.StreamOptions{EventTimeout: 100 * time.Millisecond})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		// Time spent between calls does not count against the timeout.
+		time.Sleep(150 * time.Millisecond)
+		chunk, err := stream.Next()
+		require.NoError(t, err)
+		require.Equal(t, "first", chunk.Text)
+
+		_, err = stream.Next()
+		require.ErrorIs(t, err, opensearch.ErrEventTimeout)
+	})
+
+	t.Run("context cancellation and close", func(t *testing.T) {
+		t.Parallel()
+		release := make(chan struct{})
+		t.Cleanup(func() { close(release) })
+		client := newStreamClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		})
+
+		ctx, cancel := context.WithCancel(t.Context())
+		stream, err := opensearch.ExecuteEventStream[streamChunk](ctx, client, http.MethodPost, streamReq{}, nil)
+		require.NoError(t, err)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err = stream.Next()
+		require.ErrorIs(t, err, context.Canceled)
+
+		stream, err = opensearch.ExecuteEventStream[streamChunk](t.Context(), client, http.MethodPost, streamReq{}, nil)
+		require.NoError(t, err)
+		time.AfterFunc(50*time.Millisecond, func() { _ = stream.Close() })
+		_, err = stream.Next()
+		require.ErrorIs(t, err, opensearch.ErrEventStreamClosed)
+		require.NoError(t, stream.Close())
+	})
+}
@@ -12,3 +12,12 @@ import "github.com/opensearch-project/opensearch-go/v5"
 type Inspect struct {
 	Response *opensearch.Response
 }
+
+// StatusCode returns the HTTP status of a generated response, or 0 when no
+// response was received.
+func StatusCode[T interface{ Inspect() Inspect }](resp T) int {
+	if r := resp.Inspect().Response; r != nil {
+		return r.StatusCode
+	}
+	return 0
+}
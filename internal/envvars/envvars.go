@@ -51,6 +51,10 @@ const ShardRequests = "OPENSEARCH_GO_SHARD_REQUESTS"
 // RequestTimeout overrides the per-attempt HTTP round-trip timeout.
 const RequestTimeout = "OPENSEARCH_GO_REQUEST_TIMEOUT"
 
+// RequestBudget overrides the total time budget of a request across its
+// attempts and retry backoffs.
+const RequestBudget = "OPENSEARCH_GO_REQUEST_BUDGET"
+
 // DNSCacheRefresh overrides the client-side DNS cache refresh interval, which
 // also bounds how long a stale (last-known-good) address is served when the
 // resolver is briefly unreachable. time.ParseDuration format, integer seconds,
@@ -7,6 +7,8 @@
 // Package path provides generated typed path builder structs for all OpenSearch
 // API operations. Each struct corresponds to one x-operation-group from the
 // OpenSearch API specification and builds the URL path used in HTTP requests.
+// Join builds the paths of hand-written plugin clients whose operations are
+// not in the specification.
 //
 //go:generate sh -c "cd ../../cmd/osgen && go run . paths -spec ../../opensearch-openapi.yaml -pkg path -o ../../internal/path/builders_gen.go -test-out ../../internal/path/builders_gen_test.go"
 package path
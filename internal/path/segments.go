@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package path
+
+import "fmt"
+
+// Segment is one segment of a path built by Join.
+type Segment struct {
+	value   string
+	values  []string
+	field   string
+	literal bool
+}
+
+// Lit returns a literal segment from the API reference, such as "_search".
+// It is written as-is.
+func Lit(v string) Segment { return Segment{value: v, literal: true} }
+
+// Required returns a user-supplied segment that must not be empty; field
+// names it in the error Join returns otherwise.
+func Required(field, v string) Segment { return Segment{value: v, field: field} }
+
+// Optional returns a user-supplied segment that is left out when empty.
+func Optional(v string) Segment { return Segment{value: v} }
+
+// List returns a comma-separated list of user-supplied values, left out
+// when empty.
+func List(vs []string) Segment { return Segment{values: vs} }
+
+// Join builds a URL path for the hand-written plugin clients, whose
+// operations are not in the bundled specification. User-supplied values
+// are percent-encoded like the generated builders encode them.
+func Join(segments ...Segment) (string, error) {
+	pb := acquire()
+	for _, s := range segments {
+		switch {
+		case s.literal:
+			pb.writeLit(s.value)
+		case s.values != nil:
+			writeSegments(pb, s.values)
+		case s.value != "":
+			pb.writeReq(s.value)
+		case s.field != "":
+			pb.release()
+			return "", fmt.Errorf("%s: %w", s.field, errRequired)
+		}
+	}
+	return pb.release(), nil
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package path_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/path"
+)
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	got, err := path.Join(
+		path.Lit("_plugins"), path.Lit("_anomaly_detection"), path.Lit("detectors"),
+		path.Required("DetectorID", "a/../b"), path.Lit("_profile"),
+		path.List([]string{"state", "", "models"}), path.Optional(""),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors/a%2F..%2Fb/_profile/state,models", got)
+
+	got, err = path.Join(path.Lit("_plugins"), path.List(nil))
+	require.NoError(t, err)
+	require.Equal(t, "/_plugins", got)
+
+	_, err = path.Join(path.Lit("_plugins"), path.Required("GetMonitorReq.MonitorID", ""))
+	require.ErrorIs(t, err, path.ErrRequired)
+	require.ErrorContains(t, err, "GetMonitorReq.MonitorID: ")
+}
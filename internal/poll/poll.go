@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package poll holds the waiting primitive shared by the polling workflow
+// helpers (snapshot, model, and replication managers, and the like).
+package poll
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep waits for d. It returns ctx.Err() when ctx ends first, and nil
+// otherwise.
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package poll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/poll"
+)
+
+func TestSleep(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, poll.Sleep(t.Context(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, poll.Sleep(ctx, time.Hour), context.Canceled)
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package ptr holds the helpers the hand-written plugin and utility code
+// uses to move between values and the optional (pointer) fields of the
+// generated request and response types.
+package ptr
+
+// Deref returns *p, or the zero value when p is nil.
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// NonZero returns a pointer to v, or nil when v is the zero value, so an
+// unset option is omitted from a request body rather than sent empty.
+func NonZero[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// ReasonSuffix returns ": " followed by *reason, or "" when reason is nil
+// or empty, for appending a server-reported failure reason to an error
+// message.
+func ReasonSuffix(reason *string) string {
+	if reason == nil || *reason == "" {
+		return ""
+	}
+	return ": " + *reason
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package ptr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+)
+
+func TestDeref(t *testing.T) {
+	t.Parallel()
+
+	s := "x"
+	require.Equal(t, "x", ptr.Deref(&s))
+	require.Empty(t, ptr.Deref[string](nil))
+	require.Zero(t, ptr.Deref[int](nil))
+}
+
+func TestNonZero(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, ptr.NonZero(""))
+	require.Nil(t, ptr.NonZero(0))
+	require.Equal(t, "x", *ptr.NonZero("x"))
+}
+
+func TestReasonSuffix(t *testing.T) {
+	t.Parallel()
+
+	empty, reason := "", "disk full"
+	require.Empty(t, ptr.ReasonSuffix(nil))
+	require.Empty(t, ptr.ReasonSuffix(&empty))
+	require.Equal(t, ": disk full", ptr.ReasonSuffix(&reason))
+}
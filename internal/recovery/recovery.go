@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package recovery reads snapshot restores from the indices recovery API,
+// and holds the rule for when a restore has finished that opensearchapi/wait
+// and opensearchutil share.
+package recovery
+
+import "github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+
+// Recovery types and stages reported by the indices recovery API.
+const (
+	TypeSnapshot = "SNAPSHOT"
+	StageDone    = "DONE"
+)
+
+// Restore counts the snapshot shard recoveries of a restore.
+type Restore struct {
+	Missing string // an index with no recovery reported yet; "" when every index has one
+	Shards  int    // shards recovering, or recovered, from the snapshot
+	Pending int    // of Shards, those not at the DONE stage
+}
+
+// Done reports whether the restore has finished: every index has a
+// recovery, and no shard is still recovering from the snapshot. An index
+// whose recoveries include no snapshot shard, as after its primary
+// relocated or its node restarted, has nothing left to restore.
+func (r Restore) Done() bool {
+	return r.Missing == "" && r.Pending == 0
+}
+
+// ReadRestore reads the restore of indices from resp.
+func ReadRestore(indices []string, resp *opensearchapi.IndicesRecoveryResp) Restore {
+	var r Restore
+	for _, index := range indices {
+		status, ok := resp.Entries[index]
+		if !ok || len(status.Shards) == 0 {
+			if r.Missing == "" {
+				r.Missing = index
+			}
+			continue
+		}
+		for _, shard := range status.Shards {
+			if shard.Type != TypeSnapshot {
+				continue
+			}
+			r.Shards++
+			if shard.Stage != StageDone {
+				r.Pending++
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package recovery_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/recovery"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+func TestReadRestore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want recovery.Restore
+		done bool
+	}{
+		{
+			name: "index missing",
+			body: `{"a":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE"}]}}`,
+			want: recovery.Restore{Missing: "b", Shards: 1},
+		},
+		{
+			name: "index without shards",
+			body: `{"a":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE"}]},"b":{"shards":[]}}`,
+			want: recovery.Restore{Missing: "b", Shards: 1},
+		},
+		{
+			name: "in progress",
+			body: `{"a":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"INDEX"},{"id":0,"type":"PEER","stage":"INIT"}]},` +
+				`"b":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE"}]}}`,
+			want: recovery.Restore{Shards: 2, Pending: 1},
+		},
+		{
+			name: "done",
+			body: `{"a":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE"}]},"b":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE"}]}}`,
+			want: recovery.Restore{Shards: 2},
+			done: true,
+		},
+		{
+			name: "no snapshot shards left",
+			body: `{"a":{"shards":[{"id":0,"type":"PEER","stage":"DONE"}]},"b":{"shards":[{"id":0,"type":"EXISTING_STORE","stage":"DONE"}]}}`,
+			want: recovery.Restore{},
+			done: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var resp opensearchapi.IndicesRecoveryResp
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &resp))
+			got := recovery.ReadRestore([]string{"a", "b"}, &resp)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.done, got.Done())
+		})
+	}
+}
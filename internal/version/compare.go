@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package version
+
+import (
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Compare compares two OpenSearch versions such as "2.12.0" and returns -1,
+// 0, or +1. Missing minor and patch numbers count as zero, and a pre-release
+// or build suffix is ignored: a 3.1.0-SNAPSHOT node serves the 3.1.0 API.
+// An invalid version sorts below every valid one.
+func Compare(a, b string) int {
+	return semver.Compare(canonical(a), canonical(b))
+}
+
+// canonical returns v in the "vMAJOR.MINOR.PATCH" form semver expects, or
+// "" when v is not a version.
+func canonical(v string) string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	return semver.Canonical("v" + v)
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/version"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.12.0", "2.12.0", 0},
+		{"2.9.0", "2.12.0", -1},
+		{"3.0.0", "2.19.1", 1},
+		{"2.12", "2.12.0", 0},
+		{"3", "3.0.0", 0},
+		{"3.1.0-SNAPSHOT", "3.1.0", 0},
+		{"v2.4.0", "2.4.0", 0},
+		{"", "1.0.0", -1},
+		{"garbage", "1.0.0", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.want, version.Compare(tt.a, tt.b))
+		})
+	}
+}
@@ -125,6 +125,18 @@ type Config struct {
 	// 0 = no per-attempt timeout (default), >0 = explicit timeout.
 	RequestTimeout time.Duration
 
+	// RequestBudget bounds the total time of a request across all of its
+	// attempts and retry backoffs, and sets the server-side timeout of
+	// search, bulk, index, and delete_by_query requests from what remains.
+	// Attempts past the budget fail with opensearchtransport.ErrBudgetExhausted.
+	// See opensearchtransport.Config.RequestBudget.
+	// 0 = no budget (default), >0 = explicit budget.
+	RequestBudget time.Duration
+
+	// BudgetReserve is held back from the server-side timeout of each attempt.
+	// 0 = default (10% of the budget), >0 = explicit reserve.
+	BudgetReserve time.Duration
+
 	// DNSCacheRefresh controls how often the client-side DNS cache re-resolves
 	// cached hostnames. The cache is installed only when no custom Transport is
 	// provided; a caller-supplied Transport is never modified.
@@ -208,6 +220,16 @@ type Config struct {
 
 	EnableDebugLogger bool // Enable the debug logging.
 
+	// DebugLogger receives the transport's debug output in place of stdout,
+	// such as an [opensearchtransport.SlogDebugLogger]. It takes precedence
+	// over EnableDebugLogger.
+	DebugLogger opensearchtransport.DebuggingLogger
+
+	// Redactor masks secrets in the debug output and in Response.String.
+	// Nil applies the default rules of [opensearchtransport.Redactor].
+	// Loggers carry their own Redactor.
+	Redactor *opensearchtransport.Redactor
+
 	// ActiveListCap sets the maximum number of connections in the ready list's active partition per pool.
 	// When discovery adds connections that would exceed this cap, overflow connections
 	// are moved to a standby list for later rotation. This caps the number of active
@@ -460,6 +482,8 @@ func NewClient(cfg Config) (*Client, error) {
 		MaxRetries:           cfg.MaxRetries,
 		RetryBackoff:         cfg.RetryBackoff,
 		RequestTimeout:       cfg.RequestTimeout,
+		RequestBudget:        cfg.RequestBudget,
+		BudgetReserve:        cfg.BudgetReserve,
 
 		DNSCacheRefresh: cfg.DNSCacheRefresh,
 		DNSDialTimeout:  cfg.DNSDialTimeout,
@@ -469,6 +493,8 @@ func NewClient(cfg Config) (*Client, error) {
 		CompressRequestBody: cfg.CompressRequestBody,
 
 		EnableDebugLogger: cfg.EnableDebugLogger,
+		DebugLogger:       cfg.DebugLogger,
+		Redactor:          cfg.Redactor,
 
 		DiscoverNodesInterval: cfg.DiscoverNodesInterval,
 
@@ -585,6 +611,7 @@ func configKey(cfg Config) (ttlcache.Key, bool) {
 	// building a key. Kept as one predicate next to the field reads below so the
 	// two lists stay together.
 	if cfg.Transport != nil || cfg.Logger != nil || cfg.Selector != nil ||
+		cfg.DebugLogger != nil || cfg.Redactor != nil ||
 		cfg.Router != nil || cfg.Observer != nil || cfg.Signer != nil ||
 		cfg.OperationClassifier != nil ||
 		cfg.ConnectionPoolFunc != nil || cfg.AddressResolver != nil ||
@@ -625,6 +652,8 @@ func configKey(cfg Config) (ttlcache.Key, bool) {
 		Bool(cfg.EnableRetryOnTimeout).
 		Int(int64(cfg.MaxRetries)).
 		Int(int64(cfg.RequestTimeout)).
+		Int(int64(cfg.RequestBudget)).
+		Int(int64(cfg.BudgetReserve)).
 		Int(int64(cfg.DNSCacheRefresh)).
 		Int(int64(cfg.DNSDialTimeout)).
 		Int(int64(cfg.DNSKeepAlive)).
@@ -706,6 +735,14 @@ func (c *Client) Request(req *http.Request) (*http.Response, error) {
 	return c.Transport.Request(req)
 }
 
+// redactor returns the Redactor of the client's config, nil for the defaults.
+func (c *Client) redactor() *opensearchtransport.Redactor {
+	if c.config == nil {
+		return nil
+	}
+	return c.config.Redactor
+}
+
 // ensureReqHeader initializes req.Header if the caller left it nil, pre-sizing
 // for the headers the transport sets on every request (User-Agent,
 // Authorization, Content-Type, Content-Encoding, etc.) so the map does not
@@ -742,6 +779,8 @@ func Execute[T any](ctx context.Context, c *Client, method string, req Request,
 	if ctx != nil {
 		httpReq = httpReq.WithContext(ctx)
 	}
+	// Captured before the transport rewrites the URL to the selected node.
+	redact := redaction{redactor: c.redactor(), method: httpReq.Method, path: httpReq.URL.Path}
 
 	// Transport.Request buffers the body and returns the connection to the pool;
 	// resp.Body is already an io.NopCloser over the buffered bytes.
@@ -756,6 +795,7 @@ func Execute[T any](ctx context.Context, c *Client, method string, req Request,
 		Header:     resp.Header,
 		Body:       resp.Body,
 		render:     &renderCache{},
+		redaction:  redact,
 	}
 
 	if err != nil {
@@ -809,6 +849,17 @@ func (c *Client) DiscoverNodes(ctx context.Context) error {
 	return ErrTransportMissingMethodDiscoverNodes
 }
 
+// ServerVersion returns the OpenSearch version of the connected cluster as
+// the transport last learned it, or "" when it is unknown or the transport
+// does not track it. See [opensearchtransport.Transport.ServerVersion].
+func (c *Client) ServerVersion() string {
+	if vt, ok := c.Transport.(opensearchtransport.Versioned); ok {
+		return vt.ServerVersion()
+	}
+
+	return ""
+}
+
 // GetConfig returns the client configuration.
 func (c *Client) GetConfig() *Config {
 	return c.config
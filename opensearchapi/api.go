@@ -78,6 +78,27 @@ type Config struct {
 	// The OPENSEARCH_GO_ERROR_MASK environment variable can override
 	// this value with comma-separated +/- tokens (see [errmask.Parse]).
 	Errors *errmask.ErrorMask
+
+	// Cache enables a response cache for the metadata calls made on hot
+	// paths: Indices.Mapping.Get, Indices.Settings.Get, Indices.Alias.Get,
+	// Cluster.State, and Info. nil, the default, disables it. See
+	// [CacheConfig] for what is cached and when entries are invalidated.
+	// Cache hits are reported to Client.Observer when it implements
+	// [opensearchtransport.CacheObserver].
+	Cache *CacheConfig
+
+	// VersionGate checks each call against the OpenSearch version of the
+	// cluster before sending it. [VersionGateOff], the default, disables
+	// the check; [VersionGateRefuse] fails calls the cluster is too old for
+	// with an [UnsupportedByServerError]; [VersionGateAdapt] drops the
+	// unsupported optional parameters and body fields instead.
+	//
+	// The version is the lowest one the transport's health checks have seen
+	// across live nodes, or read from GET / when health checks have not run.
+	// While it is unknown every call is sent. Only core operations are
+	// checked, against the versions the API specification records for the
+	// operation, its own query parameters, and its top-level body fields.
+	VersionGate VersionGateMode
 }
 
 // NewClient returns an api client wrapping an [opensearch.Client]. When the
@@ -103,7 +124,12 @@ func buildClient(config Config) (*Client, error) {
 		return nil, err
 	}
 
-	return clientInit(rootClient, resolveErrorMask(config)), nil
+	client := clientInit(rootClient, resolveErrorMask(config))
+	if config.Cache != nil {
+		client.cache = newResponseCache(*config.Cache, config.Client.Observer)
+	}
+	client.gate = newVersionGate(config.VersionGate)
+	return client, nil
 }
 
 // Close releases the client's background resources by closing the underlying
@@ -193,17 +219,20 @@ func (e *ErrorMaskConflictError) Error() string {
 
 // Clone returns a new Client that shares this Client's underlying
 // opensearch.Client -- and therefore its connection pool, auth, transport,
-// and router -- but carries an independent error-mask cell seeded from the
-// current mask. Mutating the clone's mask via [Client.SetErrorMask] does
-// not affect the original, mirroring [net/http.Transport.Clone]'s "same
-// config, independent instance" contract.
+// router, response cache, and version gate -- but carries an independent error-mask cell
+// seeded from the current mask. Mutating the clone's mask via
+// [Client.SetErrorMask] does not affect the original, mirroring
+// [net/http.Transport.Clone]'s "same config, independent instance" contract.
 //
 // Use it to derive a client that talks over the same connection but reports
 // (or tolerates) a different set of partial-failure categories. To share the
 // transport with plugin clients instead, pass the exported [Client.Client]
 // field to their constructors.
 func (c *Client) Clone() *Client {
-	return clientInit(c.Client, c.errorMask())
+	clone := clientInit(c.Client, c.errorMask())
+	clone.cache = c.cache
+	clone.gate = c.gate
+	return clone
 }
 
 // request calls [opensearch.Execute] and checks the response for OpenSearch API errors.
@@ -211,9 +240,24 @@ func (c *Client) Clone() *Client {
 // [opensearch.Execute] routes through [opensearchtransport.Transport.Request] and buffers
 // the response body, so resp.Body here is already an [io.NopCloser] over a
 // [bytes.Reader] -- the connection has been drained and returned to the pool.
-// The helper only needs to translate IsError into a typed error.
+// The helper only needs to translate IsError into a typed error. With a
+// version gate configured, the call is checked first; with a response cache
+// configured, it then goes through the cache.
 func request[T any](ctx context.Context, c *Client, method string, req opensearch.Request, dataPointer *T) (*opensearch.Response, error) {
-	resp, err := opensearch.Execute(ctx, c.Client, method, req, dataPointer)
+	var (
+		resp *opensearch.Response
+		err  error
+	)
+	if c.gate != nil {
+		if req, err = c.gate.check(ctx, c, method, req); err != nil {
+			return nil, err
+		}
+	}
+	if c.cache != nil {
+		resp, err = cachedExecute(ctx, c, method, req, dataPointer)
+	} else {
+		resp, err = opensearch.Execute(ctx, c.Client, method, req, dataPointer)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,459 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// Defaults of CacheConfig.
+const (
+	DefaultCacheTTL        = 30 * time.Second
+	DefaultCacheMaxEntries = 1024
+	DefaultCacheMaxBytes   = 16 << 20
+)
+
+// CacheConfig configures the response cache of a Client; see [Config.Cache].
+//
+// The cache answers the idempotent metadata calls services make on hot
+// paths -- Indices.Mapping.Get, Indices.Settings.Get, Indices.Alias.Get,
+// Cluster.State, and Info -- from memory, keyed by the request path and
+// query parameters. Only 200 responses are cached. A request that sets its
+// own headers (the Header field of the request struct), such as security
+// impersonation or tenant selection, is always sent and never cached: the
+// headers may change the response.
+//
+// A mutating call made through the same Client (or one of its clones)
+// drops the entries of the indices it targets before it returns: put
+// mapping, put settings, alias changes, index create, delete, open, close,
+// and rollover, and any other non-document write. A call without an index
+// in its path, such as UpdateAliases, drops every entry but Info's.
+// Document writes, bulk, and refresh do not invalidate.
+//
+// Changes made by other clients, dynamic mapping updates, and mutations
+// addressed through an alias to entries fetched for its indices are not
+// seen until the entry's TTL elapses. An expired entry is then revalidated:
+// if the cluster state version (GET /_cluster/state/version) is the one
+// recorded when the entry was fetched, the entry is served for another TTL;
+// otherwise it is fetched again. A miss costs a single request: the version
+// is first recorded when an expired entry is fetched again, so an entry's
+// first expiry is a plain refetch and its later ones are revalidated.
+type CacheConfig struct {
+	// TTL is how long a response is served without contacting the cluster.
+	// Defaults to DefaultCacheTTL.
+	TTL time.Duration
+
+	// MaxEntries bounds the number of cached responses; the least recently
+	// used are evicted first. Defaults to DefaultCacheMaxEntries.
+	MaxEntries int
+
+	// MaxBytes bounds the total size of the cached response bodies; a
+	// larger response is not cached. Defaults to DefaultCacheMaxBytes.
+	MaxBytes int64
+
+	// DisableRevalidation turns the cluster state version check off: an
+	// expired entry is always fetched again. Revalidation also stops when
+	// the cluster refuses the check with 401 or 403.
+	DisableRevalidation bool
+}
+
+// cacheClassifier labels cache-hit events and picks out the data-plane
+// writes that leave metadata alone.
+//
+//nolint:gochecknoglobals // stateless, shared by every Client
+var cacheClassifier = opensearchtransport.NewOperationClassifier()
+
+// cacheScope is the set of indices whose metadata a cached response holds.
+type cacheScope uint8
+
+const (
+	scopeNames   cacheScope = iota // the entry's names
+	scopeIndices                   // every index
+	scopeCluster                   // no index: Info, dropped by InvalidateCache only
+)
+
+// cacheEntry is a cached response. It is not modified once stored, except
+// for expires, which the cache lock guards.
+type cacheEntry struct {
+	key     string
+	scope   cacheScope
+	names   []string // indices and aliases, for scopeNames
+	status  int
+	header  http.Header
+	body    []byte
+	fetched time.Time
+	expires time.Time
+	version string // cluster state version at fetch time; empty if unknown
+}
+
+// responseCache is the LRU response cache shared by a Client and its clones.
+type responseCache struct {
+	ttl        time.Duration
+	maxEntries int
+	maxBytes   int64
+	revalidate atomic.Bool
+	observer   opensearchtransport.CacheObserver // nil when the client's observer does not implement it
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // of *cacheEntry
+	lru     list.List                // most recently used first
+	bytes   int64
+	gen     uint64 // incremented by every invalidation
+}
+
+func newResponseCache(cfg CacheConfig, observer opensearchtransport.ConnectionObserver) *responseCache {
+	rc := &responseCache{
+		ttl:        cfg.TTL,
+		maxEntries: cfg.MaxEntries,
+		maxBytes:   cfg.MaxBytes,
+		entries:    make(map[string]*list.Element),
+	}
+	if co, ok := observer.(opensearchtransport.CacheObserver); ok {
+		rc.observer = co
+	}
+	if rc.ttl <= 0 {
+		rc.ttl = DefaultCacheTTL
+	}
+	if rc.maxEntries <= 0 {
+		rc.maxEntries = DefaultCacheMaxEntries
+	}
+	if rc.maxBytes <= 0 {
+		rc.maxBytes = DefaultCacheMaxBytes
+	}
+	rc.revalidate.Store(!cfg.DisableRevalidation)
+	return rc
+}
+
+// InvalidateCache drops every response cached by the Client, for example
+// after metadata changes made by another client. It is a no-op when the
+// Client has no cache.
+func (c *Client) InvalidateCache() {
+	if c.cache != nil {
+		c.cache.invalidate(scopeCluster, nil)
+	}
+}
+
+// preparedRequest is an opensearch.Request for an already built request.
+type preparedRequest struct{ req *http.Request }
+
+func (r preparedRequest) GetRequest(string) (*http.Request, error) { return r.req, nil }
+
+// cachedExecute is [opensearch.Execute] through the response cache of c.
+func cachedExecute[T any](ctx context.Context, c *Client, method string, req opensearch.Request, dataPointer *T) (*opensearch.Response, error) {
+	rc := c.cache
+	httpReq, err := req.GetRequest(method)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = httpReq.Context()
+	}
+
+	key, scope, names, cacheable := cacheTarget(httpReq)
+	if !cacheable {
+		resp, err := opensearch.Execute(ctx, c.Client, method, preparedRequest{httpReq}, dataPointer)
+		if scope, names, ok := mutationTarget(httpReq); ok {
+			// Invalidate whatever the outcome: a failed or timed out call may
+			// still have been applied.
+			rc.invalidate(scope, names)
+		}
+		return resp, err
+	}
+
+	now := time.Now()
+	e, ok := rc.get(key)
+	version := ""
+	if ok {
+		fresh := now.Before(e.expires)
+		if !fresh && e.version != "" {
+			if version = rc.clusterVersion(ctx, c.Client); version == e.version {
+				rc.renew(e, now)
+				fresh = true
+			}
+		}
+		if fresh {
+			return rc.hit(ctx, httpReq, e, now, version != "", dataPointer)
+		}
+	}
+
+	gen := rc.generation()
+	if ok && version == "" {
+		// The entry expired without a version to check: record one for its
+		// next expiry. A cold or evicted key is fetched without one, as it
+		// may not be asked for again. The version is read before the fetch:
+		// a change in between is caught by the next revalidation, never
+		// hidden by it.
+		version = rc.clusterVersion(ctx, c.Client)
+	}
+	resp, err := opensearch.Execute(ctx, c.Client, method, preparedRequest{httpReq}, dataPointer)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		rc.put(&cacheEntry{
+			key:     key,
+			scope:   scope,
+			names:   responseNames(scope, names, httpReq, resp.RawBody()),
+			status:  resp.StatusCode,
+			header:  resp.Header.Clone(),
+			body:    bytes.Clone(resp.RawBody()),
+			fetched: time.Now(),
+			version: version,
+		}, gen)
+	}
+	return resp, err
+}
+
+// hit answers a request from e.
+func (rc *responseCache) hit(ctx context.Context, req *http.Request, e cacheEntry, now time.Time, revalidated bool, dataPointer any) (*opensearch.Response, error) {
+	resp := opensearch.NewBufferedResponse(e.status, e.header.Clone(), bytes.Clone(e.body))
+	if dataPointer != nil {
+		if err := json.Unmarshal(e.body, dataPointer); err != nil {
+			return resp, err
+		}
+	}
+	if rc.observer != nil {
+		path := req.URL.EscapedPath()
+		index, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		if strings.HasPrefix(index, "_") {
+			index = ""
+		}
+		rc.observer.OnCacheHit(ctx, opensearchtransport.CacheHitEvent{
+			Request: opensearchtransport.RequestEvent{
+				Method:       req.Method,
+				Path:         path,
+				RouteName:    cacheClassifier.Classify(req.Method, req.URL.Path).String(),
+				Index:        index,
+				RequestBytes: req.ContentLength,
+			},
+			StatusCode:    e.status,
+			Age:           now.Sub(e.fetched),
+			Revalidated:   revalidated,
+			ResponseBytes: int64(len(e.body)),
+		})
+	}
+	return resp, nil
+}
+
+// get returns a copy of the entry for key, marking it recently used.
+func (rc *responseCache) get(key string) (cacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	el, ok := rc.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	rc.lru.MoveToFront(el)
+	return *el.Value.(*cacheEntry), true //nolint:forcetypeassert // the list only holds entries
+}
+
+// renew extends the TTL of e if it is still cached.
+func (rc *responseCache) renew(e cacheEntry, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if el, ok := rc.entries[e.key]; ok {
+		if cur := el.Value.(*cacheEntry); cur.version == e.version { //nolint:forcetypeassert // the list only holds entries
+			cur.expires = now.Add(rc.ttl)
+		}
+	}
+}
+
+func (rc *responseCache) generation() uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.gen
+}
+
+// put stores e unless an invalidation has happened since generation gen,
+// when the response may predate it, then evicts down to the size limits.
+func (rc *responseCache) put(e *cacheEntry, gen uint64) {
+	size := int64(len(e.body))
+	if size > rc.maxBytes {
+		return
+	}
+	e.expires = e.fetched.Add(rc.ttl)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.gen != gen {
+		return
+	}
+	if el, ok := rc.entries[e.key]; ok {
+		rc.remove(el)
+	}
+	rc.entries[e.key] = rc.lru.PushFront(e)
+	rc.bytes += size
+	for rc.lru.Len() > rc.maxEntries || rc.bytes > rc.maxBytes {
+		rc.remove(rc.lru.Back())
+	}
+}
+
+// invalidate drops the entries holding metadata of names, or of every
+// index for scopeIndices; scopeCluster drops every entry.
+func (rc *responseCache) invalidate(scope cacheScope, names []string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.gen++
+	for el := rc.lru.Front(); el != nil; {
+		next := el.Next()
+		e := el.Value.(*cacheEntry) //nolint:forcetypeassert // the list only holds entries
+		switch {
+		case scope == scopeCluster, e.scope == scopeIndices:
+			rc.remove(el)
+		case e.scope == scopeCluster:
+		case scope == scopeIndices, overlaps(e.names, names):
+			rc.remove(el)
+		}
+		el = next
+	}
+}
+
+// remove drops el. The lock must be held.
+func (rc *responseCache) remove(el *list.Element) {
+	e := rc.lru.Remove(el).(*cacheEntry) //nolint:forcetypeassert // the list only holds entries
+	delete(rc.entries, e.key)
+	rc.bytes -= int64(len(e.body))
+}
+
+// versionRequest reads the cluster state version.
+type versionRequest struct{}
+
+func (versionRequest) GetRequest(method string) (*http.Request, error) {
+	return http.NewRequest(method, "/_cluster/state/version", nil)
+}
+
+// clusterVersion returns the cluster state version and UUID, or "" when
+// revalidation is off or the check fails.
+func (rc *responseCache) clusterVersion(ctx context.Context, client *opensearch.Client) string {
+	if !rc.revalidate.Load() {
+		return ""
+	}
+	var state struct {
+		Version   int64  `json:"version"`
+		StateUUID string `json:"state_uuid"`
+	}
+	resp, err := opensearch.Execute(ctx, client, http.MethodGet, versionRequest{}, &state)
+	if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		rc.revalidate.Store(false)
+	}
+	if err != nil || resp.IsError() || state.StateUUID == "" {
+		return ""
+	}
+	return strconv.FormatInt(state.Version, 10) + "/" + state.StateUUID
+}
+
+// cacheTarget returns the cache key of a cacheable request, and the scope
+// and index names of its response. A request with headers of its own is not
+// cacheable: the key does not cover them.
+func cacheTarget(req *http.Request) (string, cacheScope, []string, bool) {
+	if req.Method != http.MethodGet || len(req.Header) > 0 {
+		return "", 0, nil, false
+	}
+	segs := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	key := req.URL.Path + "?" + req.URL.RawQuery
+	switch {
+	case len(segs) == 1 && segs[0] == "":
+		return key, scopeCluster, nil, true
+	case segs[0] == "_cluster" && len(segs) >= 2 && segs[1] == "state":
+		if len(segs) >= 4 {
+			scope, names := indexScope(segs[3])
+			return key, scope, names, true
+		}
+		return key, scopeIndices, nil, true
+	case isMetadataAPI(segs[0]):
+		return key, scopeIndices, nil, true
+	case len(segs) >= 2 && !strings.HasPrefix(segs[0], "_") && isMetadataAPI(segs[1]):
+		scope, names := indexScope(segs[0])
+		return key, scope, names, true
+	}
+	return "", 0, nil, false
+}
+
+func isMetadataAPI(seg string) bool {
+	switch seg {
+	case "_mapping", "_settings", "_alias", "_aliases":
+		return true
+	}
+	return false
+}
+
+// mutationTarget returns the indices whose cached metadata a request may
+// change, and false for requests that change none: reads, document
+// writes, bulk, and maintenance such as refresh.
+func mutationTarget(req *http.Request) (cacheScope, []string, bool) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return 0, nil, false
+	}
+	op := cacheClassifier.Classify(req.Method, req.URL.Path)
+	if op != opensearchtransport.OpOther && !op.IsWrite() {
+		return 0, nil, false // a read sent as POST, such as a search
+	}
+	switch op.Category() {
+	case opensearchtransport.CatDocWrite, opensearchtransport.CatBulk, opensearchtransport.CatScrollWrite,
+		opensearchtransport.CatPITWrite, opensearchtransport.CatMaintWrite:
+		return 0, nil, false
+	}
+	if op == opensearchtransport.OpIndexAnalyze {
+		return 0, nil, false
+	}
+	first, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	if first == "" || strings.HasPrefix(first, "_") {
+		return scopeIndices, nil, true
+	}
+	scope, names := indexScope(first)
+	return scope, names, true
+}
+
+// indexScope returns the scope of a comma-separated index expression:
+// scopeIndices when it has a wildcard or _all, which may cover indices that
+// do not exist yet.
+func indexScope(expr string) (cacheScope, []string) {
+	names := strings.Split(expr, ",")
+	for _, n := range names {
+		if n == "_all" || strings.ContainsAny(n, "*?") || strings.HasPrefix(n, "-") {
+			return scopeIndices, nil
+		}
+	}
+	return scopeNames, names
+}
+
+// responseNames adds the concrete indices a mapping, settings, or alias
+// response is keyed by to names, so that a mutation of an index drops the
+// entries fetched through its aliases.
+func responseNames(scope cacheScope, names []string, req *http.Request, body []byte) []string {
+	if scope != scopeNames || strings.HasPrefix(req.URL.Path, "/_cluster/") {
+		return names
+	}
+	var byIndex map[string]json.RawMessage
+	if json.Unmarshal(body, &byIndex) != nil {
+		return names
+	}
+	for name := range byIndex {
+		names = append(names, name)
+	}
+	return names
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
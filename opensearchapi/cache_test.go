@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package opensearchapi_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// metadataServer serves the metadata calls of the cache tests, counting
+// them, and bumps the cluster state version on every write. GET / is also
+// the transport's health check, so tests of Info count cache hits instead.
+type metadataServer struct {
+	version atomic.Int64
+	mu      sync.Mutex
+	calls   map[string]int
+}
+
+func (s *metadataServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := r.Method + " " + r.URL.Path
+	s.mu.Lock()
+	s.calls[call]++
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case call == "GET /_cluster/state/version":
+		_, _ = fmt.Fprintf(w, `{"version":%d,"state_uuid":"uuid-%[1]d"}`, s.version.Load())
+	case call == "GET /":
+		_, _ = io.WriteString(w, `{"cluster_name":"test","version":{"number":"3.0.0","distribution":"opensearch"}}`)
+	case call == "GET /missing/_mapping":
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`)
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/_mapping"):
+		// Aliases resolve to the logs index.
+		index := strings.Split(r.URL.Path, "/")[1]
+		if index == "logs-alias" {
+			index = "logs"
+		}
+		_, _ = fmt.Fprintf(w, `{%q:{"mappings":{"properties":{"v%d":{"type":"keyword"}}}}}`, index, s.version.Load())
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/_doc"):
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"_id":"1","result":"created"}`)
+	case r.Method != http.MethodGet && r.Method != http.MethodHead:
+		s.version.Add(1)
+		_, _ = io.WriteString(w, `{"acknowledged":true}`)
+	default:
+		_, _ = io.WriteString(w, `{}`)
+	}
+}
+
+func (s *metadataServer) count(call string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[call]
+}
+
+// hitRecorder records cache-hit events.
+type hitRecorder struct {
+	opensearchtransport.BaseConnectionObserver
+	mu   sync.Mutex
+	hits []opensearchtransport.CacheHitEvent
+}
+
+func (o *hitRecorder) OnCacheHit(_ context.Context, e opensearchtransport.CacheHitEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.hits = append(o.hits, e)
+}
+
+func (o *hitRecorder) events() []opensearchtransport.CacheHitEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]opensearchtransport.CacheHitEvent(nil), o.hits...)
+}
+
+func newCachedClient(t *testing.T, cfg opensearchapi.CacheConfig) (*opensearchapi.Client, *metadataServer, *hitRecorder) {
+	t.Helper()
+	srv := &metadataServer{calls: make(map[string]int)}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	obs := &hitRecorder{}
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{ts.URL}, Observer: obs},
+		Cache:  &cfg,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, srv, obs
+}
+
+func getMapping(t *testing.T, client *opensearchapi.Client, index string) *opensearchapi.IndicesGetMappingResp {
+	t.Helper()
+	resp, err := client.Indices.Mapping.Get(t.Context(), &opensearchapi.IndicesGetMappingReq{Indices: []string{index}})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hits are served from memory and reported", func(t *testing.T) {
+		t.Parallel()
+		client, srv, obs := newCachedClient(t, opensearchapi.CacheConfig{})
+
+		first := getMapping(t, client, "logs")
+		second := getMapping(t, client, "logs")
+		require.Equal(t, 1, srv.count("GET /logs/_mapping"))
+		require.Equal(t, first.Entries, second.Entries)
+		require.Equal(t, first.Inspect().Response.RawBody(), second.Inspect().Response.RawBody())
+
+		_, err := client.Info(t.Context(), nil)
+		require.NoError(t, err)
+		info, err := client.Info(t.Context(), nil)
+		require.NoError(t, err)
+		require.Equal(t, "test", info.ClusterName)
+
+		hits := obs.events()
+		require.Len(t, hits, 2)
+		require.Equal(t, "GET", hits[0].Request.Method)
+		require.Equal(t, "/logs/_mapping", hits[0].Request.Path)
+		require.Equal(t, "mapping_get", hits[0].Request.RouteName)
+		require.Equal(t, "logs", hits[0].Request.Index)
+		require.Equal(t, http.StatusOK, hits[0].StatusCode)
+		require.False(t, hits[0].Revalidated)
+		require.Equal(t, int64(len(first.Inspect().Response.RawBody())), hits[0].ResponseBytes)
+		require.Equal(t, "cluster_info", hits[1].Request.RouteName)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		t.Parallel()
+		client, srv, _ := newCachedClient(t, opensearchapi.CacheConfig{})
+
+		for range 2 {
+			_, err := client.Indices.Mapping.Get(t.Context(), &opensearchapi.IndicesGetMappingReq{Indices: []string{"missing"}})
+			require.Error(t, err)
+		}
+		require.Equal(t, 2, srv.count("GET /missing/_mapping"))
+	})
+
+	t.Run("requests with their own headers bypass the cache", func(t *testing.T) {
+		t.Parallel()
+		client, srv, obs := newCachedClient(t, opensearchapi.CacheConfig{})
+
+		getMapping(t, client, "logs")
+		for range 2 {
+			_, err := client.Indices.Mapping.Get(t.Context(), &opensearchapi.IndicesGetMappingReq{
+				Indices: []string{"logs"},
+				Header:  http.Header{"Opendistro_security_impersonate_as": {"alice"}},
+			})
+			require.NoError(t, err)
+		}
+		require.Equal(t, 3, srv.count("GET /logs/_mapping"), "neither served from nor stored in the cache")
+		require.Empty(t, obs.events())
+
+		getMapping(t, client, "logs")
+		require.Equal(t, 3, srv.count("GET /logs/_mapping"), "the headerless entry is intact")
+	})
+
+	t.Run("mutations invalidate the indices they target", func(t *testing.T) {
+		t.Parallel()
+		client, srv, _ := newCachedClient(t, opensearchapi.CacheConfig{})
+
+		getMapping(t, client, "logs")
+		getMapping(t, client, "logs-alias")
+		getMapping(t, client, "other")
+
+		// A document write leaves metadata alone.
+		_, err := client.Document.Index(t.Context(), opensearchapi.IndexReq{Index: "logs", Body: strings.NewReader(`{}`)})
+		require.NoError(t, err)
+		getMapping(t, client, "logs")
+		require.Equal(t, 1, srv.count("GET /logs/_mapping"))
+
+		// Putting the mapping of logs drops it, and the entry fetched through
+		// its alias, but not other.
+		_, err = client.Indices.Mapping.Put(t.Context(), &opensearchapi.IndicesPutMappingReq{
+			Indices:    []string{"logs"},
+			BodyReader: strings.NewReader(`{"properties":{}}`),
+		})
+		require.NoError(t, err)
+		getMapping(t, client, "logs")
+		getMapping(t, client, "logs-alias")
+		getMapping(t, client, "other")
+		require.Equal(t, 2, srv.count("GET /logs/_mapping"))
+		require.Equal(t, 2, srv.count("GET /logs-alias/_mapping"))
+		require.Equal(t, 1, srv.count("GET /other/_mapping"))
+
+		// A call without an index drops every index.
+		_, err = client.Indices.UpdateAliases(t.Context(), &opensearchapi.IndicesUpdateAliasesReq{
+			BodyReader: strings.NewReader(`{"actions":[]}`),
+		})
+		require.NoError(t, err)
+		getMapping(t, client, "other")
+		require.Equal(t, 2, srv.count("GET /other/_mapping"))
+	})
+
+	t.Run("clones share the cache", func(t *testing.T) {
+		t.Parallel()
+		client, srv, _ := newCachedClient(t, opensearchapi.CacheConfig{})
+		clone := client.Clone()
+
+		getMapping(t, client, "logs")
+		getMapping(t, clone, "logs")
+		require.Equal(t, 1, srv.count("GET /logs/_mapping"))
+
+		client.InvalidateCache()
+		getMapping(t, clone, "logs")
+		require.Equal(t, 2, srv.count("GET /logs/_mapping"))
+	})
+
+	t.Run("size limits evict the least recently used", func(t *testing.T) {
+		t.Parallel()
+		client, srv, _ := newCachedClient(t, opensearchapi.CacheConfig{MaxEntries: 2})
+
+		getMapping(t, client, "a")
+		getMapping(t, client, "b")
+		getMapping(t, client, "a")
+		getMapping(t, client, "c") // evicts b
+		getMapping(t, client, "a")
+		getMapping(t, client, "b")
+		require.Equal(t, 1, srv.count("GET /a/_mapping"))
+		require.Equal(t, 2, srv.count("GET /b/_mapping"))
+
+		small, srv, _ := newCachedClient(t, opensearchapi.CacheConfig{MaxBytes: 10})
+		getMapping(t, small, "a")
+		getMapping(t, small, "a")
+		require.Equal(t, 2, srv.count("GET /a/_mapping"))
+	})
+}
+
+func TestResponseCacheRevalidation(t *testing.T) {
+	t.Parallel()
+	const ttl = 20 * time.Millisecond
+
+	t.Run("an unchanged cluster state version extends the entry", func(t *testing.T) {
+		t.Parallel()
+		client, srv, obs := newCachedClient(t, opensearchapi.CacheConfig{TTL: ttl})
+
+		// A miss sends only the request itself.
+		getMapping(t, client, "logs")
+		require.Zero(t, srv.count("GET /_cluster/state/version"))
+
+		// The first expiry fetches the entry again, recording the version.
+		time.Sleep(2 * ttl)
+		getMapping(t, client, "logs")
+		require.Equal(t, 2, srv.count("GET /logs/_mapping"))
+		require.Equal(t, 1, srv.count("GET /_cluster/state/version"))
+
+		time.Sleep(2 * ttl)
+		getMapping(t, client, "logs")
+		getMapping(t, client, "logs")
+		require.Equal(t, 2, srv.count("GET /logs/_mapping"))
+		require.Equal(t, 2, srv.count("GET /_cluster/state/version"))
+
+		hits := obs.events()
+		require.Len(t, hits, 2)
+		require.True(t, hits[0].Revalidated)
+		require.GreaterOrEqual(t, hits[0].Age, 2*ttl)
+		require.False(t, hits[1].Revalidated)
+
+		// A change made by another client moves the version on.
+		srv.version.Add(1)
+		time.Sleep(2 * ttl)
+		resp := getMapping(t, client, "logs")
+		require.Contains(t, resp.Entries["logs"].Mappings.Properties, "v1")
+		require.Equal(t, 3, srv.count("GET /logs/_mapping"))
+	})
+
+	t.Run("without revalidation expired entries are fetched again", func(t *testing.T) {
+		t.Parallel()
+		client, srv, _ := newCachedClient(t, opensearchapi.CacheConfig{TTL: ttl, DisableRevalidation: true})
+
+		getMapping(t, client, "logs")
+		time.Sleep(2 * ttl)
+		getMapping(t, client, "logs")
+		require.Equal(t, 2, srv.count("GET /logs/_mapping"))
+		require.Zero(t, srv.count("GET /_cluster/state/version"))
+	})
+}
@@ -25,6 +25,8 @@ var noBody *opensearch.NoBody //nolint:gochecknoglobals // package-internal sent
 type Client struct {
 	Client         *opensearch.Client
 	errors         *errMaskWidth
+	cache          *responseCache
+	gate           *versionGate
 	Cat            CatClient
 	Cluster        ClusterClient
 	Dangling       DanglingClient
@@ -278,6 +278,37 @@ func (e *UnionBranchError) Error() string {
 // simply held a different branch.
 func (e *UnionBranchError) Unwrap() error { return e.Err }
 
+// ---------------------------------------------------------------------------
+// UnsupportedByServerError
+// ---------------------------------------------------------------------------
+
+// ErrUnsupportedByServer is the sentinel every [UnsupportedByServerError]
+// matches with [errors.Is].
+var ErrUnsupportedByServer = errors.New("unsupported by server")
+
+// UnsupportedByServerError reports a call the version gate (see
+// [Config.VersionGate]) refused before sending it, because the connected
+// cluster is older than the operation, query parameter, or body field the
+// call uses. Nothing was sent to the server.
+type UnsupportedByServerError struct {
+	Operation     string // operation group, e.g. "search_pipeline.put"
+	Parameter     string // query parameter or body field; "" when the operation itself is unsupported
+	Requires      string // version that added Operation or Parameter, e.g. "2.9.0"
+	ServerVersion string // version of the connected cluster
+}
+
+func (e *UnsupportedByServerError) Error() string {
+	if e.Parameter == "" {
+		return fmt.Sprintf("%s: %s requires OpenSearch >= %s, server is %s",
+			ErrUnsupportedByServer, e.Operation, e.Requires, e.ServerVersion)
+	}
+	return fmt.Sprintf("%s: %s parameter %q requires OpenSearch >= %s, server is %s",
+		ErrUnsupportedByServer, e.Operation, e.Parameter, e.Requires, e.ServerVersion)
+}
+
+// Is reports whether target is [ErrUnsupportedByServer].
+func (e *UnsupportedByServerError) Is(target error) bool { return target == ErrUnsupportedByServer }
+
 // ---------------------------------------------------------------------------
 // Helper functions
 // ---------------------------------------------------------------------------
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v5/errmask"
+)
+
+// MultiSearchHeader is the per-item header line of an _msearch request body.
+// Index is filled from [MultiSearchItem.Indices]; the remaining fields mirror
+// the search query parameters the header line accepts.
+type MultiSearchHeader struct {
+	Index             []string   `json:"index,omitempty"`
+	AllowNoIndices    *bool      `json:"allow_no_indices,omitempty"`
+	ExpandWildcards   string     `json:"expand_wildcards,omitempty"`
+	IgnoreUnavailable *bool      `json:"ignore_unavailable,omitempty"`
+	Preference        string     `json:"preference,omitempty"`
+	RequestCache      *bool      `json:"request_cache,omitempty"`
+	Routing           string     `json:"routing,omitempty"`
+	SearchPipeline    string     `json:"search_pipeline,omitempty"`
+	SearchType        SearchType `json:"search_type,omitempty"`
+}
+
+// MultiSearchItem is one sub-search added to a [MultiSearch].
+type MultiSearchItem struct {
+	// Indices are the indices the sub-search targets. Empty targets the
+	// indices of the enclosing request, or all indices when those are empty.
+	Indices []string
+
+	// Header carries the per-item parameters. Its Index field is
+	// overwritten with Indices.
+	Header MultiSearchHeader
+
+	// Body is the search definition. A nil Body sends an empty query.
+	Body *SearchBody
+}
+
+// MultiSearchResult is the outcome of one [MultiSearchItem]: exactly one of
+// Resp and Err is meaningful, except for a shard-level partial failure, which
+// carries both the populated Resp and a [*PartialSearchError].
+type MultiSearchResult struct {
+	Resp *SearchResp
+	Err  error
+}
+
+// MultiSearchResults holds the per-item outcomes of [MultiSearch.Do] in the
+// order the items were added.
+type MultiSearchResults []MultiSearchResult
+
+// Result returns the response and error for the item at slot i, as returned
+// by [MultiSearch.Add].
+func (r MultiSearchResults) Result(i int) (*SearchResp, error) {
+	if i < 0 || i >= len(r) {
+		return nil, fmt.Errorf("multi-search: slot %d out of range [0,%d)", i, len(r))
+	}
+	return r[i].Resp, r[i].Err
+}
+
+// MultiSearchConfig bounds how a [MultiSearch] splits its items into
+// _msearch requests. A zero value sends every item in a single request.
+type MultiSearchConfig struct {
+	// Indices are the default indices placed on each _msearch request path.
+	Indices []string
+
+	// Params are the query parameters sent with every _msearch request.
+	// A non-zero MaxConcurrentSearches doubles as a per-request item budget:
+	// no request carries more sub-searches than the server would run at once.
+	Params *MSearchParams
+
+	// MaxItems caps the number of sub-searches per _msearch request.
+	// Zero means no cap beyond Params.MaxConcurrentSearches.
+	MaxItems int
+
+	// MaxBytes caps the encoded NDJSON body size per _msearch request. An
+	// item that alone exceeds MaxBytes is sent in a request of its own.
+	// Zero means no cap.
+	MaxBytes int
+}
+
+// MultiSearch builds a multi-search from typed [SearchBody] items, encoding
+// the NDJSON header/body pairs itself. [MultiSearch.Do] splits the items
+// into as many _msearch requests as the configured budgets require and maps
+// every sub-response back to the slot its item was added at.
+//
+// A MultiSearch is not safe for concurrent use.
+type MultiSearch struct {
+	cfg   MultiSearchConfig
+	items []MultiSearchItem
+}
+
+// NewMultiSearch returns an empty [MultiSearch] using cfg.
+func NewMultiSearch(cfg MultiSearchConfig) *MultiSearch {
+	return &MultiSearch{cfg: cfg}
+}
+
+// Add appends item and returns its slot in the [MultiSearchResults].
+func (m *MultiSearch) Add(item MultiSearchItem) int {
+	m.items = append(m.items, item)
+	return len(m.items) - 1
+}
+
+// Len returns the number of items added so far.
+func (m *MultiSearch) Len() int { return len(m.items) }
+
+// multiSearchBatch is one _msearch request: the encoded NDJSON body and the
+// input slots its sub-searches belong to, in body order.
+type multiSearchBatch struct {
+	body  bytes.Buffer
+	slots []int
+}
+
+// batches encodes every item and groups the encodings into requests within
+// the configured budgets. Items that fail to encode are recorded in results
+// and left out of every batch.
+func (m *MultiSearch) batches(results MultiSearchResults) []*multiSearchBatch {
+	maxItems := m.cfg.MaxItems
+	if p := m.cfg.Params; p != nil && p.MaxConcurrentSearches > 0 &&
+		(maxItems == 0 || p.MaxConcurrentSearches < maxItems) {
+		maxItems = p.MaxConcurrentSearches
+	}
+
+	var (
+		out []*multiSearchBatch
+		cur *multiSearchBatch
+	)
+	for slot, item := range m.items {
+		lines, err := encodeMultiSearchItem(item)
+		if err != nil {
+			results[slot].Err = fmt.Errorf("multi-search: encode item %d: %w", slot, err)
+			continue
+		}
+		if cur != nil && ((maxItems > 0 && len(cur.slots) >= maxItems) ||
+			(m.cfg.MaxBytes > 0 && cur.body.Len()+len(lines) > m.cfg.MaxBytes)) {
+			cur = nil
+		}
+		if cur == nil {
+			cur = &multiSearchBatch{}
+			out = append(out, cur)
+		}
+		cur.body.Write(lines)
+		cur.slots = append(cur.slots, slot)
+	}
+	return out
+}
+
+// encodeMultiSearchItem renders item as its header and body NDJSON lines.
+func encodeMultiSearchItem(item MultiSearchItem) ([]byte, error) {
+	header := item.Header
+	header.Index = item.Indices
+	h, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	body := item.Body
+	if body == nil {
+		body = &SearchBody{}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]byte, 0, len(h)+len(b)+2)
+	lines = append(lines, h...)
+	lines = append(lines, '\n')
+	lines = append(lines, b...)
+	return append(lines, '\n'), nil
+}
+
+// Do sends the added items through c and returns one result per item in
+// input order. Batches are sent sequentially.
+//
+// A sub-search the server rejected yields a [*MultiSearchItemError] in its
+// slot whose single failure carries the slot index. Shard-level failures
+// yield a [*PartialSearchError] alongside the populated response unless the
+// client's error mask suppresses them. When a whole _msearch request fails,
+// every slot it carried receives that error and the returned error joins the
+// per-request failures; it is nil when every request reached the server.
+func (m *MultiSearch) Do(ctx context.Context, c *Client) (MultiSearchResults, error) {
+	results := make(MultiSearchResults, len(m.items))
+	mask := c.errorMask()
+
+	var errs []error
+	for _, batch := range m.batches(results) {
+		req := &MSearchReq{
+			Indices: m.cfg.Indices,
+			Body:    bytes.NewReader(batch.body.Bytes()),
+			Params:  m.cfg.Params,
+		}
+		var data MSearchResp
+		resp, err := request(ctx, c, http.MethodPost, req, &data)
+		data.response = resp
+		if err != nil {
+			err = fmt.Errorf("multi-search: slots %d-%d: %w", batch.slots[0], batch.slots[len(batch.slots)-1], err)
+			for _, slot := range batch.slots {
+				results[slot].Err = err
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		for i, slot := range batch.slots {
+			if i >= len(data.Responses) {
+				results[slot].Err = fmt.Errorf("multi-search: no sub-response for slot %d (got %d for %d items)",
+					slot, len(data.Responses), len(batch.slots))
+				continue
+			}
+			results[slot] = multiSearchSlotResult(&data, &data.Responses[i], slot, mask)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// multiSearchSlotResult converts one decoded sub-response into the result
+// for slot.
+func multiSearchSlotResult(data *MSearchResp, item *MSearchRespItem, slot int, mask errmask.ErrorMask) MultiSearchResult {
+	switch item.Type() {
+	case MSearchRespItemMultiSearchItemType:
+		// Guarded by the Type() check, so the branch error cannot fire.
+		branch, _ := item.MultiSearchItem()
+		resp := &SearchResp{
+			Clusters:         branch.Clusters,
+			ScrollID:         branch.ScrollID,
+			Shards:           branch.Shards,
+			Aggregations:     branch.Aggregations,
+			Hits:             branch.Hits,
+			NumReducePhases:  branch.NumReducePhases,
+			PhaseTook:        branch.PhaseTook,
+			PITID:            branch.PITID,
+			ProcessorResults: branch.ProcessorResults,
+			Profile:          branch.Profile,
+			Suggest:          branch.Suggest,
+			TerminatedEarly:  branch.TerminatedEarly,
+			TimedOut:         branch.TimedOut,
+			Took:             branch.Took,
+			response:         data.response,
+		}
+		return MultiSearchResult{
+			Resp: resp,
+			Err:  collapsePerOpErrors(resp.PartialFailures(mask), nil),
+		}
+	case MSearchRespItemErrorRespBaseType:
+		branch, _ := item.ErrorRespBase()
+		return MultiSearchResult{Err: &MultiSearchItemError{
+			Items: []MultiSearchItemFailure{{Index: slot, ErrorRespBase: branch}},
+		}}
+	default:
+		return MultiSearchResult{Err: fmt.Errorf("multi-search: undecodable sub-response for slot %d", slot)}
+	}
+}
+
+// DecodeHits unmarshals the _source of every hit in resp into a T, in hit
+// order. Hits without a _source (e.g. when source fetching is disabled)
+// decode as the zero T. It works equally on a [Client.Search] response and
+// on each slot of [MultiSearchResults].
+func DecodeHits[T any](resp *SearchResp) ([]T, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	out := make([]T, len(resp.Hits.Hits))
+	for i, hit := range resp.Hits.Hits {
+		if len(hit.Source) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(hit.Source, &out[i]); err != nil {
+			return nil, fmt.Errorf("decode hit %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
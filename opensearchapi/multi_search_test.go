@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// msearchStub answers every _msearch request with one sub-response per
+// header line: a search result whose single hit echoes the header's first
+// index, or a per-item error when that index is "missing". It records the
+// number of sub-searches each request carried.
+type msearchStub struct {
+	mu      sync.Mutex
+	batches []int
+}
+
+func (s *msearchStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/_msearch") {
+		_, _ = io.WriteString(w, `{}`)
+		return
+	}
+
+	var responses []string
+	sc := bufio.NewScanner(r.Body)
+	for line := 0; sc.Scan(); line++ {
+		if line%2 == 1 {
+			continue
+		}
+		var header opensearchapi.MultiSearchHeader
+		if err := json.Unmarshal(sc.Bytes(), &header); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		index := header.Index[0]
+		if index == "missing" {
+			responses = append(responses,
+				`{"error":{"type":"index_not_found_exception","reason":"no such index [missing]"},"status":404}`)
+			continue
+		}
+		responses = append(responses, fmt.Sprintf(
+			`{"took":1,"timed_out":false,"_shards":{"total":1,"successful":1,"failed":0},`+
+				`"hits":{"total":{"value":1,"relation":"eq"},"max_score":1,`+
+				`"hits":[{"_index":%q,"_id":"1","_score":1,"_source":{"name":%q}}]},"status":200}`,
+			index, index))
+	}
+
+	s.mu.Lock()
+	s.batches = append(s.batches, len(responses))
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = io.WriteString(w, `{"took":1,"responses":[`+strings.Join(responses, ",")+`]}`)
+}
+
+func newMultiSearchClient(t *testing.T, h http.Handler) *opensearchapi.Client {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{ts.URL}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestMultiSearch(t *testing.T) {
+	t.Parallel()
+
+	type doc struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("results map to input slots across batches", func(t *testing.T) {
+		t.Parallel()
+		stub := &msearchStub{}
+		client := newMultiSearchClient(t, stub)
+
+		ms := opensearchapi.NewMultiSearch(opensearchapi.MultiSearchConfig{
+			Params: &opensearchapi.MSearchParams{MaxConcurrentSearches: 2},
+		})
+		indices := []string{"a", "missing", "c", "d", "e"}
+		for i, index := range indices {
+			size := 1
+			slot := ms.Add(opensearchapi.MultiSearchItem{
+				Indices: []string{index},
+				Body:    &opensearchapi.SearchBody{Size: &size},
+			})
+			require.Equal(t, i, slot)
+		}
+
+		results, err := ms.Do(t.Context(), client)
+		require.NoError(t, err)
+		require.Len(t, results, len(indices))
+		require.Equal(t, []int{2, 2, 1}, stub.batches, "MaxConcurrentSearches bounds each request")
+
+		for i, index := range indices {
+			resp, err := results.Result(i)
+			if index == "missing" {
+				var itemErr *opensearchapi.MultiSearchItemError
+				require.ErrorAs(t, err, &itemErr)
+				require.Len(t, itemErr.Items, 1)
+				require.Equal(t, i, itemErr.Items[0].Index, "failure carries the input slot")
+				require.Equal(t, http.StatusNotFound, itemErr.Items[0].Status)
+				require.Nil(t, resp)
+				continue
+			}
+			require.NoError(t, err)
+			docs, err := opensearchapi.DecodeHits[doc](resp)
+			require.NoError(t, err)
+			require.Equal(t, []doc{{Name: index}}, docs)
+			require.NotNil(t, resp.Inspect().Response)
+		}
+	})
+
+	t.Run("byte budget splits requests", func(t *testing.T) {
+		t.Parallel()
+		stub := &msearchStub{}
+		client := newMultiSearchClient(t, stub)
+
+		// Each item encodes as `{"index":["x"]}\n{}\n` (19 bytes), so a 20-byte
+		// budget fits exactly one per request.
+		ms := opensearchapi.NewMultiSearch(opensearchapi.MultiSearchConfig{MaxBytes: 20})
+		for _, index := range []string{"a", "b", "c"} {
+			ms.Add(opensearchapi.MultiSearchItem{Indices: []string{index}})
+		}
+
+		results, err := ms.Do(t.Context(), client)
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 1, 1}, stub.batches)
+		for i := range results {
+			_, err := results.Result(i)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("request failure fills every slot of its batch", func(t *testing.T) {
+		t.Parallel()
+		client := newMultiSearchClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/_msearch") {
+				_, _ = io.WriteString(w, `{}`)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"status":400,"error":{"type":"illegal_argument_exception","reason":"bad"}}`)
+		}))
+
+		ms := opensearchapi.NewMultiSearch(opensearchapi.MultiSearchConfig{})
+		ms.Add(opensearchapi.MultiSearchItem{Indices: []string{"a"}})
+		ms.Add(opensearchapi.MultiSearchItem{Indices: []string{"b"}})
+
+		results, err := ms.Do(t.Context(), client)
+		require.Error(t, err)
+		for i := range results {
+			resp, itemErr := results.Result(i)
+			require.Nil(t, resp)
+			require.ErrorIs(t, err, itemErr, "the joined error carries the batch failure")
+		}
+	})
+
+	t.Run("out of range slot", func(t *testing.T) {
+		t.Parallel()
+		var results opensearchapi.MultiSearchResults
+		_, err := results.Result(0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+)
+
+// DefaultUpdateRetries is the number of times [Update] retries a
+// read-modify-write cycle after a version conflict when
+// [UpdateOptions.MaxRetries] is zero.
+const DefaultUpdateRetries = 3
+
+var (
+	// ErrUpdateConflict is returned by [Update] when every attempt lost the
+	// race to a concurrent writer. The last version-conflict error is
+	// wrapped alongside it.
+	ErrUpdateConflict = errors.New("optimistic update: version conflict retries exhausted")
+
+	// ErrUpdateNotFound is returned by [Update] when the document does not
+	// exist and [UpdateOptions.CreateIfMissing] is false.
+	ErrUpdateNotFound = errors.New("optimistic update: document not found")
+)
+
+// UpdateOptions configures [Update]. A nil *UpdateOptions uses the defaults.
+type UpdateOptions struct {
+	// MaxRetries bounds how many times a conflicting write is retried with a
+	// fresh read. Zero uses [DefaultUpdateRetries]; a negative value disables
+	// retries.
+	MaxRetries int
+
+	// Backoff returns the delay before retry n (1-based). Nil retries
+	// immediately.
+	Backoff func(n int) time.Duration
+
+	// CreateIfMissing makes a missing document start from the zero T; the
+	// write then uses op_type=create, so a concurrent creator surfaces as a
+	// conflict and is retried like any other.
+	CreateIfMissing bool
+
+	// Routing, Refresh and Pipeline are passed through to the Get and Index
+	// calls that make up each attempt.
+	Routing  []string
+	Refresh  string
+	Pipeline string
+}
+
+// UpdateResult is the document as written by [Update] together with the
+// concurrency metadata the server assigned to the write.
+type UpdateResult[T any] struct {
+	Doc         T
+	Index       string
+	ID          string
+	SeqNo       int64
+	PrimaryTerm int64
+	Version     int64
+	Result      Result
+	// Created reports that the document did not exist before this write.
+	Created bool
+	// Attempts is the number of read-modify-write cycles performed.
+	Attempts int
+}
+
+// Update performs an optimistic-concurrency read-modify-write of one
+// document. Each attempt reads the document, decodes its _source into a T,
+// calls mutate, and indexes the result with if_seq_no/if_primary_term set to
+// the values read, so a concurrent write in between is rejected by the server
+// with a 409 instead of being overwritten. A conflicting attempt is retried
+// from a fresh read up to [UpdateOptions.MaxRetries] times.
+//
+// An error returned by mutate aborts the update and is returned unchanged.
+// When the write succeeded but replica shards failed, the result is returned
+// together with the [*ShardFailureError].
+func Update[T any](
+	ctx context.Context,
+	c *Client,
+	index, id string,
+	mutate func(*T) error,
+	opts *UpdateOptions,
+) (*UpdateResult[T], error) {
+	if opts == nil {
+		opts = &UpdateOptions{}
+	}
+	retries := opts.MaxRetries
+	switch {
+	case retries == 0:
+		retries = DefaultUpdateRetries
+	case retries < 0:
+		retries = 0
+	}
+
+	var lastConflict error
+	for attempt := 1; attempt <= retries+1; attempt++ {
+		if attempt > 1 && opts.Backoff != nil {
+			timer := time.NewTimer(opts.Backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		res, err := updateAttempt(ctx, c, index, id, mutate, opts)
+		if res != nil {
+			res.Attempts = attempt
+		}
+		if !isVersionConflict(err) {
+			return res, err
+		}
+		lastConflict = err
+	}
+	return nil, fmt.Errorf("%w after %d attempts: %w", ErrUpdateConflict, retries+1, lastConflict)
+}
+
+// updateAttempt runs one read-modify-write cycle.
+func updateAttempt[T any](
+	ctx context.Context,
+	c *Client,
+	index, id string,
+	mutate func(*T) error,
+	opts *UpdateOptions,
+) (*UpdateResult[T], error) {
+	getResp, err := c.Doc.Get(ctx, GetReq{
+		Index:  index,
+		ID:     id,
+		Params: &GetParams{Routing: opts.Routing},
+	})
+	missing := isNotFound(getResp, err)
+	if err != nil && !missing {
+		return nil, err
+	}
+	if missing && !opts.CreateIfMissing {
+		return nil, fmt.Errorf("%w: %s/%s", ErrUpdateNotFound, index, id)
+	}
+
+	var doc T
+	if !missing && len(getResp.Source) > 0 {
+		if err := json.Unmarshal(getResp.Source, &doc); err != nil {
+			return nil, fmt.Errorf("optimistic update: decode %s/%s: %w", index, id, err)
+		}
+	}
+	if err := mutate(&doc); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("optimistic update: encode %s/%s: %w", index, id, err)
+	}
+
+	params := &IndexParams{
+		Routing:  opts.Routing,
+		Refresh:  opts.Refresh,
+		Pipeline: opts.Pipeline,
+	}
+	if missing {
+		params.OpType = OpTypeCreate
+	} else {
+		if getResp.SeqNo == nil || getResp.PrimaryTerm == nil {
+			return nil, fmt.Errorf("optimistic update: %s/%s: response carries no _seq_no/_primary_term", index, id)
+		}
+		seqNo, primaryTerm := int(*getResp.SeqNo), int(*getResp.PrimaryTerm)
+		params.IfSeqNo = &seqNo
+		params.IfPrimaryTerm = &primaryTerm
+	}
+
+	indexResp, err := c.Doc.Index(ctx, IndexReq{
+		Index:  index,
+		ID:     id,
+		Body:   bytes.NewReader(body),
+		Params: params,
+	})
+	if err != nil && !IsPartialFailure(err) {
+		return nil, err
+	}
+	return &UpdateResult[T]{
+		Doc:         doc,
+		Index:       indexResp.Index,
+		ID:          indexResp.ID,
+		SeqNo:       indexResp.SeqNo,
+		PrimaryTerm: indexResp.PrimaryTerm,
+		Version:     indexResp.Version,
+		Result:      indexResp.Result,
+		Created:     missing,
+	}, err
+}
+
+// isNotFound reports whether a Get failed because the document (or its
+// index) does not exist.
+func isNotFound(resp *GetResp, err error) bool {
+	if err == nil {
+		return resp != nil && !resp.Found
+	}
+	if resp == nil || resp.response == nil {
+		return false
+	}
+	return resp.response.StatusCode == http.StatusNotFound
+}
+
+// isVersionConflict reports whether err is the server rejecting a write
+// whose if_seq_no/if_primary_term (or op_type=create) precondition failed.
+func isVersionConflict(err error) bool {
+	if errors.Is(err, opensearch.ErrVersionConflict) {
+		return true
+	}
+	var structErr *opensearch.StructError
+	return errors.As(err, &structErr) && structErr.Status == http.StatusConflict
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// docStub serves a single document at /idx/_doc/1 with seq_no tracking.
+// conflicts makes the next N conditional writes fail with a 409 after
+// bumping seq_no, as a concurrent writer would.
+type docStub struct {
+	mu        sync.Mutex
+	source    string
+	seqNo     int
+	conflicts int
+	writes    int
+}
+
+func (s *docStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/idx/_doc/1" && r.URL.Path != "/idx/_create/1" {
+		_, _ = io.WriteString(w, `{}`)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == http.MethodGet {
+		if s.source == "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"_index":"idx","_id":"1","found":false}`)
+			return
+		}
+		fmt.Fprintf(w, `{"_index":"idx","_id":"1","_version":%d,"_seq_no":%d,"_primary_term":1,"found":true,"_source":%s}`,
+			s.seqNo+1, s.seqNo, s.source)
+		return
+	}
+
+	q := r.URL.Query()
+	create := q.Get("op_type") == "create"
+	if s.conflicts > 0 || (create && s.source != "") || (!create && q.Get("if_seq_no") != fmt.Sprint(s.seqNo)) {
+		if s.conflicts > 0 {
+			s.conflicts--
+			s.seqNo++
+		}
+		w.WriteHeader(http.StatusConflict)
+		_, _ = io.WriteString(w, `{"error":{"root_cause":[],"type":"version_conflict_engine_exception",`+
+			`"reason":"[1]: version conflict"},"status":409}`)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	result := "updated"
+	if s.source == "" {
+		result = "created"
+	} else {
+		s.seqNo++
+	}
+	s.source = string(body)
+	s.writes++
+	fmt.Fprintf(w, `{"_index":"idx","_id":"1","_version":%d,"_seq_no":%d,"_primary_term":1,"result":%q,`+
+		`"_shards":{"total":1,"successful":1,"failed":0}}`, s.seqNo+1, s.seqNo, result)
+}
+
+func newUpdateClient(t *testing.T, h http.Handler) *opensearchapi.Client {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{ts.URL}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	type counter struct {
+		Count int `json:"count"`
+	}
+	increment := func(c *counter) error { c.Count++; return nil }
+
+	t.Run("retries after a version conflict", func(t *testing.T) {
+		t.Parallel()
+		stub := &docStub{source: `{"count":1}`, conflicts: 1}
+		client := newUpdateClient(t, stub)
+
+		res, err := opensearchapi.Update(t.Context(), client, "idx", "1", increment, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, res.Attempts)
+		require.Equal(t, counter{Count: 2}, res.Doc)
+		require.Equal(t, int64(2), res.SeqNo)
+		require.Equal(t, opensearchapi.ResultUpdated, res.Result)
+		require.False(t, res.Created)
+		require.JSONEq(t, `{"count":2}`, stub.source)
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		t.Parallel()
+		stub := &docStub{source: `{"count":1}`, conflicts: 10}
+		client := newUpdateClient(t, stub)
+
+		_, err := opensearchapi.Update(t.Context(), client, "idx", "1", increment,
+			&opensearchapi.UpdateOptions{MaxRetries: 2})
+		require.ErrorIs(t, err, opensearchapi.ErrUpdateConflict)
+		require.Equal(t, 7, stub.conflicts, "three attempts were made")
+		require.Zero(t, stub.writes)
+	})
+
+	t.Run("missing document", func(t *testing.T) {
+		t.Parallel()
+		client := newUpdateClient(t, &docStub{})
+
+		_, err := opensearchapi.Update(t.Context(), client, "idx", "1", increment, nil)
+		require.ErrorIs(t, err, opensearchapi.ErrUpdateNotFound)
+	})
+
+	t.Run("creates a missing document when asked", func(t *testing.T) {
+		t.Parallel()
+		stub := &docStub{}
+		client := newUpdateClient(t, stub)
+
+		res, err := opensearchapi.Update(t.Context(), client, "idx", "1", increment,
+			&opensearchapi.UpdateOptions{CreateIfMissing: true})
+		require.NoError(t, err)
+		require.True(t, res.Created)
+		require.Equal(t, counter{Count: 1}, res.Doc)
+		require.Equal(t, opensearchapi.ResultCreated, res.Result)
+	})
+
+	t.Run("mutate error aborts without writing", func(t *testing.T) {
+		t.Parallel()
+		stub := &docStub{source: `{"count":1}`}
+		client := newUpdateClient(t, stub)
+		abort := errors.New("abort")
+
+		_, err := opensearchapi.Update(t.Context(), client, "idx", "1",
+			func(*counter) error { return abort }, nil)
+		require.ErrorIs(t, err, abort)
+		require.Zero(t, stub.writes)
+		require.JSONEq(t, `{"count":1}`, stub.source)
+	})
+}
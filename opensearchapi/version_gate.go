@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/version"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// VersionGateMode selects what a Client does with a call the connected
+// cluster is too old to serve; see [Config.VersionGate].
+type VersionGateMode uint8
+
+const (
+	// VersionGateOff sends every call unchecked. The default.
+	VersionGateOff VersionGateMode = iota
+
+	// VersionGateRefuse fails a call that uses an operation, query
+	// parameter, or body field the cluster does not support with an
+	// [UnsupportedByServerError], without sending it.
+	VersionGateRefuse
+
+	// VersionGateAdapt fails a call to an unsupported operation, or one
+	// setting an unsupported required parameter, like VersionGateRefuse,
+	// but removes unsupported optional query parameters and body fields
+	// from the request and sends the rest. Each removal is reported to the
+	// client's Logger and to the debug logger.
+	VersionGateAdapt
+)
+
+// versionGateRefresh is how long a version the gate fetched itself is
+// trusted, and how long it waits before fetching again after the cluster
+// failed to answer.
+const versionGateRefresh = time.Minute
+
+// operationVersion is a row of the generated operationVersions table.
+type operationVersion struct {
+	Name   string          // operation group
+	Added  string          // version that added the operation; "" for every version
+	Params []versionedName // query parameters added after the operation
+	Fields []versionedName // top-level body fields added after the operation
+}
+
+// versionedName is a query parameter or body field and the version that
+// added it.
+type versionedName struct {
+	Name     string
+	Added    string
+	Required bool
+}
+
+// versionGate checks requests against the version of the cluster. It is
+// shared by a Client and its clones.
+type versionGate struct {
+	mode VersionGateMode
+
+	mu       sync.Mutex
+	version  string        // fetched by the gate when the transport does not know it
+	fetched  time.Time     // when the cluster last answered or failed a fetch
+	fetching chan struct{} // closed when the running fetch ends; nil when none runs
+}
+
+func newVersionGate(mode VersionGateMode) *versionGate {
+	if mode == VersionGateOff {
+		return nil
+	}
+	return &versionGate{mode: mode}
+}
+
+// check returns the request to send in place of req, or an
+// [UnsupportedByServerError]. Calls are let through unchanged when the
+// cluster version is unknown: the gate never fails a call the server might
+// have served.
+func (g *versionGate) check(ctx context.Context, c *Client, method string, req opensearch.Request) (opensearch.Request, error) {
+	ov := operationVersions(req)
+	if ov == nil {
+		return req, nil
+	}
+	server := g.serverVersion(ctx, c.Client)
+	if server == "" {
+		return req, nil
+	}
+	if ov.Added != "" && version.Compare(server, ov.Added) < 0 {
+		return nil, &UnsupportedByServerError{Operation: ov.Name, Requires: ov.Added, ServerVersion: server}
+	}
+
+	params, fields := unsupported(ov.Params, server), unsupported(ov.Fields, server)
+	if len(params) == 0 && len(fields) == 0 {
+		return req, nil
+	}
+	httpReq, err := req.GetRequest(method)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.gateParams(c.Client, httpReq, ov.Name, params, server); err != nil {
+		return nil, err
+	}
+	if err := g.gateFields(c.Client, httpReq, ov.Name, fields, server); err != nil {
+		return nil, err
+	}
+	return preparedRequest{httpReq}, nil
+}
+
+// gateParams refuses or removes the query parameters of req in params. The
+// other parameters are left as they were encoded.
+func (g *versionGate) gateParams(client *opensearch.Client, req *http.Request, op string, params []versionedName, server string) error {
+	if len(params) == 0 || req.URL.RawQuery == "" {
+		return nil
+	}
+	query := req.URL.Query()
+	for _, p := range params {
+		if !query.Has(p.Name) {
+			continue
+		}
+		if g.mode != VersionGateAdapt || p.Required {
+			return &UnsupportedByServerError{Operation: op, Parameter: p.Name, Requires: p.Added, ServerVersion: server}
+		}
+		req.URL.RawQuery = dropQueryParam(req.URL.RawQuery, p.Name)
+		logDropped(client, op, p, server)
+	}
+	return nil
+}
+
+// dropQueryParam removes every pair of key from the raw query, keeping the
+// other pairs byte for byte and in order.
+func dropQueryParam(raw, key string) string {
+	var b strings.Builder
+	for raw != "" {
+		var pair string
+		pair, raw, _ = strings.Cut(raw, "&")
+		k, _, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(k); err == nil && name == key {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(pair)
+	}
+	return b.String()
+}
+
+// gateFields refuses or removes the top-level fields of the JSON body of
+// req in fields. A body that is not a JSON object is left alone.
+func (g *versionGate) gateFields(client *opensearch.Client, req *http.Request, op string, fields []versionedName, server string) error {
+	if len(fields) == 0 || req.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	var obj map[string]json.RawMessage
+	dropped := false
+	if json.Unmarshal(body, &obj) == nil {
+		for _, f := range fields {
+			if _, ok := obj[f.Name]; !ok {
+				continue
+			}
+			if g.mode != VersionGateAdapt {
+				return &UnsupportedByServerError{Operation: op, Parameter: f.Name, Requires: f.Added, ServerVersion: server}
+			}
+			delete(obj, f.Name)
+			dropped = true
+			logDropped(client, op, f, server)
+		}
+	}
+	if dropped {
+		if body, err = json.Marshal(obj); err != nil {
+			return err
+		}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return nil
+}
+
+// serverVersion returns the version of the cluster: the transport's when
+// it knows it, otherwise one read from GET / and kept for
+// versionGateRefresh. It returns "" when neither is available.
+//
+// One call fetches at a time, without holding the lock; concurrent calls
+// wait for its result, or until their own context ends.
+func (g *versionGate) serverVersion(ctx context.Context, client *opensearch.Client) string {
+	if v := client.ServerVersion(); v != "" {
+		return v
+	}
+
+	for {
+		g.mu.Lock()
+		if time.Since(g.fetched) < versionGateRefresh {
+			v := g.version
+			g.mu.Unlock()
+			return v
+		}
+		wait := g.fetching
+		if wait == nil {
+			g.fetching = make(chan struct{})
+			g.mu.Unlock()
+			return g.fetch(ctx, client)
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-wait:
+			// Fetched, or abandoned by its caller: look again.
+		case <-ctx.Done():
+			return ""
+		}
+	}
+}
+
+// fetch reads the version from GET / and wakes the calls waiting for it. A
+// fetch cut short by the caller's context is not recorded, so the next call
+// fetches again.
+func (g *versionGate) fetch(ctx context.Context, client *opensearch.Client) string {
+	var info struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	resp, err := opensearch.Execute(ctx, client, http.MethodGet, InfoReq{}, &info)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	close(g.fetching)
+	g.fetching = nil
+	switch {
+	case err == nil && !resp.IsError():
+		g.version = info.Version.Number
+		g.fetched = time.Now()
+	case ctx.Err() == nil:
+		g.fetched = time.Now()
+	}
+	return g.version
+}
+
+// unsupported returns the names in names the server version lacks.
+func unsupported(names []versionedName, server string) []versionedName {
+	var out []versionedName
+	for _, n := range names {
+		if version.Compare(server, n.Added) < 0 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// logDropped reports a parameter or field removed from a request to the
+// client's Logger, as an error without a request or response like the
+// transport's discovery failures, and to the debug logger.
+func logDropped(client *opensearch.Client, op string, n versionedName, server string) {
+	err := fmt.Errorf("%s: dropped %q, which requires OpenSearch >= %s; server is %s", op, n.Name, n.Added, server)
+	if cfg := client.GetConfig(); cfg != nil && cfg.Logger != nil {
+		_ = cfg.Logger.LogRoundTrip(nil, nil, err, time.Now(), 0)
+	}
+	if dl := opensearchtransport.LoadDebugLogger(); dl != nil {
+		_ = dl.Logf("%s\n", err)
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropQueryParam(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"only parameter", "primary_only=true", ""},
+		{"keeps the others as encoded", "z=1&primary_only=true&a=b%2Cc&q=x+y", "z=1&a=b%2Cc&q=x+y"},
+		{"every occurrence", "primary_only=true&a=1&primary_only=false", "a=1"},
+		{"escaped key", "primary%5Fonly=true&a=1", "a=1"},
+		{"without value", "a=1&primary_only", "a=1"},
+		{"absent", "a=1&b=2", "a=1&b=2"},
+		{"prefix is not a match", "primary_only_x=1", "primary_only_x=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, dropQueryParam(tt.raw, "primary_only"))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package opensearchapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// oldServer reports OpenSearch 2.8.0 and records the calls it receives.
+type oldServer struct {
+	mu    sync.Mutex
+	calls map[string]*http.Request
+	body  map[string]string
+}
+
+func (s *oldServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	s.calls[r.URL.Path] = r
+	s.body[r.URL.Path] = string(body)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/" {
+		_, _ = io.WriteString(w, `{"name":"node","cluster_name":"test","version":{"number":"2.8.0","distribution":"opensearch"}}`)
+		return
+	}
+	_, _ = io.WriteString(w, `{"acknowledged":true}`)
+}
+
+func (s *oldServer) call(path string) (*http.Request, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path], s.body[path]
+}
+
+// logOutput collects the output of a logger the transport writes to from
+// several goroutines.
+type logOutput struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (o *logOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.Write(p)
+}
+
+func (o *logOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.String()
+}
+
+func newGatedClient(t *testing.T, mode opensearchapi.VersionGateMode) (*opensearchapi.Client, *oldServer) {
+	t.Helper()
+	return newLoggedGatedClient(t, mode, nil)
+}
+
+func newLoggedGatedClient(
+	t *testing.T, mode opensearchapi.VersionGateMode, logger opensearchtransport.Logger,
+) (*opensearchapi.Client, *oldServer) {
+	t.Helper()
+	srv := &oldServer{calls: make(map[string]*http.Request), body: make(map[string]string)}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client:      opensearch.Config{Addresses: []string{ts.URL}, Logger: logger},
+		VersionGate: mode,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, srv
+}
+
+func forceMergeReq() *opensearchapi.IndicesForceMergeReq {
+	primaryOnly, wait := true, false
+	return &opensearchapi.IndicesForceMergeReq{
+		Indices: []string{"logs"},
+		Params:  &opensearchapi.IndicesForceMergeParams{PrimaryOnly: &primaryOnly, WaitForCompletion: &wait},
+	}
+}
+
+func restoreReq() opensearchapi.SnapshotRestoreReq {
+	pattern, storage := "(.+)", "remote_snapshot"
+	return opensearchapi.SnapshotRestoreReq{
+		Repository: "repo",
+		Snapshot:   "snap",
+		Body: &opensearchapi.SnapshotRestoreBody{
+			RenameAliasPattern: &pattern,
+			StorageType:        &storage,
+		},
+	}
+}
+
+func TestVersionGate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("refuse fails unsupported operations before sending", func(t *testing.T) {
+		t.Parallel()
+		client, srv := newGatedClient(t, opensearchapi.VersionGateRefuse)
+
+		_, err := client.SearchPipeline.Get(t.Context(), opensearchapi.SearchPipelineGetReq{ID: "p"})
+		require.ErrorIs(t, err, opensearchapi.ErrUnsupportedByServer)
+		var unsupported *opensearchapi.UnsupportedByServerError
+		require.ErrorAs(t, err, &unsupported)
+		require.Equal(t, opensearchapi.UnsupportedByServerError{
+			Operation:     "search_pipeline.get",
+			Requires:      "2.9.0",
+			ServerVersion: "2.8.0",
+		}, *unsupported)
+		req, _ := srv.call("/_search/pipeline/p")
+		require.Nil(t, req)
+	})
+
+	t.Run("refuse fails unsupported parameters and fields", func(t *testing.T) {
+		t.Parallel()
+		client, srv := newGatedClient(t, opensearchapi.VersionGateRefuse)
+
+		_, err := client.Indices.ForceMerge(t.Context(), forceMergeReq())
+		var unsupported *opensearchapi.UnsupportedByServerError
+		require.ErrorAs(t, err, &unsupported)
+		require.Equal(t, "primary_only", unsupported.Parameter)
+		require.Equal(t, "2.13.0", unsupported.Requires)
+
+		_, err = client.Snapshot.Restore(t.Context(), restoreReq())
+		require.ErrorAs(t, err, &unsupported)
+		require.Equal(t, "rename_alias_pattern", unsupported.Parameter)
+
+		// Parameters the server supports pass.
+		wait := false
+		_, err = client.Indices.ForceMerge(t.Context(), &opensearchapi.IndicesForceMergeReq{
+			Indices: []string{"logs"},
+			Params:  &opensearchapi.IndicesForceMergeParams{WaitForCompletion: &wait},
+		})
+		require.NoError(t, err)
+		req, _ := srv.call("/logs/_forcemerge")
+		require.Equal(t, "false", req.URL.Query().Get("wait_for_completion"))
+	})
+
+	t.Run("adapt drops unsupported optional parameters and fields", func(t *testing.T) {
+		t.Parallel()
+		client, srv := newGatedClient(t, opensearchapi.VersionGateAdapt)
+
+		_, err := client.Indices.ForceMerge(t.Context(), forceMergeReq())
+		require.NoError(t, err)
+		req, _ := srv.call("/logs/_forcemerge")
+		require.False(t, req.URL.Query().Has("primary_only"))
+		require.Equal(t, "false", req.URL.Query().Get("wait_for_completion"))
+
+		_, err = client.Snapshot.Restore(t.Context(), restoreReq())
+		require.NoError(t, err)
+		_, body := srv.call("/_snapshot/repo/snap/_restore")
+		require.JSONEq(t, `{"storage_type":"remote_snapshot"}`, body)
+
+		// Unsupported operations are still refused.
+		_, err = client.SearchPipeline.Get(t.Context(), opensearchapi.SearchPipelineGetReq{ID: "p"})
+		require.ErrorIs(t, err, opensearchapi.ErrUnsupportedByServer)
+	})
+
+	t.Run("adapt logs each drop to the client's logger", func(t *testing.T) {
+		t.Parallel()
+		var out logOutput
+		client, _ := newLoggedGatedClient(t, opensearchapi.VersionGateAdapt, &opensearchtransport.TextLogger{Output: &out})
+
+		_, err := client.Indices.ForceMerge(t.Context(), forceMergeReq())
+		require.NoError(t, err)
+		_, err = client.Snapshot.Restore(t.Context(), restoreReq())
+		require.NoError(t, err)
+
+		require.Contains(t, out.String(),
+			`indices.forcemerge: dropped "primary_only", which requires OpenSearch >= 2.13.0; server is 2.8.0`)
+		require.Contains(t, out.String(),
+			`snapshot.restore: dropped "rename_alias_pattern", which requires OpenSearch >= 2.18.0; server is 2.8.0`)
+	})
+
+	t.Run("a cancelled fetch does not disable the gate", func(t *testing.T) {
+		t.Parallel()
+		client, srv := newGatedClient(t, opensearchapi.VersionGateRefuse)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := client.SearchPipeline.Get(ctx, opensearchapi.SearchPipelineGetReq{ID: "p"})
+		require.Error(t, err)
+
+		_, err = client.SearchPipeline.Get(t.Context(), opensearchapi.SearchPipelineGetReq{ID: "p"})
+		require.ErrorIs(t, err, opensearchapi.ErrUnsupportedByServer)
+		req, _ := srv.call("/_search/pipeline/p")
+		require.Nil(t, req)
+	})
+
+	t.Run("off sends every call", func(t *testing.T) {
+		t.Parallel()
+		client, srv := newGatedClient(t, opensearchapi.VersionGateOff)
+
+		_, err := client.Indices.ForceMerge(t.Context(), forceMergeReq())
+		require.NoError(t, err)
+		req, _ := srv.call("/logs/_forcemerge")
+		require.Equal(t, "true", req.URL.Query().Get("primary_only"))
+	})
+}
+
+func TestUnsupportedByServerError(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrapped: %w", &opensearchapi.UnsupportedByServerError{
+		Operation: "search", Parameter: "verbose_pipeline", Requires: "3.0.0", ServerVersion: "2.19.0",
+	})
+	require.True(t, errors.Is(err, opensearchapi.ErrUnsupportedByServer))
+	require.EqualError(t, err,
+		`wrapped: unsupported by server: search parameter "verbose_pipeline" requires OpenSearch >= 3.0.0, server is 2.19.0`)
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Code generated by cmd/osgen; DO NOT EDIT.
+
+package opensearchapi
+
+import (
+	"github.com/opensearch-project/opensearch-go/v5"
+)
+
+// operationVersions returns the OpenSearch versions the parts of req need,
+// or nil when the operation, its query parameters, and its body fields are
+// available on every version.
+func operationVersions(req opensearch.Request) *operationVersion {
+	switch req.(type) {
+	case BulkStreamReq, *BulkStreamReq:
+		return &operationVersion{
+			Name:  "bulk_stream",
+			Added: "2.17.0",
+		}
+	case CatAllPITSegmentsReq, *CatAllPITSegmentsReq:
+		return &operationVersion{
+			Name:  "cat.all_pit_segments",
+			Added: "2.4.0",
+		}
+	case CatClusterManagerReq, *CatClusterManagerReq:
+		return &operationVersion{
+			Name:  "cat.cluster_manager",
+			Added: "2.0.0",
+		}
+	case CatPITSegmentsReq, *CatPITSegmentsReq:
+		return &operationVersion{
+			Name:  "cat.pit_segments",
+			Added: "2.4.0",
+		}
+	case CatSegmentReplicationReq, *CatSegmentReplicationReq:
+		return &operationVersion{
+			Name:  "cat.segment_replication",
+			Added: "2.6.0",
+		}
+	case CreatePITReq, *CreatePITReq:
+		return &operationVersion{
+			Name:  "create_pit",
+			Added: "2.4.0",
+		}
+	case DeleteAllPITsReq, *DeleteAllPITsReq:
+		return &operationVersion{
+			Name:  "delete_all_pits",
+			Added: "2.4.0",
+		}
+	case DeletePITReq, *DeletePITReq:
+		return &operationVersion{
+			Name:  "delete_pit",
+			Added: "2.4.0",
+		}
+	case GetAllPITsReq, *GetAllPITsReq:
+		return &operationVersion{
+			Name:  "get_all_pits",
+			Added: "2.4.0",
+		}
+	case IndicesClearCacheReq, *IndicesClearCacheReq:
+		return &operationVersion{
+			Name: "indices.clear_cache",
+			Params: []versionedName{
+				{Name: "file", Added: "2.8.0"},
+			},
+		}
+	case IndicesCloneReq, *IndicesCloneReq:
+		return &operationVersion{
+			Name: "indices.clone",
+			Params: []versionedName{
+				{Name: "wait_for_completion", Added: "2.7.0"},
+			},
+		}
+	case IndicesForceMergeReq, *IndicesForceMergeReq:
+		return &operationVersion{
+			Name: "indices.forcemerge",
+			Params: []versionedName{
+				{Name: "primary_only", Added: "2.13.0"},
+				{Name: "wait_for_completion", Added: "2.7.0"},
+			},
+		}
+	case IndicesOpenReq, *IndicesOpenReq:
+		return &operationVersion{
+			Name: "indices.open",
+			Params: []versionedName{
+				{Name: "wait_for_completion", Added: "2.7.0"},
+			},
+		}
+	case IndicesPutAliasReq, *IndicesPutAliasReq:
+		return &operationVersion{
+			Name: "indices.put_alias",
+			Fields: []versionedName{
+				{Name: "is_hidden", Added: "2.16.0"},
+			},
+		}
+	case IndicesShrinkReq, *IndicesShrinkReq:
+		return &operationVersion{
+			Name: "indices.shrink",
+			Params: []versionedName{
+				{Name: "wait_for_completion", Added: "2.7.0"},
+			},
+		}
+	case IndicesSplitReq, *IndicesSplitReq:
+		return &operationVersion{
+			Name: "indices.split",
+			Params: []versionedName{
+				{Name: "wait_for_completion", Added: "2.7.0"},
+			},
+		}
+	case IndicesUpgradeReq, *IndicesUpgradeReq:
+		return &operationVersion{
+			Name: "indices.upgrade",
+			Params: []versionedName{
+				{Name: "wait_for_completion", Added: "2.7.0"},
+			},
+		}
+	case SearchReq, *SearchReq:
+		return &operationVersion{
+			Name: "search",
+			Params: []versionedName{
+				{Name: "verbose_pipeline", Added: "3.0.0"},
+			},
+			Fields: []versionedName{
+				{Name: "derived", Added: "2.14.0"},
+			},
+		}
+	case SearchPipelineDeleteReq, *SearchPipelineDeleteReq:
+		return &operationVersion{
+			Name:  "search_pipeline.delete",
+			Added: "2.9.0",
+		}
+	case SearchPipelineGetReq, *SearchPipelineGetReq:
+		return &operationVersion{
+			Name:  "search_pipeline.get",
+			Added: "2.9.0",
+		}
+	case SearchPipelinePutReq, *SearchPipelinePutReq:
+		return &operationVersion{
+			Name:  "search_pipeline.put",
+			Added: "2.9.0",
+		}
+	case SnapshotRestoreReq, *SnapshotRestoreReq:
+		return &operationVersion{
+			Name: "snapshot.restore",
+			Fields: []versionedName{
+				{Name: "rename_alias_pattern", Added: "2.18.0"},
+				{Name: "rename_alias_replacement", Added: "2.18.0"},
+				{Name: "source_remote_store_repository", Added: "2.10.0"},
+				{Name: "storage_type", Added: "2.7.0"},
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package wait
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/apiutil"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// DataStream waits until the data stream name exists and its write index
+// (the last backing index) has its primary shards active, so documents can
+// be appended to it. It returns the data stream as last observed.
+func DataStream(ctx context.Context, c *opensearchapi.Client, name string, opts *Options) (*opensearchapi.IndicesDataStream, error) {
+	var out *opensearchapi.IndicesDataStream
+	err := poll(ctx, "data stream "+name, opts.withDefaults(), func(ctx context.Context, serverWait time.Duration) observation {
+		resp, err := c.Indices.GetDataStream(ctx, &opensearchapi.IndicesGetDataStreamReq{Name: []string{name}})
+		if err != nil {
+			if apiutil.StatusCode(resp) == http.StatusNotFound {
+				return observation{reason: ReasonDataStreamMissing, detail: "data stream " + name + " not found", err: err}
+			}
+			return observation{err: err}
+		}
+
+		var stream *opensearchapi.IndicesDataStream
+		for i := range resp.DataStreams {
+			if resp.DataStreams[i].Name == name {
+				stream = &resp.DataStreams[i]
+				break
+			}
+		}
+		if stream == nil || len(stream.Indices) == 0 {
+			return observation{reason: ReasonDataStreamMissing, detail: "data stream " + name + " has no backing index"}
+		}
+		out = stream
+
+		writeIndex := stream.Indices[len(stream.Indices)-1].IndexName
+		params := &opensearchapi.ClusterHealthParams{WaitForStatus: string(StatusYellow)}
+		params.Timeout = serverWait
+		health, obs := clusterHealth(ctx, c, []string{writeIndex}, params)
+		if health == nil {
+			return obs
+		}
+		if Status(health.Status).rank() >= StatusYellow.rank() {
+			return observation{done: true}
+		}
+		obs = healthShortfall(health)
+		obs.detail = "write index " + writeIndex + ": " + obs.detail
+		return obs
+	})
+	return out, err
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package wait
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/apiutil"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Status is a cluster or index health status.
+type Status string
+
+// Health statuses, in increasing order of health.
+const (
+	StatusRed    Status = "red"
+	StatusYellow Status = "yellow"
+	StatusGreen  Status = "green"
+)
+
+// rank orders statuses so "at least yellow" compares numerically. Unknown
+// statuses rank below red.
+func (s Status) rank() int {
+	switch Status(strings.ToLower(string(s))) {
+	case StatusGreen:
+		return 3
+	case StatusYellow:
+		return 2
+	case StatusRed:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ClusterStatus waits until the cluster health status is at least status.
+func ClusterStatus(ctx context.Context, c *opensearchapi.Client, status Status, opts *Options) (*opensearchapi.ClusterHealthResp, error) {
+	return waitHealth(ctx, c, "cluster status "+string(status), nil, status, opts)
+}
+
+// IndexStatus waits until every index in indices exists and its health is at
+// least status. With [StatusGreen] every primary and replica shard of those
+// indices is allocated and started.
+func IndexStatus(
+	ctx context.Context,
+	c *opensearchapi.Client,
+	indices []string,
+	status Status,
+	opts *Options,
+) (*opensearchapi.ClusterHealthResp, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("wait: IndexStatus requires at least one index")
+	}
+	condition := fmt.Sprintf("indices %s status %s", strings.Join(indices, ","), status)
+	return waitHealth(ctx, c, condition, indices, status, opts)
+}
+
+func waitHealth(
+	ctx context.Context,
+	c *opensearchapi.Client,
+	condition string,
+	indices []string,
+	status Status,
+	opts *Options,
+) (*opensearchapi.ClusterHealthResp, error) {
+	var out *opensearchapi.ClusterHealthResp
+	err := poll(ctx, condition, opts.withDefaults(), func(ctx context.Context, serverWait time.Duration) observation {
+		params := &opensearchapi.ClusterHealthParams{WaitForStatus: string(status)}
+		params.Timeout = serverWait
+		if len(indices) > 0 {
+			params.Level = "indices"
+		}
+		health, obs := clusterHealth(ctx, c, indices, params)
+		if health == nil {
+			return obs
+		}
+		out = health
+		for _, index := range indices {
+			if _, ok := health.Indices[index]; !ok && !strings.ContainsAny(index, "*,") {
+				return observation{reason: ReasonIndexMissing, detail: "index " + index + " not found"}
+			}
+		}
+		if Status(health.Status).rank() >= status.rank() {
+			return observation{done: true}
+		}
+		return healthShortfall(health)
+	})
+	return out, err
+}
+
+// Nodes waits until at least n nodes have joined the cluster.
+func Nodes(ctx context.Context, c *opensearchapi.Client, n int, opts *Options) (*opensearchapi.ClusterHealthResp, error) {
+	var out *opensearchapi.ClusterHealthResp
+	err := poll(ctx, fmt.Sprintf("%d nodes", n), opts.withDefaults(), func(ctx context.Context, serverWait time.Duration) observation {
+		params := &opensearchapi.ClusterHealthParams{WaitForNodes: ">=" + strconv.Itoa(n)}
+		params.Timeout = serverWait
+		health, obs := clusterHealth(ctx, c, nil, params)
+		if health == nil {
+			return obs
+		}
+		out = health
+		if health.NumberOfNodes >= n {
+			return observation{done: true}
+		}
+		return observation{
+			reason: ReasonNodeCount,
+			detail: fmt.Sprintf("%d of %d nodes joined", health.NumberOfNodes, n),
+		}
+	})
+	return out, err
+}
+
+// clusterHealth calls Cluster.Health. A health request whose wait_for_*
+// condition did not hold within its timeout answers 408 with a complete
+// body; that body is decoded and returned like a 200 so the caller can
+// report what was still missing.
+func clusterHealth(
+	ctx context.Context,
+	c *opensearchapi.Client,
+	indices []string,
+	params *opensearchapi.ClusterHealthParams,
+) (*opensearchapi.ClusterHealthResp, observation) {
+	resp, err := c.Cluster.Health(ctx, &opensearchapi.ClusterHealthReq{Indices: indices, Params: params})
+	if err == nil {
+		return resp, observation{}
+	}
+	if apiutil.StatusCode(resp) == http.StatusRequestTimeout {
+		if body := resp.RawBody(); body != nil {
+			var timedOut opensearchapi.ClusterHealthResp
+			if json.NewDecoder(body).Decode(&timedOut) == nil {
+				return &timedOut, observation{}
+			}
+		}
+	}
+	if errors.Is(err, opensearch.ErrIndexNotFound) {
+		return nil, observation{reason: ReasonIndexMissing, detail: "index not found", err: err}
+	}
+	return nil, observation{err: err}
+}
+
+// healthShortfall explains why a health response is below the requested
+// status.
+func healthShortfall(h *opensearchapi.ClusterHealthResp) observation {
+	if h.UnassignedShards > 0 || h.InitializingShards > 0 {
+		return observation{
+			reason: ReasonShardsUnassigned,
+			detail: fmt.Sprintf("status %s, %d unassigned and %d initializing shards",
+				h.Status, h.UnassignedShards, h.InitializingShards),
+		}
+	}
+	return observation{reason: ReasonStatus, detail: "status " + h.Status}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/apiutil"
+	"github.com/opensearch-project/opensearch-go/v5/internal/recovery"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// SnapshotRestore waits until a snapshot restore of indices has finished:
+// every index exists and every shard recovered from the snapshot has reached
+// the DONE stage. Call it after [opensearchapi.SnapshotClient.Restore]
+// returns; indices carry their post-rename names.
+func SnapshotRestore(
+	ctx context.Context,
+	c *opensearchapi.Client,
+	indices []string,
+	opts *Options,
+) (*opensearchapi.IndicesRecoveryResp, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("wait: SnapshotRestore requires at least one index")
+	}
+	condition := "snapshot restore of " + strings.Join(indices, ",")
+
+	var out *opensearchapi.IndicesRecoveryResp
+	err := poll(ctx, condition, opts.withDefaults(), func(ctx context.Context, _ time.Duration) observation {
+		resp, err := c.Indices.Recovery(ctx, &opensearchapi.IndicesRecoveryReq{Indices: indices})
+		if err != nil {
+			if apiutil.StatusCode(resp) == http.StatusNotFound || errors.Is(err, opensearch.ErrIndexNotFound) {
+				return observation{reason: ReasonIndexMissing, detail: "restored index not created yet", err: err}
+			}
+			return observation{err: err}
+		}
+		out = resp
+		return restoreProgress(indices, resp)
+	})
+	return out, err
+}
+
+// restoreProgress reports whether every index in indices has finished
+// recovering from a snapshot.
+func restoreProgress(indices []string, resp *opensearchapi.IndicesRecoveryResp) observation {
+	restore := recovery.ReadRestore(indices, resp)
+	switch {
+	case restore.Missing != "":
+		return observation{reason: ReasonIndexMissing, detail: "no recovery reported for " + restore.Missing}
+	case restore.Done():
+		return observation{done: true}
+	}
+	return observation{
+		reason: ReasonRecoveryInProgress,
+		detail: fmt.Sprintf("%d of %d snapshot shard recoveries not done", restore.Pending, restore.Shards),
+	}
+}
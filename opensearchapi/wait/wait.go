@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package wait blocks until an OpenSearch cluster reaches a condition:
+// a cluster health status, an index with every shard allocated, a node
+// count, a finished snapshot restore, or a data stream with an active write
+// index. It is meant for application startup and for orchestrating rolling
+// upgrades, where the test-only readiness harness is not available.
+//
+// Each waiter combines the server-side wait_for_* parameters of
+// [opensearchapi.ClusterClient.Health] (so a single request parks on the
+// server until the condition holds or the per-request wait elapses) with
+// client-side polling bounded by [Options.Timeout] and the context deadline.
+// When the deadline passes, the waiter returns a [*TimeoutError] naming the
+// [Reason] the condition was last seen unmet:
+//
+//	health, err := wait.IndexStatus(ctx, client, []string{"orders"}, wait.StatusGreen, nil)
+//	var te *wait.TimeoutError
+//	if errors.As(err, &te) && te.Reason == wait.ReasonShardsUnassigned {
+//	    log.Printf("orders still has unassigned shards: %s", te.Detail)
+//	}
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default bounds used when the corresponding [Options] field is zero.
+const (
+	DefaultTimeout      = 5 * time.Minute
+	DefaultPollInterval = time.Second
+	DefaultServerWait   = 10 * time.Second
+)
+
+// Options bounds a wait. A nil *Options uses the defaults.
+type Options struct {
+	// Timeout is the total time to wait. The context deadline, when
+	// earlier, takes precedence. Zero uses [DefaultTimeout].
+	Timeout time.Duration
+
+	// PollInterval is the pause between client-side polls. Zero uses
+	// [DefaultPollInterval].
+	PollInterval time.Duration
+
+	// ServerWait caps how long one health request parks on the server via
+	// its wait_for_* parameters; it is further capped by the remaining
+	// budget. Zero uses [DefaultServerWait].
+	ServerWait time.Duration
+}
+
+func (o *Options) withDefaults() Options {
+	var out Options
+	if o != nil {
+		out = *o
+	}
+	if out.Timeout <= 0 {
+		out.Timeout = DefaultTimeout
+	}
+	if out.PollInterval <= 0 {
+		out.PollInterval = DefaultPollInterval
+	}
+	if out.ServerWait <= 0 {
+		out.ServerWait = DefaultServerWait
+	}
+	return out
+}
+
+// Reason classifies why a condition was still unmet when a wait timed out.
+type Reason int
+
+const (
+	// ReasonUnknown means no poll completed before the deadline.
+	ReasonUnknown Reason = iota
+	// ReasonStatus means the cluster or index health status was below the
+	// requested one.
+	ReasonStatus
+	// ReasonShardsUnassigned means shards were still unassigned or
+	// initializing.
+	ReasonShardsUnassigned
+	// ReasonNodeCount means fewer nodes than requested had joined.
+	ReasonNodeCount
+	// ReasonIndexMissing means a requested index did not exist.
+	ReasonIndexMissing
+	// ReasonRecoveryInProgress means a snapshot restore recovery had not
+	// reached its final stage.
+	ReasonRecoveryInProgress
+	// ReasonDataStreamMissing means the data stream or its write index did
+	// not exist.
+	ReasonDataStreamMissing
+	// ReasonRequestFailed means the last poll failed at the transport or
+	// HTTP level; [TimeoutError.Err] carries the failure.
+	ReasonRequestFailed
+)
+
+// String names the reason, for diagnostics.
+func (r Reason) String() string {
+	switch r {
+	case ReasonStatus:
+		return "status"
+	case ReasonShardsUnassigned:
+		return "shards_unassigned"
+	case ReasonNodeCount:
+		return "node_count"
+	case ReasonIndexMissing:
+		return "index_missing"
+	case ReasonRecoveryInProgress:
+		return "recovery_in_progress"
+	case ReasonDataStreamMissing:
+		return "data_stream_missing"
+	case ReasonRequestFailed:
+		return "request_failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrTimeout matches every [*TimeoutError] under [errors.Is].
+var ErrTimeout = errors.New("wait: timed out")
+
+// TimeoutError reports that a wait's deadline passed before its condition
+// held. Reason and Detail describe the last observation; Err is the last
+// poll failure, if any.
+type TimeoutError struct {
+	Condition string
+	Reason    Reason
+	Detail    string
+	Elapsed   time.Duration
+	Polls     int
+	Err       error
+}
+
+func (e *TimeoutError) Error() string {
+	msg := fmt.Sprintf("wait: %s: timed out after %s (%d polls): %s",
+		e.Condition, e.Elapsed.Round(time.Millisecond), e.Polls, e.Reason)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is [ErrTimeout].
+func (e *TimeoutError) Is(target error) bool { return target == ErrTimeout }
+
+// Unwrap returns the last poll failure, if any.
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// observation is the outcome of one poll: whether the condition held and,
+// when it did not, why.
+type observation struct {
+	done   bool
+	reason Reason
+	detail string
+	err    error
+}
+
+// poll runs check until it reports done, the deadline passes, or ctx is
+// cancelled. check receives the server-side wait it may spend.
+func poll(ctx context.Context, condition string, o Options, check func(ctx context.Context, serverWait time.Duration) observation) error {
+	start := time.Now()
+	deadline := start.Add(o.Timeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+
+	var (
+		last  observation
+		polls int
+	)
+	for {
+		serverWait := min(o.ServerWait, time.Until(deadline))
+		if serverWait < 0 {
+			serverWait = 0
+		}
+		last = check(ctx, serverWait)
+		polls++
+		if last.done {
+			return nil
+		}
+		if last.err != nil && last.reason == ReasonUnknown {
+			last.reason = ReasonRequestFailed
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		timer := time.NewTimer(min(o.PollInterval, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &TimeoutError{
+					Condition: condition, Reason: last.reason, Detail: last.detail,
+					Elapsed: time.Since(start), Polls: polls, Err: last.err,
+				}
+			}
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return &TimeoutError{
+		Condition: condition, Reason: last.reason, Detail: last.detail,
+		Elapsed: time.Since(start), Polls: polls, Err: last.err,
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package wait_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi/wait"
+)
+
+// fastOpts keeps every wait in these tests well under a second.
+var fastOpts = &wait.Options{Timeout: 300 * time.Millisecond, PollInterval: 5 * time.Millisecond}
+
+func newClient(t *testing.T, h http.HandlerFunc) *opensearchapi.Client {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{ts.URL}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// healthHandler answers _cluster/health with body(n) for the n-th health
+// request (0-based), as a 408 when the body's status is not green.
+func healthHandler(calls *atomic.Int32, body func(n int32) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/_cluster/health") {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		b := body(calls.Add(1) - 1)
+		if !strings.Contains(b, `"green"`) {
+			w.WriteHeader(http.StatusRequestTimeout)
+		}
+		_, _ = io.WriteString(w, b)
+	}
+}
+
+func TestClusterStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns once the status is reached", func(t *testing.T) {
+		t.Parallel()
+		var calls atomic.Int32
+		client := newClient(t, healthHandler(&calls, func(n int32) string {
+			if n < 2 {
+				return `{"status":"yellow","timed_out":true,"unassigned_shards":1}`
+			}
+			return `{"status":"green","number_of_nodes":3}`
+		}))
+
+		health, err := wait.ClusterStatus(t.Context(), client, wait.StatusGreen, fastOpts)
+		require.NoError(t, err)
+		require.Equal(t, "green", health.Status)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("lower status satisfies a yellow wait", func(t *testing.T) {
+		t.Parallel()
+		var calls atomic.Int32
+		client := newClient(t, healthHandler(&calls, func(int32) string {
+			return `{"status":"green"}`
+		}))
+
+		_, err := wait.ClusterStatus(t.Context(), client, wait.StatusYellow, fastOpts)
+		require.NoError(t, err)
+	})
+
+	t.Run("timeout carries the reason", func(t *testing.T) {
+		t.Parallel()
+		var calls atomic.Int32
+		client := newClient(t, healthHandler(&calls, func(int32) string {
+			return `{"status":"yellow","timed_out":true,"unassigned_shards":2,"initializing_shards":1}`
+		}))
+
+		_, err := wait.ClusterStatus(t.Context(), client, wait.StatusGreen, fastOpts)
+		require.ErrorIs(t, err, wait.ErrTimeout)
+		var te *wait.TimeoutError
+		require.ErrorAs(t, err, &te)
+		require.Equal(t, wait.ReasonShardsUnassigned, te.Reason)
+		require.Contains(t, te.Detail, "2 unassigned")
+		require.Greater(t, te.Polls, 1)
+	})
+}
+
+func TestIndexStatus(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newClient(t, healthHandler(&calls, func(n int32) string {
+		if n == 0 {
+			return `{"status":"red","indices":{}}`
+		}
+		return `{"status":"green","indices":{"orders":{"status":"green"}}}`
+	}))
+
+	health, err := wait.IndexStatus(t.Context(), client, []string{"orders"}, wait.StatusGreen, fastOpts)
+	require.NoError(t, err)
+	require.Contains(t, health.Indices, "orders")
+
+	_, err = wait.IndexStatus(t.Context(), client, nil, wait.StatusGreen, fastOpts)
+	require.Error(t, err)
+}
+
+func TestNodes(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newClient(t, healthHandler(&calls, func(int32) string {
+		return `{"status":"yellow","number_of_nodes":2}`
+	}))
+
+	_, err := wait.Nodes(t.Context(), client, 2, fastOpts)
+	require.NoError(t, err)
+
+	_, err = wait.Nodes(t.Context(), client, 3, fastOpts)
+	var te *wait.TimeoutError
+	require.ErrorAs(t, err, &te)
+	require.Equal(t, wait.ReasonNodeCount, te.Reason)
+	require.Equal(t, "2 of 3 nodes joined", te.Detail)
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/restored/_recovery" {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		stage := "INDEX"
+		if calls.Add(1) > 2 {
+			stage = "DONE"
+		}
+		fmt.Fprintf(w, `{"restored":{"shards":[`+
+			`{"id":0,"type":"SNAPSHOT","stage":"DONE","primary":true},`+
+			`{"id":1,"type":"SNAPSHOT","stage":%q,"primary":true}]}}`, stage)
+	})
+
+	resp, err := wait.SnapshotRestore(t.Context(), client, []string{"restored"}, fastOpts)
+	require.NoError(t, err)
+	require.Len(t, resp.Entries["restored"].Shards, 2)
+	require.Equal(t, int32(3), calls.Load())
+
+	_, err = wait.SnapshotRestore(t.Context(), client, []string{"other"}, fastOpts)
+	var te *wait.TimeoutError
+	require.ErrorAs(t, err, &te)
+	require.Equal(t, wait.ReasonIndexMissing, te.Reason)
+}
+
+func TestDataStream(t *testing.T) {
+	t.Parallel()
+
+	var created atomic.Bool
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_data_stream/logs":
+			if !created.Swap(true) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"error":{"type":"index_not_found_exception","reason":"no such index [logs]"},"status":404}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"data_streams":[{"name":"logs","generation":2,"status":"GREEN",`+
+				`"template":"logs","timestamp_field":{"name":"@timestamp"},"indices":[`+
+				`{"index_name":".ds-logs-000001","index_uuid":"a"},{"index_name":".ds-logs-000002","index_uuid":"b"}]}]}`)
+		case r.URL.Path == "/_cluster/health/.ds-logs-000002":
+			_, _ = io.WriteString(w, `{"status":"yellow"}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	})
+
+	stream, err := wait.DataStream(t.Context(), client, "logs", fastOpts)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stream.Generation)
+}
+
+func TestTimeoutError(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("connection refused")
+	err := &wait.TimeoutError{
+		Condition: "cluster status green",
+		Reason:    wait.ReasonRequestFailed,
+		Elapsed:   time.Second,
+		Polls:     4,
+		Err:       cause,
+	}
+	require.ErrorIs(t, err, wait.ErrTimeout)
+	require.ErrorIs(t, err, cause)
+	require.Equal(t,
+		"wait: cluster status green: timed out after 1s (4 polls): request_failed: connection refused",
+		err.Error())
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// apiError is an OpenSearch exception, written as the server does:
+//
+//	{"error":{"root_cause":[{"type":...,"reason":...}],"type":...,"reason":...},"status":...}
+type apiError struct {
+	status int
+	typ    string
+	reason string
+	index  string
+}
+
+func (e *apiError) Error() string { return e.typ + ": " + e.reason }
+
+// cause returns the exception object of e.
+func (e *apiError) cause() map[string]any {
+	c := map[string]any{"type": e.typ, "reason": e.reason}
+	if e.index != "" {
+		c["index"] = e.index
+		c["index_uuid"] = "_na_"
+	}
+	return c
+}
+
+func (e *apiError) body() map[string]any {
+	c := e.cause()
+	c["root_cause"] = []any{e.cause()}
+	return map[string]any{"error": c, "status": e.status}
+}
+
+func indexNotFound(name string) *apiError {
+	return &apiError{
+		status: http.StatusNotFound, typ: "index_not_found_exception",
+		reason: "no such index [" + name + "]", index: name,
+	}
+}
+
+func indexExists(name string) *apiError {
+	return &apiError{
+		status: http.StatusBadRequest, typ: "resource_already_exists_exception",
+		reason: fmt.Sprintf("index [%s/_na_] already exists", name), index: name,
+	}
+}
+
+func invalidIndexName(name, why string) *apiError {
+	return &apiError{
+		status: http.StatusBadRequest, typ: "invalid_index_name_exception",
+		reason: fmt.Sprintf("Invalid index name [%s], %s", name, why), index: name,
+	}
+}
+
+func versionConflict(index, reason string) *apiError {
+	return &apiError{status: http.StatusConflict, typ: "version_conflict_engine_exception", reason: reason, index: index}
+}
+
+func documentMissing(index, id string) *apiError {
+	return &apiError{
+		status: http.StatusNotFound, typ: "document_missing_exception",
+		reason: "[" + id + "]: document missing", index: index,
+	}
+}
+
+func illegalArgument(format string, a ...any) *apiError {
+	return &apiError{status: http.StatusBadRequest, typ: "illegal_argument_exception", reason: fmt.Sprintf(format, a...)}
+}
+
+func parsingError(format string, a ...any) *apiError {
+	return &apiError{status: http.StatusBadRequest, typ: "parsing_exception", reason: fmt.Sprintf(format, a...)}
+}
+
+// faultError returns the error of an injected fault with status.
+func faultError(status int) *apiError {
+	if status == http.StatusTooManyRequests {
+		return &apiError{
+			status: status, typ: "rejected_execution_exception",
+			reason: "rejected execution of coordinating operation [injected by opensearchtest]",
+		}
+	}
+	return &apiError{status: status, typ: "exception", reason: http.StatusText(status) + " [injected by opensearchtest]"}
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	writeJSON(w, e.status, e.body())
+}
+
+// writeStringError writes an error whose "error" is a string, as the server
+// does for unknown endpoints and methods.
+func writeStringError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]any{"error": msg, "status": status})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
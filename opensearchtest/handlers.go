@@ -0,0 +1,1002 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// handle serves a request not failed by a fault.
+func (n *Node) handle(w http.ResponseWriter, r *http.Request) {
+	segs := splitPath(r.URL.Path)
+	seg := func(i int) string {
+		if i < len(segs) {
+			return segs[i]
+		}
+		return ""
+	}
+
+	switch {
+	case len(segs) == 0:
+		n.methods(w, r, n.info, http.MethodGet, http.MethodHead)
+	case seg(0) == "_cluster" && seg(1) == "health":
+		n.methods(w, r, n.clusterHealth, http.MethodGet)
+	case seg(0) == "_cluster" && seg(1) == "state":
+		n.methods(w, r, n.clusterState, http.MethodGet)
+	case seg(0) == "_nodes":
+		n.methods(w, r, n.nodesInfo, http.MethodGet)
+	case seg(0) == "_cat" && seg(1) == "shards":
+		n.methods(w, r, n.catShards, http.MethodGet)
+	case seg(0) == "_bulk":
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.bulk(w, r, "") }, http.MethodPost, http.MethodPut)
+	case seg(0) == "_mget":
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.mget(w, r, "") }, http.MethodGet, http.MethodPost)
+	case seg(0) == "_search":
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.search(w, r, "") }, http.MethodGet, http.MethodPost)
+	case seg(0) == "_count":
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.count(w, r, "") }, http.MethodGet, http.MethodPost)
+	case seg(0) == "_refresh":
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.refresh(w, r, "") }, http.MethodGet, http.MethodPost)
+	case seg(0) == "_aliases" && len(segs) == 1:
+		n.methods(w, r, n.updateAliases, http.MethodPost)
+	case seg(0) == "_alias" || (seg(0) == "_aliases" && len(segs) == 2):
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.getAliases(w, r, "", seg(1)) },
+			http.MethodGet, http.MethodHead)
+	case strings.HasPrefix(seg(0), "_"):
+		noHandler(w, r)
+	default:
+		n.handleIndex(w, r, segs)
+	}
+}
+
+// handleIndex serves the APIs under /{index}.
+func (n *Node) handleIndex(w http.ResponseWriter, r *http.Request, segs []string) {
+	name := segs[0]
+	api := ""
+	if len(segs) > 1 {
+		api = segs[1]
+	}
+	id := ""
+	if len(segs) > 2 {
+		id = segs[2]
+	}
+
+	switch {
+	case len(segs) == 1:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut:
+				n.createIndex(w, r, name)
+			case http.MethodDelete:
+				n.deleteIndex(w, r, name)
+			default:
+				n.getIndex(w, r, name)
+			}
+		}, http.MethodPut, http.MethodGet, http.MethodHead, http.MethodDelete)
+	case api == "_doc" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.indexDoc(w, r, name, "", false) }, http.MethodPost)
+	case api == "_doc" && len(segs) == 3:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut, http.MethodPost:
+				n.indexDoc(w, r, name, id, false)
+			case http.MethodDelete:
+				n.deleteDoc(w, r, name, id)
+			default:
+				n.getDoc(w, r, name, id, false)
+			}
+		}, http.MethodPut, http.MethodPost, http.MethodGet, http.MethodHead, http.MethodDelete)
+	case api == "_create" && len(segs) == 3:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.indexDoc(w, r, name, id, true) },
+			http.MethodPut, http.MethodPost)
+	case api == "_source" && len(segs) == 3:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.getDoc(w, r, name, id, true) },
+			http.MethodGet, http.MethodHead)
+	case api == "_update" && len(segs) == 3:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.updateDoc(w, r, name, id) }, http.MethodPost)
+	case api == "_bulk" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.bulk(w, r, name) }, http.MethodPost, http.MethodPut)
+	case api == "_mget" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.mget(w, r, name) }, http.MethodGet, http.MethodPost)
+	case api == "_search" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.search(w, r, name) }, http.MethodGet, http.MethodPost)
+	case api == "_count" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.count(w, r, name) }, http.MethodGet, http.MethodPost)
+	case api == "_refresh" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.refresh(w, r, name) }, http.MethodGet, http.MethodPost)
+	case api == "_mapping" && len(segs) == 2:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) { n.getMapping(w, r, name) }, http.MethodGet)
+	case (api == "_alias" || api == "_aliases") && len(segs) <= 3:
+		n.methods(w, r, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPut, http.MethodPost:
+				n.putAlias(w, r, name, id)
+			case http.MethodDelete:
+				n.deleteAlias(w, r, name, id)
+			default:
+				n.getAliases(w, r, name, id)
+			}
+		}, http.MethodPut, http.MethodPost, http.MethodGet, http.MethodHead, http.MethodDelete)
+	default:
+		noHandler(w, r)
+	}
+}
+
+// methods calls h when r has one of methods, and fails it otherwise.
+func (n *Node) methods(w http.ResponseWriter, r *http.Request, h http.HandlerFunc, methods ...string) {
+	if !slices.Contains(methods, r.Method) {
+		writeStringError(w, http.StatusMethodNotAllowed, "Incorrect HTTP method for uri ["+r.URL.RequestURI()+
+			"] and method ["+r.Method+"], allowed: ["+strings.Join(methods, ", ")+"]")
+		return
+	}
+	h(w, r)
+}
+
+func noHandler(w http.ResponseWriter, r *http.Request) {
+	writeStringError(w, http.StatusBadRequest, "no handler found for uri ["+r.URL.RequestURI()+"] and method ["+r.Method+"]")
+}
+
+func (n *Node) info(w http.ResponseWriter, _ *http.Request) {
+	c := n.cluster
+	writeJSON(w, http.StatusOK, map[string]any{
+		"name":         n.name,
+		"cluster_name": c.name,
+		"cluster_uuid": "opensearchtest-cluster-uuid",
+		"version": map[string]any{
+			"distribution":                        "opensearch",
+			"number":                              c.version,
+			"build_type":                          "opensearchtest",
+			"build_hash":                          "opensearchtest",
+			"build_date":                          "2025-01-01T00:00:00.000000Z",
+			"build_snapshot":                      false,
+			"lucene_version":                      "10.1.0",
+			"minimum_wire_compatibility_version":  "2.19.0",
+			"minimum_index_compatibility_version": "2.0.0",
+		},
+		"tagline": "The OpenSearch Project: https://opensearch.org/",
+	})
+}
+
+func (n *Node) clusterHealth(w http.ResponseWriter, _ *http.Request) {
+	c := n.cluster
+	c.store.mu.Lock()
+	var shards int
+	for _, idx := range c.store.indices {
+		shards += idx.shards
+	}
+	c.store.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"cluster_name":                     c.name,
+		"status":                           "green",
+		"timed_out":                        false,
+		"number_of_nodes":                  len(c.nodes),
+		"number_of_data_nodes":             len(c.nodes),
+		"discovered_master":                true,
+		"discovered_cluster_manager":       true,
+		"active_primary_shards":            shards,
+		"active_shards":                    shards,
+		"relocating_shards":                0,
+		"initializing_shards":              0,
+		"unassigned_shards":                0,
+		"delayed_unassigned_shards":        0,
+		"number_of_pending_tasks":          0,
+		"number_of_in_flight_fetch":        0,
+		"task_max_waiting_in_queue_millis": 0,
+		"active_shards_percent_as_number":  100.0, //nolint:mnd // all shards active
+	})
+}
+
+// clusterState serves the index metadata of _cluster/state, as used by the
+// transport for routing.
+func (n *Node) clusterState(w http.ResponseWriter, r *http.Request) {
+	segs := splitPath(r.URL.Path)
+	expr := ""
+	if len(segs) > 3 {
+		expr = segs[3]
+	}
+	s := n.cluster.store
+	s.mu.Lock()
+	indices, err := s.resolve(expr)
+	meta := map[string]any{}
+	for _, idx := range indices {
+		meta[idx.name] = map[string]any{
+			"routing_num_shards": idx.shards,
+			"settings":           map[string]any{"index": map[string]any{"number_of_shards": strconv.Itoa(idx.shards)}},
+			"aliases":            slices.Sorted(maps.Keys(idx.aliases)),
+		}
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"cluster_name": n.cluster.name,
+		"metadata":     map[string]any{"indices": meta},
+	})
+}
+
+// nodesInfo serves _nodes: every node, or this node for _nodes/_local.
+func (n *Node) nodesInfo(w http.ResponseWriter, r *http.Request) {
+	nodes := n.cluster.nodes
+	if segs := splitPath(r.URL.Path); len(segs) > 1 && segs[1] == "_local" {
+		nodes = []*Node{n}
+	}
+	out := make(map[string]any, len(nodes))
+	for _, node := range nodes {
+		addr := node.publishAddress()
+		host, _, _ := strings.Cut(addr, ":")
+		out[node.id] = map[string]any{
+			"name":              node.name,
+			"transport_address": addr,
+			"host":              host,
+			"ip":                host,
+			"version":           n.cluster.version,
+			"roles":             node.roles,
+			"attributes":        map[string]any{},
+			"http": map[string]any{
+				"bound_address":   []string{addr},
+				"publish_address": addr,
+			},
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"_nodes":       map[string]any{"total": len(nodes), "successful": len(nodes), "failed": 0},
+		"cluster_name": n.cluster.name,
+		"nodes":        out,
+	})
+}
+
+// catShards serves _cat/shards in JSON, spreading the primaries of each
+// index over the nodes.
+func (n *Node) catShards(w http.ResponseWriter, _ *http.Request) {
+	s := n.cluster.store
+	s.mu.Lock()
+	indices, _ := s.resolve("_all")
+	var out []map[string]any
+	for i, idx := range indices {
+		for shard := range idx.shards {
+			node := n.cluster.nodes[(i+shard)%len(n.cluster.nodes)]
+			out = append(out, map[string]any{
+				"index":  idx.name,
+				"shard":  strconv.Itoa(shard),
+				"prirep": "p",
+				"state":  "STARTED",
+				"docs":   strconv.Itoa(len(idx.docs)),
+				"node":   node.name,
+			})
+		}
+	}
+	s.mu.Unlock()
+	if out == nil {
+		out = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (n *Node) createIndex(w http.ResponseWriter, r *http.Request, name string) {
+	body, err := readObject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := n.cluster.store.createIndex(name, body); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"acknowledged": true, "shards_acknowledged": true, "index": name})
+}
+
+func (n *Node) deleteIndex(w http.ResponseWriter, _ *http.Request, name string) {
+	if err := n.cluster.store.deleteIndices(name); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+func (n *Node) getIndex(w http.ResponseWriter, r *http.Request, name string) {
+	s := n.cluster.store
+	s.mu.Lock()
+	indices, err := s.resolve(name)
+	out := map[string]any{}
+	for _, idx := range indices {
+		settings := maps.Clone(idx.settings)
+		settings["number_of_shards"] = strconv.Itoa(idx.shards)
+		settings["number_of_replicas"] = strconv.Itoa(idx.replicas)
+		settings["uuid"] = idx.uuid
+		settings["provided_name"] = idx.name
+		settings["creation_date"] = strconv.FormatInt(idx.created.UnixMilli(), 10)
+		out[idx.name] = map[string]any{
+			"aliases":  aliasObjects(idx),
+			"mappings": idx.mappings,
+			"settings": map[string]any{"index": settings},
+		}
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (n *Node) getMapping(w http.ResponseWriter, _ *http.Request, name string) {
+	s := n.cluster.store
+	s.mu.Lock()
+	indices, err := s.resolve(name)
+	out := map[string]any{}
+	for _, idx := range indices {
+		out[idx.name] = map[string]any{"mappings": idx.mappings}
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (n *Node) refresh(w http.ResponseWriter, _ *http.Request, name string) {
+	s := n.cluster.store
+	s.mu.Lock()
+	indices, err := s.resolve(name)
+	var shards int
+	for _, idx := range indices {
+		shards += idx.shards
+	}
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"_shards": shardsInfo{Total: shards, Successful: shards}})
+}
+
+func (n *Node) indexDoc(w http.ResponseWriter, r *http.Request, name, id string, create bool) {
+	opts, err := parseWriteOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	opts.create = opts.create || create
+	source, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		writeError(w, parsingError("reading request body: %s", readErr))
+		return
+	}
+	res, err := n.cluster.store.indexDoc(name, id, source, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, res.status(), res)
+}
+
+func (n *Node) updateDoc(w http.ResponseWriter, r *http.Request, name, id string) {
+	opts, err := parseWriteOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body, err := readObject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res, err := n.cluster.store.updateDoc(name, id, body, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, res.status(), res)
+}
+
+func (n *Node) deleteDoc(w http.ResponseWriter, r *http.Request, name, id string) {
+	opts, err := parseWriteOptions(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res, err := n.cluster.store.deleteDoc(name, id, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, res.status(), res)
+}
+
+func (n *Node) getDoc(w http.ResponseWriter, _ *http.Request, name, id string, sourceOnly bool) {
+	idx, doc, err := n.cluster.store.getDoc(name, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if doc == nil {
+		if sourceOnly {
+			writeError(w, &apiError{
+				status: http.StatusNotFound, typ: "resource_not_found_exception",
+				reason: "Document not found [" + idx.name + "]/[" + id + "]",
+			})
+			return
+		}
+		writeJSON(w, http.StatusNotFound, map[string]any{"_index": idx.name, "_id": id, "found": false})
+		return
+	}
+	if sourceOnly {
+		writeJSON(w, http.StatusOK, doc.source)
+		return
+	}
+	writeJSON(w, http.StatusOK, getResult(idx, doc))
+}
+
+// getResult returns the get response for doc of idx.
+func getResult(idx *index, doc *document) map[string]any {
+	return map[string]any{
+		"_index":        idx.name,
+		"_id":           doc.id,
+		"_version":      doc.version,
+		"_seq_no":       doc.seqNo,
+		"_primary_term": primaryTerm,
+		"found":         true,
+		"_source":       doc.source,
+	}
+}
+
+func (n *Node) mget(w http.ResponseWriter, r *http.Request, name string) {
+	body, err := readObject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	type ref struct{ index, id string }
+	var refs []ref
+	if docs, ok := body["docs"].([]any); ok {
+		for _, d := range docs {
+			m, _ := d.(map[string]any)
+			index, _ := m["_index"].(string)
+			if index == "" {
+				index = name
+			}
+			refs = append(refs, ref{index: index, id: stringValue(m["_id"])})
+		}
+	}
+	if ids, ok := body["ids"].([]any); ok {
+		for _, id := range ids {
+			refs = append(refs, ref{index: name, id: stringValue(id)})
+		}
+	}
+	if len(refs) == 0 {
+		writeError(w, &apiError{
+			status: http.StatusBadRequest, typ: "action_request_validation_exception",
+			reason: "Validation Failed: 1: no documents to get;",
+		})
+		return
+	}
+
+	docs := make([]any, 0, len(refs))
+	for _, ref := range refs {
+		idx, doc, err := n.cluster.store.getDoc(ref.index, ref.id)
+		switch {
+		case err != nil:
+			docs = append(docs, map[string]any{"_index": ref.index, "_id": ref.id, "error": err.cause()})
+		case doc == nil:
+			docs = append(docs, map[string]any{"_index": idx.name, "_id": ref.id, "found": false})
+		default:
+			docs = append(docs, getResult(idx, doc))
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"docs": docs})
+}
+
+// bulk serves _bulk, applying each action in order.
+func (n *Node) bulk(w http.ResponseWriter, r *http.Request, name string) {
+	start := time.Now()
+	s := n.cluster.store
+	sc := bufio.NewScanner(r.Body)
+	sc.Buffer(make([]byte, 0, 64*1024), 100*1024*1024) //nolint:mnd // up to the default http.max_content_length
+
+	var (
+		items     []any
+		hasErrors bool
+	)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var action map[string]map[string]any
+		if err := json.Unmarshal(line, &action); err != nil || len(action) != 1 {
+			writeError(w, illegalArgument("Malformed action/metadata line [%d], expected START_OBJECT", len(items)+1))
+			return
+		}
+		for op, meta := range action {
+			index, _ := meta["_index"].(string)
+			if index == "" {
+				index = name
+			}
+			id := stringValue(meta["_id"])
+			opts, optErr := parseWriteOptions(metaValues(meta))
+
+			var source []byte
+			if op != "delete" {
+				if !sc.Scan() {
+					writeError(w, parsingError("Validation Failed: 1: no requests added;"))
+					return
+				}
+				source = append([]byte(nil), sc.Bytes()...)
+			}
+
+			var (
+				res *writeResult
+				err = optErr
+			)
+			if err == nil {
+				switch {
+				case index == "":
+					err = &apiError{
+						status: http.StatusBadRequest, typ: "action_request_validation_exception",
+						reason: "Validation Failed: 1: index is missing;",
+					}
+				case op == "index":
+					res, err = s.indexDoc(index, id, source, opts)
+				case op == "create":
+					opts.create = true
+					res, err = s.indexDoc(index, id, source, opts)
+				case op == "update":
+					var body map[string]any
+					if jsonErr := decodeObject(source, &body); jsonErr != nil {
+						err = jsonErr
+						break
+					}
+					res, err = s.updateDoc(index, id, body, opts)
+				case op == "delete":
+					res, err = s.deleteDoc(index, id, opts)
+				default:
+					writeError(w, illegalArgument("Malformed action/metadata line [%d], expected field [create], "+
+						"[delete], [index] or [update] but found [%s]", len(items)+1, op))
+					return
+				}
+			}
+
+			var item map[string]any
+			if err != nil {
+				hasErrors = true
+				item = map[string]any{"_index": index, "_id": id, "status": err.status, "error": err.cause()}
+			} else {
+				item = map[string]any{
+					"_index": res.Index, "_id": res.ID, "_version": res.Version, "result": res.Result,
+					"_shards": res.Shards, "_seq_no": res.SeqNo, "_primary_term": res.PrimaryTerm,
+					"status": res.status(),
+				}
+			}
+			items = append(items, map[string]any{op: item})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		writeError(w, parsingError("reading request body: %s", err))
+		return
+	}
+	if len(items) == 0 {
+		writeError(w, &apiError{
+			status: http.StatusBadRequest, typ: "action_request_validation_exception",
+			reason: "Validation Failed: 1: no requests added;",
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"took":   time.Since(start).Milliseconds(),
+		"errors": hasErrors,
+		"items":  items,
+	})
+}
+
+// searchRequest is the body of a search or count request.
+type searchRequest struct {
+	Query  any  `json:"query"`
+	From   *int `json:"from"`
+	Size   *int `json:"size"`
+	Sort   any  `json:"sort"`
+	Source any  `json:"_source"`
+}
+
+// runSearch returns the hits of the search in body over the indices of
+// name, sorted, before from and size apply.
+func (n *Node) runSearch(name string, req searchRequest) ([]hit, []sortField, *apiError) {
+	q, err := compileQuery(req.Query)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort, err := parseSort(req.Sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := n.cluster.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, err := s.resolve(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var hits []hit
+	for _, idx := range indices {
+		for _, doc := range idx.docs {
+			if ok, score := q(doc); ok {
+				hits = append(hits, hit{index: idx, doc: doc, score: score})
+			}
+		}
+	}
+	sortHits(hits, sort)
+	return hits, sort, nil
+}
+
+func (n *Node) search(w http.ResponseWriter, r *http.Request, name string) {
+	start := time.Now()
+	req, err := readSearchRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	hits, sort, err := n.runSearch(name, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	from, size := 0, 10 //nolint:mnd // the server's default page size
+	if req.From != nil {
+		from = *req.From
+	}
+	if req.Size != nil {
+		size = *req.Size
+	}
+	total := len(hits)
+	page := hits[min(from, total):min(from+size, total)]
+
+	var maxScore any
+	out := make([]any, 0, len(page))
+	for _, h := range page {
+		item := map[string]any{"_index": h.index.name, "_id": h.doc.id}
+		if len(sort) == 0 {
+			item["_score"] = h.score
+			if maxScore == nil || h.score > maxScore.(float64) {
+				maxScore = h.score
+			}
+		} else {
+			item["_score"] = nil
+			item["sort"] = h.sort
+		}
+		if include, ok := req.Source.(bool); !ok || include {
+			item["_source"] = h.doc.source
+		}
+		out = append(out, item)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"took":      time.Since(start).Milliseconds(),
+		"timed_out": false,
+		"_shards":   n.searchShards(name),
+		"hits": map[string]any{
+			"total":     map[string]any{"value": total, "relation": "eq"},
+			"max_score": maxScore,
+			"hits":      out,
+		},
+	})
+}
+
+func (n *Node) count(w http.ResponseWriter, r *http.Request, name string) {
+	req, err := readSearchRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	req.Sort = nil
+	hits, _, err := n.runSearch(name, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(hits), "_shards": n.searchShards(name)})
+}
+
+// searchShards returns the "_shards" of a search over name.
+func (n *Node) searchShards(name string) map[string]any {
+	s := n.cluster.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, _ := s.resolve(name)
+	var shards int
+	for _, idx := range indices {
+		shards += idx.shards
+	}
+	return map[string]any{"total": shards, "successful": shards, "skipped": 0, "failed": 0}
+}
+
+// readSearchRequest reads a search body, with the from, size, and sort query
+// parameters applied over it.
+func readSearchRequest(r *http.Request) (searchRequest, *apiError) {
+	var req searchRequest
+	body, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		return req, parsingError("reading request body: %s", readErr)
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := decodeObject(body, &req); err != nil {
+			return req, err
+		}
+	}
+	params := r.URL.Query()
+	for key, dst := range map[string]**int{"from": &req.From, "size": &req.Size} {
+		if v := params.Get(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return req, illegalArgument("failed to parse [%s] value [%s]", key, v)
+			}
+			*dst = &n
+		}
+	}
+	if v := params.Get("sort"); v != "" {
+		req.Sort = v
+	}
+	return req, nil
+}
+
+func (n *Node) updateAliases(w http.ResponseWriter, r *http.Request) {
+	body, err := readObject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	actions, _ := body["actions"].([]any)
+	if len(actions) == 0 {
+		writeError(w, &apiError{
+			status: http.StatusBadRequest, typ: "action_request_validation_exception",
+			reason: "Validation Failed: 1: no actions specified;",
+		})
+		return
+	}
+	s := n.cluster.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range actions {
+		action, _ := a.(map[string]any)
+		for op, params := range action {
+			p, _ := params.(map[string]any)
+			indexExpr := strings.Join(stringList(p, "index", "indices"), ",")
+			aliases := stringList(p, "alias", "aliases")
+			indices, err := s.resolve(indexExpr)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			for _, idx := range indices {
+				switch op {
+				case "add":
+					for _, alias := range aliases {
+						if _, ok := s.indices[alias]; ok {
+							writeError(w, invalidIndexName(alias, "an index or data stream exists with the same name as the alias"))
+							return
+						}
+						idx.aliases[alias] = struct{}{}
+					}
+				case "remove":
+					for _, alias := range aliases {
+						delete(idx.aliases, alias)
+					}
+				case "remove_index":
+					delete(s.indices, idx.name)
+				default:
+					writeError(w, illegalArgument("[aliases] unknown action [%s]", op))
+					return
+				}
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+func (n *Node) putAlias(w http.ResponseWriter, _ *http.Request, name, alias string) {
+	s := n.cluster.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, err := s.resolve(name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, ok := s.indices[alias]; ok {
+		writeError(w, invalidIndexName(alias, "an index or data stream exists with the same name as the alias"))
+		return
+	}
+	for _, idx := range indices {
+		idx.aliases[alias] = struct{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+func (n *Node) deleteAlias(w http.ResponseWriter, _ *http.Request, name, alias string) {
+	s := n.cluster.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, err := s.resolve(name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var removed bool
+	for _, idx := range indices {
+		if _, ok := idx.aliases[alias]; ok {
+			delete(idx.aliases, alias)
+			removed = true
+		}
+	}
+	if !removed {
+		writeError(w, &apiError{status: http.StatusNotFound, typ: "aliases_not_found_exception", reason: "aliases [" + alias + "] missing"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"acknowledged": true})
+}
+
+// getAliases serves GET _alias, with optional index and alias name filters.
+func (n *Node) getAliases(w http.ResponseWriter, _ *http.Request, name, alias string) {
+	s := n.cluster.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, err := s.resolve(name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	out := map[string]any{}
+	for _, idx := range indices {
+		aliases := aliasObjects(idx)
+		if alias != "" {
+			for a := range aliases {
+				if !matchAny(strings.Split(alias, ","), a) {
+					delete(aliases, a)
+				}
+			}
+			if len(aliases) == 0 {
+				continue
+			}
+		}
+		out[idx.name] = map[string]any{"aliases": aliases}
+	}
+	if alias != "" && len(out) == 0 {
+		writeStringError(w, http.StatusNotFound, "alias ["+alias+"] missing")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func aliasObjects(idx *index) map[string]any {
+	out := make(map[string]any, len(idx.aliases))
+	for a := range idx.aliases {
+		out[a] = map[string]any{}
+	}
+	return out
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if p == "_all" || p == "*" || p == name {
+			return true
+		}
+		if strings.ContainsAny(p, "*?") {
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringList returns the string or strings of the first of keys in m.
+func stringList(m map[string]any, keys ...string) []string {
+	for _, key := range keys {
+		switch v := m[key].(type) {
+		case string:
+			return []string{v}
+		case []any:
+			out := make([]string, 0, len(v))
+			for _, e := range v {
+				out = append(out, stringValue(e))
+			}
+			return out
+		}
+	}
+	return nil
+}
+
+// parseWriteOptions parses the concurrency control parameters of a write.
+func parseWriteOptions(params url.Values) (writeOptions, *apiError) {
+	var opts writeOptions
+	opts.create = params.Get("op_type") == "create"
+	for key, dst := range map[string]**int64{
+		"if_seq_no":       &opts.ifSeqNo,
+		"if_primary_term": &opts.ifPrimaryTerm,
+		"version":         &opts.version,
+	} {
+		v := params.Get(key)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return opts, illegalArgument("failed to parse [%s] value [%s]", key, v)
+		}
+		*dst = &n
+	}
+	if opts.version != nil && !strings.HasPrefix(params.Get("version_type"), "external") {
+		return opts, illegalArgument("internal versioning can not be used for optimistic concurrency control. " +
+			"Please use `if_seq_no` and `if_primary_term` instead")
+	}
+	return opts, nil
+}
+
+// metaValues returns the parameters of a bulk action as query parameters.
+func metaValues(meta map[string]any) url.Values {
+	params := url.Values{}
+	for k, v := range meta {
+		params.Set(k, stringValue(v))
+	}
+	return params
+}
+
+// readObject reads a request body holding a JSON object, or nothing.
+func readObject(r *http.Request) (map[string]any, *apiError) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, parsingError("reading request body: %s", err)
+	}
+	out := map[string]any{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return out, nil
+	}
+	if err := decodeObject(body, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func decodeObject(body []byte, v any) *apiError {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return &apiError{status: http.StatusBadRequest, typ: "json_parse_exception", reason: err.Error()}
+		}
+		return parsingError("%s", err)
+	}
+	return nil
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	segs := strings.Split(p, "/")
+	for i, s := range segs {
+		if u, err := url.PathUnescape(s); err == nil {
+			segs[i] = u
+		}
+	}
+	return segs
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package opensearchtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtest"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchutil"
+)
+
+func newClient(t *testing.T, cluster *opensearchtest.Cluster) *opensearchapi.Client {
+	t.Helper()
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: cluster.Addresses()},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestDocuments(t *testing.T) {
+	t.Parallel()
+	client := newClient(t, opensearchtest.NewCluster(t, opensearchtest.Config{}))
+	ctx := t.Context()
+
+	_, err := client.Indices.Create(ctx, opensearchapi.IndicesCreateReq{
+		Index:      "users",
+		BodyReader: strings.NewReader(`{"settings":{"index":{"number_of_shards":2}},"aliases":{"people":{}}}`),
+	})
+	require.NoError(t, err)
+	_, err = client.Indices.Create(ctx, opensearchapi.IndicesCreateReq{Index: "users"})
+	require.ErrorIs(t, err, opensearch.ErrResourceAlreadyExists)
+
+	indexed, err := client.Document.Index(ctx, opensearchapi.IndexReq{Index: "users", ID: "1", Body: strings.NewReader(`{"name":"Ada"}`)})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), indexed.Version)
+	require.Equal(t, int64(0), indexed.SeqNo)
+
+	_, err = client.Document.Create(ctx, opensearchapi.CreateReq{Index: "users", ID: "1", Body: strings.NewReader(`{}`)})
+	require.ErrorIs(t, err, opensearch.ErrVersionConflict)
+
+	_, err = client.Update(ctx, opensearchapi.UpdateReq{
+		Index: "users", ID: "1", BodyReader: strings.NewReader(`{"doc":{"team":"core"}}`),
+	})
+	require.NoError(t, err)
+
+	stale, term := 0, 1
+	_, err = client.Document.Index(ctx, opensearchapi.IndexReq{
+		Index: "people", ID: "1", Body: strings.NewReader(`{"name":"Grace"}`),
+		Params: &opensearchapi.IndexParams{IfSeqNo: &stale, IfPrimaryTerm: &term},
+	})
+	require.ErrorIs(t, err, opensearch.ErrVersionConflict, "writes through the alias honor if_seq_no")
+
+	got, err := client.Document.Get(ctx, opensearchapi.GetReq{Index: "people", ID: "1"})
+	require.NoError(t, err)
+	require.True(t, got.Found)
+	require.Equal(t, int64(2), *got.Version)
+	require.Equal(t, int64(1), *got.SeqNo)
+	require.JSONEq(t, `{"name":"Ada","team":"core"}`, string(got.Source))
+
+	_, err = client.Document.Delete(ctx, opensearchapi.DeleteReq{Index: "users", ID: "1"})
+	require.NoError(t, err)
+	_, err = client.Document.Get(ctx, opensearchapi.GetReq{Index: "users", ID: "1"})
+	require.Error(t, err)
+
+	_, err = client.Indices.Delete(ctx, &opensearchapi.IndicesDeleteReq{Indices: []string{"users"}})
+	require.NoError(t, err)
+	_, err = client.Document.Get(ctx, opensearchapi.GetReq{Index: "users", ID: "1"})
+	require.ErrorIs(t, err, opensearch.ErrIndexNotFound)
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+	cluster := opensearchtest.NewCluster(t, opensearchtest.Config{})
+	client := newClient(t, cluster)
+	ctx := t.Context()
+
+	var bulk strings.Builder
+	for i, level := range []string{"info", "error", "warn", "error", "info", "error"} {
+		bulk.WriteString(`{"index":{"_index":"logs","_id":"` + strconv.Itoa(i) + `"}}` + "\n")
+		bulk.WriteString(`{"level":"` + level + `","latency":` + strconv.Itoa(i*10) + `,"msg":"Request ` + level + ` on node"}` + "\n")
+	}
+	resp, err := client.Bulk(ctx, opensearchapi.BulkReq{Body: strings.NewReader(bulk.String())})
+	require.NoError(t, err)
+	require.False(t, resp.Errors)
+	require.Len(t, resp.Items, 6)
+	require.Equal(t, 6, cluster.DocCount("logs"))
+
+	search, err := client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{"logs"},
+		BodyReader: strings.NewReader(`{
+			"query": {"bool": {
+				"must": [{"match": {"msg": "error"}}],
+				"filter": [{"range": {"latency": {"gte": 10}}}],
+				"must_not": [{"term": {"_id": "5"}}, {"ids": {"values": ["5"]}}]
+			}},
+			"sort": [{"latency": "desc"}],
+			"from": 0, "size": 1
+		}`),
+	})
+	require.NoError(t, err)
+	require.Len(t, search.Hits.Hits, 1)
+	require.Equal(t, "3", *search.Hits.Hits[0].ID)
+
+	count, err := client.Count(ctx, &opensearchapi.CountReq{
+		Indices:    []string{"logs"},
+		BodyReader: strings.NewReader(`{"query":{"terms":{"level.keyword":["info","warn"]}}}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count.Count)
+
+	mget, err := client.MGet(ctx, opensearchapi.MGetReq{Index: "logs", BodyReader: strings.NewReader(`{"ids":["0","9"]}`)})
+	require.NoError(t, err)
+	require.Len(t, mget.Docs, 2)
+	raw, err := json.Marshal(mget.Docs)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"found":false`)
+}
+
+func TestBulkIndexer(t *testing.T) {
+	t.Parallel()
+	cluster := opensearchtest.NewCluster(t, opensearchtest.Config{Nodes: 2})
+	client := newClient(t, cluster)
+
+	bi, err := opensearchutil.NewBulkIndexer(opensearchutil.BulkIndexerConfig{
+		Client:     client,
+		Index:      "events",
+		NumWorkers: 2,
+		FlushBytes: 512,
+	})
+	require.NoError(t, err)
+	for i := range 100 {
+		require.NoError(t, bi.Add(t.Context(), opensearchutil.BulkIndexerItem{
+			Action:     "index",
+			DocumentID: strconv.Itoa(i),
+			Body:       strings.NewReader(`{"n":` + strconv.Itoa(i) + `}`),
+		}))
+	}
+	require.NoError(t, bi.Close(t.Context()))
+
+	stats := bi.Stats()
+	require.Equal(t, uint64(100), stats.NumIndexed)
+	require.Zero(t, stats.NumFailed)
+	require.Equal(t, 100, cluster.DocCount("events"))
+}
+
+func TestFaults(t *testing.T) {
+	t.Parallel()
+	cluster := opensearchtest.NewCluster(t, opensearchtest.Config{Nodes: 3})
+	client := newClient(t, cluster)
+	ctx := t.Context()
+
+	cluster.InjectFault(opensearchtest.Fault{Path: "/_search", Status: http.StatusTooManyRequests, Times: 1})
+	_, err := client.Search(ctx, &opensearchapi.SearchReq{})
+	require.ErrorIs(t, err, opensearch.ErrRejectedExecution)
+	require.True(t, opensearch.IsRetryable(err))
+	cluster.ClearFaults()
+
+	// A dead node: once the transport has marked it dead, requests go to
+	// the live ones.
+	cluster.Node(0).Kill()
+	require.Eventually(t, func() bool {
+		for range 6 {
+			if _, err := client.Cluster.Health(ctx, nil); err != nil {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Positive(t, cluster.Node(0).Requests())
+
+	cluster.ClearFaults()
+	cluster.InjectFault(opensearchtest.Fault{Delay: time.Second})
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Cluster.Health(short, nil)
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "slow node: %v", err)
+}
+
+func TestTransportDiscovery(t *testing.T) {
+	t.Parallel()
+	cluster := opensearchtest.NewCluster(t, opensearchtest.Config{Nodes: 3})
+
+	u, err := url.Parse(cluster.URL())
+	require.NoError(t, err)
+	tp, err := opensearchtransport.New(opensearchtransport.Config{URLs: []*url.URL{u}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Close() })
+
+	require.NoError(t, tp.DiscoverNodes(t.Context()))
+	require.Len(t, tp.URLs(), 3)
+}
@@ -0,0 +1,498 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// query is a compiled query, returning whether a document matches and its
+// score.
+type query func(doc *document) (bool, float64)
+
+// compileQuery compiles the "query" of a search body. A nil body matches
+// every document.
+func compileQuery(body any) (query, *apiError) {
+	if body == nil {
+		return matchAll, nil
+	}
+	clause, ok := body.(map[string]any)
+	if !ok || len(clause) != 1 {
+		return nil, parsingError("query malformed, must start with start_object and contain a single query")
+	}
+	for name, params := range clause {
+		switch name {
+		case "match_all":
+			return matchAll, nil
+		case "match":
+			return compileMatch(params)
+		case "term":
+			return compileTerm(params)
+		case "terms":
+			return compileTerms(params)
+		case "range":
+			return compileRange(params)
+		case "exists":
+			return compileExists(params)
+		case "ids":
+			return compileIDs(params)
+		case "bool":
+			return compileBool(params)
+		default:
+			return nil, parsingError("unknown query [%s]", name)
+		}
+	}
+	return matchAll, nil
+}
+
+func matchAll(*document) (bool, float64) { return true, 1 }
+
+// singleField returns the field and parameters of a query of one field, as
+// {"field": params}.
+func singleField(kind string, params any) (string, any, *apiError) {
+	m, ok := params.(map[string]any)
+	if !ok || len(m) != 1 {
+		return "", nil, parsingError("[%s] query doesn't support multiple fields", kind)
+	}
+	for field, v := range m {
+		return field, v, nil
+	}
+	return "", nil, nil
+}
+
+func compileMatch(params any) (query, *apiError) {
+	field, v, err := singleField("match", params)
+	if err != nil {
+		return nil, err
+	}
+	text, operator := v, "or"
+	if m, ok := v.(map[string]any); ok {
+		text = m["query"]
+		if op, ok := m["operator"].(string); ok {
+			operator = strings.ToLower(op)
+		}
+	}
+	terms := analyze(fmt.Sprint(text))
+	field = strings.TrimSuffix(field, ".keyword")
+	return func(doc *document) (bool, float64) {
+		tokens := map[string]struct{}{}
+		for _, value := range fieldValues(doc.fields, field) {
+			for _, t := range analyze(stringValue(value)) {
+				tokens[t] = struct{}{}
+			}
+		}
+		var matched int
+		for _, t := range terms {
+			if _, ok := tokens[t]; ok {
+				matched++
+			}
+		}
+		if matched == 0 || (operator == "and" && matched < len(terms)) {
+			return false, 0
+		}
+		return true, float64(matched)
+	}, nil
+}
+
+func compileTerm(params any) (query, *apiError) {
+	field, v, err := singleField("term", params)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := v.(map[string]any); ok {
+		v = m["value"]
+	}
+	return termsQuery(field, []any{v}), nil
+}
+
+func compileTerms(params any) (query, *apiError) {
+	m, ok := params.(map[string]any)
+	if !ok {
+		return nil, parsingError("[terms] query malformed")
+	}
+	for field, v := range m {
+		if field == "boost" {
+			continue
+		}
+		values, ok := v.([]any)
+		if !ok {
+			return nil, parsingError("[terms] query does not support [%s]", field)
+		}
+		return termsQuery(field, values), nil
+	}
+	return nil, parsingError("[terms] query requires a field")
+}
+
+// termsQuery matches documents with a value of field equal to one of
+// values. A text field also matches on its tokens, as a field dynamically
+// mapped as text; a ".keyword" field matches exact values only.
+func termsQuery(field string, values []any) query {
+	keyword := strings.HasSuffix(field, ".keyword")
+	field = strings.TrimSuffix(field, ".keyword")
+	return func(doc *document) (bool, float64) {
+		for _, have := range fieldValues(doc.fields, field) {
+			for _, want := range values {
+				if compareValues(have, want) == 0 {
+					return true, 1
+				}
+				if s, ok := have.(string); ok && !keyword {
+					if slices.Contains(analyze(s), stringValue(want)) {
+						return true, 1
+					}
+				}
+			}
+		}
+		return false, 0
+	}
+}
+
+func compileRange(params any) (query, *apiError) {
+	field, v, err := singleField("range", params)
+	if err != nil {
+		return nil, err
+	}
+	bounds, ok := v.(map[string]any)
+	if !ok {
+		return nil, parsingError("[range] query malformed, no start_object after query name")
+	}
+	field = strings.TrimSuffix(field, ".keyword")
+	return func(doc *document) (bool, float64) {
+		for _, have := range fieldValues(doc.fields, field) {
+			if inRange(have, bounds) {
+				return true, 1
+			}
+		}
+		return false, 0
+	}, nil
+}
+
+func inRange(v any, bounds map[string]any) bool {
+	for op, bound := range bounds {
+		c := compareValues(v, bound)
+		switch op {
+		case "gt":
+			if c <= 0 {
+				return false
+			}
+		case "gte", "from":
+			if c < 0 {
+				return false
+			}
+		case "lt":
+			if c >= 0 {
+				return false
+			}
+		case "lte", "to":
+			if c > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func compileExists(params any) (query, *apiError) {
+	m, _ := params.(map[string]any)
+	field, ok := m["field"].(string)
+	if !ok {
+		return nil, parsingError("[exists] must be provided with a [field]")
+	}
+	field = strings.TrimSuffix(field, ".keyword")
+	return func(doc *document) (bool, float64) {
+		return len(fieldValues(doc.fields, field)) > 0, 1
+	}, nil
+}
+
+func compileIDs(params any) (query, *apiError) {
+	m, _ := params.(map[string]any)
+	values, _ := m["values"].([]any)
+	ids := map[string]struct{}{}
+	for _, v := range values {
+		ids[stringValue(v)] = struct{}{}
+	}
+	return func(doc *document) (bool, float64) {
+		_, ok := ids[doc.id]
+		return ok, 1
+	}, nil
+}
+
+func compileBool(params any) (query, *apiError) {
+	m, ok := params.(map[string]any)
+	if !ok {
+		return nil, parsingError("[bool] query malformed, no start_object after query name")
+	}
+	compileAll := func(key string) ([]query, *apiError) {
+		var clauses []any
+		switch v := m[key].(type) {
+		case nil:
+		case []any:
+			clauses = v
+		default:
+			clauses = []any{v}
+		}
+		out := make([]query, 0, len(clauses))
+		for _, c := range clauses {
+			q, err := compileQuery(c)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, q)
+		}
+		return out, nil
+	}
+	must, err := compileAll("must")
+	if err != nil {
+		return nil, err
+	}
+	filter, err := compileAll("filter")
+	if err != nil {
+		return nil, err
+	}
+	should, err := compileAll("should")
+	if err != nil {
+		return nil, err
+	}
+	mustNot, err := compileAll("must_not")
+	if err != nil {
+		return nil, err
+	}
+	minShould := 0
+	if len(should) > 0 && len(must) == 0 && len(filter) == 0 {
+		minShould = 1
+	}
+	if v, ok := m["minimum_should_match"]; ok {
+		if n, err := strconv.Atoi(stringValue(v)); err == nil {
+			minShould = n
+		}
+	}
+
+	return func(doc *document) (bool, float64) {
+		var score float64
+		for _, q := range must {
+			ok, s := q(doc)
+			if !ok {
+				return false, 0
+			}
+			score += s
+		}
+		for _, q := range filter {
+			if ok, _ := q(doc); !ok {
+				return false, 0
+			}
+		}
+		for _, q := range mustNot {
+			if ok, _ := q(doc); ok {
+				return false, 0
+			}
+		}
+		var matched int
+		for _, q := range should {
+			if ok, s := q(doc); ok {
+				matched++
+				score += s
+			}
+		}
+		if matched < minShould {
+			return false, 0
+		}
+		if score == 0 {
+			score = 1
+		}
+		return true, score
+	}, nil
+}
+
+// sortField is a field of a search sort.
+type sortField struct {
+	field string
+	desc  bool
+}
+
+// parseSort parses the "sort" of a search body: a field name, an object of
+// field to order or {"order": order}, or a list of those.
+func parseSort(v any) ([]sortField, *apiError) {
+	var specs []any
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		specs = t
+	default:
+		specs = []any{t}
+	}
+	out := make([]sortField, 0, len(specs))
+	for _, spec := range specs {
+		switch t := spec.(type) {
+		case string:
+			for _, part := range strings.Split(t, ",") {
+				field, order, _ := strings.Cut(part, ":")
+				out = append(out, sortField{field: field, desc: order == "desc" || (order == "" && field == "_score")})
+			}
+		case map[string]any:
+			for field, o := range t {
+				order, _ := o.(string)
+				if m, ok := o.(map[string]any); ok {
+					order, _ = m["order"].(string)
+				}
+				out = append(out, sortField{field: field, desc: order == "desc" || (order == "" && field == "_score")})
+			}
+		default:
+			return nil, parsingError("malformed sort format")
+		}
+	}
+	return out, nil
+}
+
+// hit is a document matched by a search.
+type hit struct {
+	index *index
+	doc   *document
+	score float64
+	sort  []any
+}
+
+// sortHits sorts hits by fields, or by descending score when fields is
+// empty. Ties keep the insertion order of the documents.
+func sortHits(hits []hit, fields []sortField) {
+	for i := range hits {
+		for _, f := range fields {
+			hits[i].sort = append(hits[i].sort, sortValue(hits[i], f.field))
+		}
+	}
+	slices.SortStableFunc(hits, func(a, b hit) int {
+		if len(fields) == 0 {
+			if c := cmp.Compare(b.score, a.score); c != 0 {
+				return c
+			}
+		}
+		for i, f := range fields {
+			av, bv := a.sort[i], b.sort[i]
+			// Documents missing the field sort last in either order.
+			switch {
+			case av == nil && bv == nil:
+				continue
+			case av == nil:
+				return 1
+			case bv == nil:
+				return -1
+			}
+			c := compareValues(av, bv)
+			if f.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		if c := strings.Compare(a.index.name, b.index.name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.doc.pos, b.doc.pos)
+	})
+}
+
+func sortValue(h hit, field string) any {
+	switch field {
+	case "_score":
+		return h.score
+	case "_id":
+		return h.doc.id
+	case "_doc":
+		return h.doc.pos
+	}
+	values := fieldValues(h.doc.fields, strings.TrimSuffix(field, ".keyword"))
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+// fieldValues returns the non-null values of the dotted field path in
+// fields, flattening arrays.
+func fieldValues(fields map[string]any, field string) []any {
+	if v, ok := fields[field]; ok {
+		return flatten(v)
+	}
+	head, rest, ok := strings.Cut(field, ".")
+	if !ok {
+		return nil
+	}
+	var out []any
+	for _, v := range flatten(fields[head]) {
+		if m, ok := v.(map[string]any); ok {
+			out = append(out, fieldValues(m, rest)...)
+		}
+	}
+	return out
+}
+
+func flatten(v any) []any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		var out []any
+		for _, e := range t {
+			out = append(out, flatten(e)...)
+		}
+		return out
+	default:
+		return []any{v}
+	}
+}
+
+// compareValues compares a and b as numbers when both are numeric and as
+// strings otherwise.
+func compareValues(a, b any) int {
+	af, aok := numberValue(a)
+	bf, bok := numberValue(b)
+	if aok && bok {
+		return cmp.Compare(af, bf)
+	}
+	return strings.Compare(stringValue(a), stringValue(b))
+}
+
+func numberValue(v any) (float64, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	case float64:
+		return t, true
+	case int64:
+		return float64(t), true
+	case int:
+		return float64(t), true
+	default:
+		return 0, false
+	}
+}
+
+func stringValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// analyze splits text into lowercase tokens of letters and digits, as the
+// standard analyzer does for simple text.
+func analyze(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
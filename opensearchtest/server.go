@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package opensearchtest provides an in-process fake OpenSearch cluster for
+// unit tests. It serves a useful subset of the REST API from memory over
+// [httptest.Server]s, so code using opensearchapi.Client, the BulkIndexer, or
+// the transport can be tested without Docker:
+//
+//   - index create, get, exists, and delete, with settings, mappings, and aliases
+//   - document index, create, get, update, and delete, with versions, seq_no,
+//     and optimistic concurrency control
+//   - _bulk, _mget, _search, _count, and _refresh
+//   - _aliases and _alias
+//   - the cluster endpoints the transport uses: GET /, _cluster/health,
+//     _nodes/http, _cat/shards, and _cluster/state/metadata
+//
+// Search supports the match_all, match, term, terms, range, exists, ids, and
+// bool queries, with from, size, and sort. Every node of a [Cluster] serves the
+// same data, and faults such as 429s, slow responses, and dead nodes can be
+// injected per node to exercise retries and failover.
+//
+//	cluster := opensearchtest.NewCluster(t, opensearchtest.Config{Nodes: 3})
+//	client, _ := opensearchapi.NewClient(opensearchapi.Config{
+//		Client: opensearch.Config{Addresses: cluster.Addresses()},
+//	})
+//	cluster.Node(0).Kill()
+package opensearchtest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/poll"
+)
+
+// Defaults of Config.
+const (
+	DefaultClusterName = "opensearch-test"
+	DefaultVersion     = "3.0.0"
+)
+
+// Config configures a [Cluster].
+type Config struct {
+	Nodes       int      // Number of nodes. Defaults to 1.
+	ClusterName string   // Defaults to DefaultClusterName.
+	Version     string   // Version reported by GET /. Defaults to DefaultVersion.
+	Roles       []string // Roles of every node. Defaults to cluster_manager, data, and ingest.
+}
+
+// Cluster is a fake OpenSearch cluster of one or more nodes serving one
+// in-memory store. It is safe for concurrent use.
+type Cluster struct {
+	name    string
+	version string
+	nodes   []*Node
+	store   *store
+}
+
+// NewCluster starts a fake cluster for cfg, closed when tb ends.
+func NewCluster(tb testing.TB, cfg Config) *Cluster {
+	tb.Helper()
+	if cfg.Nodes <= 0 {
+		cfg.Nodes = 1
+	}
+	if cfg.ClusterName == "" {
+		cfg.ClusterName = DefaultClusterName
+	}
+	if cfg.Version == "" {
+		cfg.Version = DefaultVersion
+	}
+	if len(cfg.Roles) == 0 {
+		cfg.Roles = []string{"cluster_manager", "data", "ingest"}
+	}
+
+	c := &Cluster{
+		name:    cfg.ClusterName,
+		version: cfg.Version,
+		store:   newStore(),
+	}
+	for i := range cfg.Nodes {
+		n := &Node{
+			cluster: c,
+			name:    fmt.Sprintf("node-%d", i),
+			roles:   append([]string(nil), cfg.Roles...),
+		}
+		n.id = n.name + "-id"
+		n.server = httptest.NewServer(http.HandlerFunc(n.serveHTTP))
+		c.nodes = append(c.nodes, n)
+	}
+	tb.Cleanup(c.Close)
+	return c
+}
+
+// Addresses returns the URLs of the nodes, as for opensearch.Config.Addresses.
+func (c *Cluster) Addresses() []string {
+	addrs := make([]string, len(c.nodes))
+	for i, n := range c.nodes {
+		addrs[i] = n.URL()
+	}
+	return addrs
+}
+
+// URL returns the URL of the first node.
+func (c *Cluster) URL() string { return c.nodes[0].URL() }
+
+// Nodes returns the nodes of the cluster.
+func (c *Cluster) Nodes() []*Node { return append([]*Node(nil), c.nodes...) }
+
+// Node returns the i-th node of the cluster.
+func (c *Cluster) Node(i int) *Node { return c.nodes[i] }
+
+// InjectFault injects f on every node.
+func (c *Cluster) InjectFault(f Fault) {
+	for _, n := range c.nodes {
+		n.InjectFault(f)
+	}
+}
+
+// ClearFaults removes the faults of every node and revives dead nodes.
+func (c *Cluster) ClearFaults() {
+	for _, n := range c.nodes {
+		n.ClearFaults()
+		n.Revive()
+	}
+}
+
+// DocCount returns the number of documents in the index or alias name, or 0
+// when it does not exist.
+func (c *Cluster) DocCount(name string) int {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	indices, err := c.store.resolve(name)
+	if err != nil {
+		return 0
+	}
+	var n int
+	for _, idx := range indices {
+		n += len(idx.docs)
+	}
+	return n
+}
+
+// Close shuts down every node.
+func (c *Cluster) Close() {
+	for _, n := range c.nodes {
+		n.server.Close()
+	}
+}
+
+// Fault is a failure injected into the responses of a [Node].
+type Fault struct {
+	Method string // Request method to fail; empty fails any method.
+	Path   string // Pattern of the URL paths to fail, as for path.Match; empty fails any path.
+
+	// Delay is waited before the request is handled, or failed, to simulate
+	// a slow node. The wait ends early when the client gives up.
+	Delay time.Duration
+
+	// Status, when set, is returned instead of handling the request, with
+	// Body or an OpenSearch error body for the status.
+	Status int
+	Body   string
+
+	// Drop closes the connection without a response, as a crashed node.
+	Drop bool
+
+	// Times is the number of requests to fail; 0 fails requests until the
+	// fault is cleared.
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	if f.Path != "" {
+		if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Node is a node of a fake [Cluster], served by its own [httptest.Server].
+type Node struct {
+	cluster *Cluster
+	server  *httptest.Server
+	id      string
+	name    string
+	roles   []string
+
+	requests atomic.Int64
+	dead     atomic.Bool
+
+	mu     sync.Mutex
+	faults []*fault
+}
+
+// fault is an injected Fault with the number of requests it has left.
+type fault struct {
+	Fault
+	left int
+}
+
+// ID returns the node ID reported by _nodes.
+func (n *Node) ID() string { return n.id }
+
+// Name returns the node name reported by _nodes.
+func (n *Node) Name() string { return n.name }
+
+// URL returns the base URL of the node.
+func (n *Node) URL() string { return n.server.URL }
+
+// Requests returns the number of requests the node has received, including
+// failed ones.
+func (n *Node) Requests() int64 { return n.requests.Load() }
+
+// Kill makes the node drop every connection without a response until Revive.
+func (n *Node) Kill() { n.dead.Store(true) }
+
+// Revive makes a killed node serve requests again.
+func (n *Node) Revive() { n.dead.Store(false) }
+
+// InjectFault adds f to the faults of the node. The first matching fault
+// applies to each request.
+func (n *Node) InjectFault(f Fault) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.faults = append(n.faults, &fault{Fault: f, left: f.Times})
+}
+
+// ClearFaults removes the faults of the node.
+func (n *Node) ClearFaults() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.faults = nil
+}
+
+// nextFault returns the fault applying to r, if any, counting it down.
+func (n *Node) nextFault(r *http.Request) (Fault, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, f := range n.faults {
+		if !f.matches(r) {
+			continue
+		}
+		if f.Times > 0 {
+			f.left--
+			if f.left <= 0 {
+				n.faults = append(n.faults[:i], n.faults[i+1:]...)
+			}
+		}
+		return f.Fault, true
+	}
+	return Fault{}, false
+}
+
+// publishAddress returns the host:port of the node, as reported by _nodes.
+func (n *Node) publishAddress() string {
+	return n.server.Listener.Addr().String()
+}
+
+func (n *Node) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	n.requests.Add(1)
+	if n.dead.Load() {
+		drop(w)
+		return
+	}
+	if f, ok := n.nextFault(r); ok {
+		if f.Delay > 0 && poll.Sleep(r.Context(), f.Delay) != nil {
+			return
+		}
+		switch {
+		case f.Drop:
+			drop(w)
+			return
+		case f.Status != 0:
+			if f.Body != "" {
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(f.Status)
+				_, _ = w.Write([]byte(f.Body))
+				return
+			}
+			writeError(w, faultError(f.Status))
+			return
+		}
+	}
+	n.handle(w, r)
+}
+
+// drop closes the connection of w without writing a response.
+func drop(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	if tc, ok := conn.(*net.TCPConn); ok {
+		_ = tc.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+// sleep waits for d, returning false when ctx ends first.
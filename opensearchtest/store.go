@@ -0,0 +1,561 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// primaryTerm is the primary term of every document; the fake never fails
+// over a primary.
+const primaryTerm = 1
+
+// store holds the indices of a cluster. Its methods taking the lock are
+// safe for concurrent use; the others require mu to be held.
+type store struct {
+	mu      sync.Mutex
+	indices map[string]*index
+	seq     int64 // Insertion counter ordering documents.
+}
+
+type index struct {
+	name     string
+	uuid     string
+	created  time.Time
+	shards   int
+	replicas int
+	settings map[string]any
+	mappings map[string]any
+	aliases  map[string]struct{}
+	docs     map[string]*document
+	seqNo    int64 // Last sequence number assigned; -1 when none.
+}
+
+type document struct {
+	id      string
+	source  json.RawMessage
+	fields  map[string]any
+	version int64
+	seqNo   int64
+	pos     int64 // Insertion order, for stable sorting.
+}
+
+// shardsInfo is the "_shards" object of responses.
+type shardsInfo struct {
+	Total      int `json:"total"`
+	Successful int `json:"successful"`
+	Failed     int `json:"failed"`
+}
+
+// writeResult is the response to a document write.
+type writeResult struct {
+	Index       string     `json:"_index"`
+	ID          string     `json:"_id"`
+	Version     int64      `json:"_version"`
+	Result      string     `json:"result"`
+	Shards      shardsInfo `json:"_shards"`
+	SeqNo       int64      `json:"_seq_no"`
+	PrimaryTerm int64      `json:"_primary_term"`
+}
+
+// status returns the HTTP status of the write.
+func (r *writeResult) status() int {
+	switch r.Result {
+	case "created":
+		return http.StatusCreated
+	case "not_found":
+		return http.StatusNotFound
+	default:
+		return http.StatusOK
+	}
+}
+
+// writeOptions are the concurrency control parameters of a write.
+type writeOptions struct {
+	create        bool   // op_type=create
+	ifSeqNo       *int64 // if_seq_no
+	ifPrimaryTerm *int64 // if_primary_term
+	version       *int64 // version, with version_type=external
+}
+
+func newStore() *store {
+	return &store{indices: make(map[string]*index)}
+}
+
+// createIndex creates the index name from a create index body.
+func (s *store) createIndex(name string, body map[string]any) *apiError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.indices[name]; ok {
+		return indexExists(name)
+	}
+	if err := validateIndexName(name); err != nil {
+		return err
+	}
+	if s.isAlias(name) {
+		return invalidIndexName(name, "an alias with the same name already exists")
+	}
+	idx := s.newIndex(name)
+	if settings, ok := body["settings"].(map[string]any); ok {
+		idx.settings = flattenSettings(settings)
+		if v, ok := idx.settings["number_of_shards"]; ok {
+			if n, err := strconv.Atoi(fmt.Sprint(v)); err == nil && n > 0 {
+				idx.shards = n
+			}
+		}
+		if v, ok := idx.settings["number_of_replicas"]; ok {
+			if n, err := strconv.Atoi(fmt.Sprint(v)); err == nil && n >= 0 {
+				idx.replicas = n
+			}
+		}
+	}
+	if mappings, ok := body["mappings"].(map[string]any); ok {
+		idx.mappings = mappings
+	}
+	if aliases, ok := body["aliases"].(map[string]any); ok {
+		for alias := range aliases {
+			if _, ok := s.indices[alias]; ok {
+				return invalidIndexName(alias, "an index or data stream exists with the same name as the alias")
+			}
+			idx.aliases[alias] = struct{}{}
+		}
+	}
+	s.indices[name] = idx
+	return nil
+}
+
+func (s *store) newIndex(name string) *index {
+	return &index{
+		name:     name,
+		uuid:     newID(),
+		created:  time.Now(),
+		shards:   1,
+		replicas: 1,
+		settings: map[string]any{},
+		mappings: map[string]any{},
+		aliases:  map[string]struct{}{},
+		docs:     map[string]*document{},
+		seqNo:    -1,
+	}
+}
+
+// deleteIndices deletes the indices matching expr.
+func (s *store) deleteIndices(expr string) *apiError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, err := s.resolve(expr)
+	if err != nil {
+		return err
+	}
+	for _, idx := range indices {
+		delete(s.indices, idx.name)
+	}
+	return nil
+}
+
+// resolve returns the indices named by expr: comma-separated index names,
+// aliases, and wildcard patterns, or "_all". A missing name that is not a
+// pattern is an error. The result is sorted by name.
+func (s *store) resolve(expr string) ([]*index, *apiError) {
+	seen := map[string]*index{}
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "" || part == "_all" || part == "*":
+			maps.Copy(seen, s.indices)
+		case strings.ContainsAny(part, "*?"):
+			for name, idx := range s.indices {
+				if ok, _ := path.Match(part, name); ok {
+					seen[name] = idx
+					continue
+				}
+				for alias := range idx.aliases {
+					if ok, _ := path.Match(part, alias); ok {
+						seen[name] = idx
+					}
+				}
+			}
+		default:
+			if idx, ok := s.indices[part]; ok {
+				seen[part] = idx
+				continue
+			}
+			found := false
+			for name, idx := range s.indices {
+				if _, ok := idx.aliases[part]; ok {
+					seen[name] = idx
+					found = true
+				}
+			}
+			if !found {
+				return nil, indexNotFound(part)
+			}
+		}
+	}
+	out := slices.Collect(maps.Values(seen))
+	slices.SortFunc(out, func(a, b *index) int { return strings.Compare(a.name, b.name) })
+	return out, nil
+}
+
+// writeIndex returns the index a write to name goes to, creating it when
+// it does not exist, as with automatic index creation.
+func (s *store) writeIndex(name string) (*index, *apiError) {
+	if idx, ok := s.indices[name]; ok {
+		return idx, nil
+	}
+	var targets []*index
+	for _, idx := range s.indices {
+		if _, ok := idx.aliases[name]; ok {
+			targets = append(targets, idx)
+		}
+	}
+	switch len(targets) {
+	case 1:
+		return targets[0], nil
+	case 0:
+	default:
+		return nil, illegalArgument("no write index is defined for alias [%s]. The write index may be explicitly "+
+			"disabled using is_write_index=false or the alias points to multiple indices without one being "+
+			"designated as a write index", name)
+	}
+	if err := validateIndexName(name); err != nil {
+		return nil, err
+	}
+	idx := s.newIndex(name)
+	s.indices[name] = idx
+	return idx, nil
+}
+
+func (s *store) isAlias(name string) bool {
+	for _, idx := range s.indices {
+		if _, ok := idx.aliases[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// indexDoc indexes source as document id of the index or alias name. An
+// empty id is generated.
+func (s *store) indexDoc(name, id string, source []byte, opts writeOptions) (*writeResult, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, err := s.writeIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	fields, compact, err := parseSource(idx.name, source)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		id = newID()
+		opts.create = true
+	}
+
+	doc, exists := idx.docs[id]
+	if err := checkWrite(idx.name, id, doc, exists, opts); err != nil {
+		return nil, err
+	}
+	result := "created"
+	version := int64(1)
+	pos := s.nextPos()
+	if exists {
+		result = "updated"
+		version = doc.version + 1
+		pos = doc.pos
+	}
+	if opts.version != nil {
+		version = *opts.version
+	}
+	idx.seqNo++
+	idx.docs[id] = &document{id: id, source: compact, fields: fields, version: version, seqNo: idx.seqNo, pos: pos}
+	return idx.result(id, version, result), nil
+}
+
+// updateDoc applies an update request body to document id.
+func (s *store) updateDoc(name, id string, body map[string]any, opts writeOptions) (*writeResult, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := body["script"]; ok {
+		return nil, illegalArgument("script updates are not supported by opensearchtest")
+	}
+	idx, err := s.writeIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	partial, _ := body["doc"].(map[string]any)
+	doc, exists := idx.docs[id]
+	if !exists {
+		upsert, ok := body["upsert"].(map[string]any)
+		if asUpsert, _ := body["doc_as_upsert"].(bool); asUpsert && partial != nil {
+			upsert, ok = partial, true
+		}
+		if !ok {
+			return nil, documentMissing(idx.name, id)
+		}
+		if err := checkWrite(idx.name, id, nil, false, opts); err != nil {
+			return nil, err
+		}
+		return s.put(idx, id, upsert, 1, s.nextPos(), "created")
+	}
+	if err := checkWrite(idx.name, id, doc, true, opts); err != nil {
+		return nil, err
+	}
+	if partial == nil {
+		return nil, &apiError{
+			status: http.StatusBadRequest, typ: "action_request_validation_exception",
+			reason: "Validation Failed: 1: script or doc is missing;",
+		}
+	}
+
+	merged := deepCopy(doc.fields).(map[string]any)
+	mergeInto(merged, partial)
+	if noop, _ := body["detect_noop"].(bool); noop || body["detect_noop"] == nil {
+		if jsonEqual(merged, doc.fields) {
+			return idx.result(id, doc.version, "noop"), nil
+		}
+	}
+	return s.put(idx, id, merged, doc.version+1, doc.pos, "updated")
+}
+
+// put stores fields as document id with version.
+func (s *store) put(idx *index, id string, fields map[string]any, version, pos int64, result string) (*writeResult, *apiError) {
+	source, err := json.Marshal(fields)
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, typ: "mapper_parsing_exception", reason: err.Error(), index: idx.name}
+	}
+	idx.seqNo++
+	idx.docs[id] = &document{id: id, source: source, fields: fields, version: version, seqNo: idx.seqNo, pos: pos}
+	return idx.result(id, version, result), nil
+}
+
+// deleteDoc deletes document id. A missing document has the result
+// "not_found".
+func (s *store) deleteDoc(name, id string, opts writeOptions) (*writeResult, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx, err := s.writeIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	doc, exists := idx.docs[id]
+	if err := checkWrite(idx.name, id, doc, exists, opts); err != nil {
+		return nil, err
+	}
+	idx.seqNo++
+	if !exists {
+		return idx.result(id, 1, "not_found"), nil
+	}
+	delete(idx.docs, id)
+	r := idx.result(id, doc.version+1, "deleted")
+	return r, nil
+}
+
+// getDoc returns document id of the index or alias name, or nil when it
+// does not exist.
+func (s *store) getDoc(name, id string) (*index, *document, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indices, err := s.resolve(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(indices) != 1 {
+		return nil, nil, illegalArgument("alias [%s] has more than one index associated with it [%s], "+
+			"can't execute a single index op", name, indexNames(indices))
+	}
+	idx := indices[0]
+	doc := idx.docs[id]
+	if doc != nil {
+		d := *doc
+		doc = &d
+	}
+	return idx, doc, nil
+}
+
+func (s *store) nextPos() int64 {
+	s.seq++
+	return s.seq
+}
+
+// result returns the write result for document id of idx.
+func (idx *index) result(id string, version int64, result string) *writeResult {
+	successful := 1
+	if result == "noop" {
+		successful = 0
+	}
+	seqNo := idx.seqNo
+	if doc, ok := idx.docs[id]; ok && result == "noop" {
+		seqNo = doc.seqNo
+	}
+	return &writeResult{
+		Index:       idx.name,
+		ID:          id,
+		Version:     version,
+		Result:      result,
+		Shards:      shardsInfo{Total: 1 + idx.replicas, Successful: successful},
+		SeqNo:       seqNo,
+		PrimaryTerm: primaryTerm,
+	}
+}
+
+// checkWrite applies the concurrency control of opts to a write of doc.
+func checkWrite(index, id string, doc *document, exists bool, opts writeOptions) *apiError {
+	if opts.create && exists {
+		return versionConflict(index, fmt.Sprintf("[%s]: version conflict, document already exists (current version [%d])",
+			id, doc.version))
+	}
+	if opts.ifSeqNo != nil || opts.ifPrimaryTerm != nil {
+		var seqNo, term int64 = -2, 0
+		if exists {
+			seqNo, term = doc.seqNo, primaryTerm
+		}
+		wantSeqNo, wantTerm := int64(-2), int64(0)
+		if opts.ifSeqNo != nil {
+			wantSeqNo = *opts.ifSeqNo
+		}
+		if opts.ifPrimaryTerm != nil {
+			wantTerm = *opts.ifPrimaryTerm
+		}
+		if !exists || seqNo != wantSeqNo || term != wantTerm {
+			if !exists {
+				return versionConflict(index, fmt.Sprintf("[%s]: version conflict, required seqNo [%d], primary term [%d]. "+
+					"but no document was found", id, wantSeqNo, wantTerm))
+			}
+			return versionConflict(index, fmt.Sprintf("[%s]: version conflict, required seqNo [%d], primary term [%d]. "+
+				"current document has seqNo [%d] and primary term [%d]", id, wantSeqNo, wantTerm, seqNo, term))
+		}
+	}
+	if opts.version != nil && exists && *opts.version <= doc.version {
+		return versionConflict(index, fmt.Sprintf("[%s]: version conflict, current version [%d] is higher or equal "+
+			"to the one provided [%d]", id, doc.version, *opts.version))
+	}
+	return nil
+}
+
+// validateIndexName applies the index naming rules of the server.
+func validateIndexName(name string) *apiError {
+	switch {
+	case name == "" || name == "." || name == "..":
+		return invalidIndexName(name, "must not be '.' or '..'")
+	case strings.ToLower(name) != name:
+		return invalidIndexName(name, "must be lowercase")
+	case strings.ContainsAny(name[:1], "_-+"):
+		return invalidIndexName(name, "must not start with '_', '-', or '+'")
+	case strings.ContainsAny(name, `\/*?"<>| ,#:`):
+		return invalidIndexName(name, `must not contain the following characters [ , ", *, \, <, |, ,, >, /, ?]`)
+	}
+	return nil
+}
+
+// parseSource decodes a document source, which must be a JSON object.
+func parseSource(index string, source []byte) (map[string]any, json.RawMessage, *apiError) {
+	dec := json.NewDecoder(bytes.NewReader(source))
+	dec.UseNumber()
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil || fields == nil {
+		return nil, nil, &apiError{
+			status: http.StatusBadRequest, typ: "mapper_parsing_exception",
+			reason: "failed to parse", index: index,
+		}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, source); err != nil {
+		return nil, nil, &apiError{status: http.StatusBadRequest, typ: "mapper_parsing_exception", reason: err.Error(), index: index}
+	}
+	return fields, compact.Bytes(), nil
+}
+
+// flattenSettings returns settings with the "index." prefix and nesting
+// removed, as {"number_of_shards": 1}.
+func flattenSettings(settings map[string]any) map[string]any {
+	out := map[string]any{}
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			key := prefix + k
+			if sub, ok := v.(map[string]any); ok {
+				walk(key+".", sub)
+				continue
+			}
+			out[strings.TrimPrefix(key, "index.")] = v
+		}
+	}
+	walk("", settings)
+	return out
+}
+
+func mergeInto(dst, src map[string]any) {
+	for k, v := range src {
+		if sub, ok := v.(map[string]any); ok {
+			if cur, ok := dst[k].(map[string]any); ok {
+				mergeInto(cur, sub)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+func deepCopy(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, child := range t {
+			out[k] = deepCopy(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, child := range t {
+			out[i] = deepCopy(child)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func jsonEqual(a, b any) bool {
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ja, jb)
+}
+
+func indexNames(indices []*index) string {
+	names := make([]string, len(indices))
+	for i, idx := range indices {
+		names[i] = idx.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// newID returns a random 20 character ID, as auto-generated document IDs.
+func newID() string {
+	b := make([]byte, 15) //nolint:mnd // 20 base64 characters
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtransport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrBudgetExhausted is returned when a request runs out of its
+// [Config.RequestBudget] before an attempt can start: the remaining budget
+// is no more than [Config.BudgetReserve], or a retry backoff would outlast
+// it. The error wraps the failure of the last attempt, if any.
+var ErrBudgetExhausted = errors.New("request budget exhausted")
+
+// defaultBudgetReserveDivisor sets the default BudgetReserve: 10% of the
+// request's budget.
+const defaultBudgetReserveDivisor = 10
+
+// timeoutParam is the server-side timeout query parameter, as encoded by
+// the generated TimeoutParams of the API packages.
+const timeoutParam = "timeout"
+
+// requestBudget tracks the total time left to a request across attempts.
+// The zero value is an unlimited budget.
+type requestBudget struct {
+	deadline time.Time
+	reserve  time.Duration
+
+	// attemptTimeout is the transport's RequestTimeout, which bounds each
+	// attempt when it is shorter than the remaining budget.
+	attemptTimeout time.Duration
+
+	// serverTimeout is set when the transport, not the caller, owns the
+	// request's server-side timeout.
+	serverTimeout bool
+}
+
+// startBudget returns the budget of req: the configured budget from now,
+// or the request context deadline when that is earlier.
+func (c *Transport) startBudget(req *http.Request) requestBudget {
+	if c.requestBudget <= 0 {
+		return requestBudget{}
+	}
+	now := time.Now()
+	b := requestBudget{deadline: now.Add(c.requestBudget), reserve: c.budgetReserve, attemptTimeout: c.requestTimeout}
+	if d, ok := req.Context().Deadline(); ok && d.Before(b.deadline) {
+		b.deadline = d
+	}
+	if b.reserve <= 0 {
+		b.reserve = b.deadline.Sub(now) / defaultBudgetReserveDivisor
+	}
+	switch c.operationClassifier().Classify(req.Method, req.URL.Path) {
+	case OpSearch, OpBulk, OpDocIndex, OpDeleteByQuery:
+		b.serverTimeout = !hasQueryParam(req.URL.RawQuery, timeoutParam)
+	}
+	return b
+}
+
+func (b requestBudget) enabled() bool { return !b.deadline.IsZero() }
+
+// attempt prepares req for an attempt under the budget. It returns the
+// remaining budget, or an error wrapping ErrBudgetExhausted and lastErr
+// when no more than the reserve is left. For operations whose server-side
+// timeout the transport owns, it sets the "timeout" query parameter to the
+// attempt's timeout -- the remaining budget, or RequestTimeout when shorter
+// -- less the reserve, so the server stops before the client abandons the
+// attempt. A RequestTimeout no longer than the reserve holds back the
+// default share of itself instead.
+func (b requestBudget) attempt(req *http.Request, attempt int, lastErr error) (time.Duration, error) {
+	remaining := time.Until(b.deadline)
+	if remaining <= b.reserve {
+		return 0, budgetExhausted(attempt, lastErr)
+	}
+	if b.serverTimeout {
+		timeout, reserve := remaining, b.reserve
+		if b.attemptTimeout > 0 && b.attemptTimeout < timeout {
+			timeout = b.attemptTimeout
+			if reserve >= timeout {
+				reserve = timeout / defaultBudgetReserveDivisor
+			}
+		}
+		setQueryParam(req, timeoutParam, formatServerDuration(timeout-reserve))
+	}
+	return remaining, nil
+}
+
+// allows reports whether a retry backoff of d leaves room for an attempt.
+func (b requestBudget) allows(d time.Duration) bool {
+	return !b.enabled() || time.Until(b.deadline)-d > b.reserve
+}
+
+func budgetExhausted(attempts int, lastErr error) error {
+	if lastErr == nil {
+		return fmt.Errorf("%w after %d attempts", ErrBudgetExhausted, attempts)
+	}
+	return fmt.Errorf("%w after %d attempts: %w", ErrBudgetExhausted, attempts, lastErr)
+}
+
+// lastFailure returns the failure of the last attempt: its error, or its
+// retryable response status.
+func lastFailure(res *http.Response, err error) error {
+	if err == nil && res != nil {
+		return fmt.Errorf("last attempt returned %s", res.Status)
+	}
+	return err
+}
+
+// formatServerDuration formats d as an OpenSearch time value in whole
+// milliseconds, rounding down so the server stops no later than d.
+func formatServerDuration(d time.Duration) string {
+	return strconv.FormatInt(max(d.Milliseconds(), 1), 10) + "ms"
+}
+
+// hasQueryParam reports whether the raw query sets key.
+func hasQueryParam(raw, key string) bool {
+	for raw != "" {
+		var kv string
+		kv, raw, _ = strings.Cut(raw, "&")
+		if k, _, _ := strings.Cut(kv, "="); k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// setQueryParam sets key to value in the request query, replacing any
+// earlier value so that each attempt carries its own.
+func setQueryParam(req *http.Request, key, value string) {
+	param := key + "=" + value
+	parts := strings.Split(req.URL.RawQuery, "&")
+	for i, kv := range parts {
+		if k, _, _ := strings.Cut(kv, "="); k == key {
+			parts[i] = param
+			req.URL.RawQuery = strings.Join(parts, "&")
+			return
+		}
+	}
+	if req.URL.RawQuery == "" {
+		req.URL.RawQuery = param
+	} else {
+		req.URL.RawQuery += "&" + param
+	}
+}
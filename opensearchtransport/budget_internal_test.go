@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtransport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport/testutil/mockhttp"
+)
+
+// newBudgetTransport returns a Transport with cfg's budget settings sending
+// through fn.
+func newBudgetTransport(t *testing.T, cfg Config, fn func(*http.Request) (*http.Response, error)) *Transport {
+	t.Helper()
+	u, _ := url.Parse("http://localhost:9200")
+	cfg.URLs = []*url.URL{u, u, u}
+	cfg.SkipConnectionShuffle = true
+	cfg.HealthCheck = NoOpHealthCheck
+	cfg.NodeStatsInterval = -1
+	cfg.Transport = mockhttp.NewRoundTripFunc(t, fn)
+	tp, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Close() })
+	return tp
+}
+
+func budgetResponse(req *http.Request, status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    req,
+	}
+}
+
+func TestRequestBudgetServerTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name, method, target string
+		budget               time.Duration
+		requestTimeout       time.Duration
+		reserve              time.Duration
+		ctxTimeout           time.Duration
+		want                 func(t *testing.T, queries []url.Values)
+	}{
+		{
+			name: "search gets the remaining budget less the reserve", method: http.MethodPost, target: "/logs/_search",
+			budget: time.Second,
+			want: func(t *testing.T, queries []url.Values) {
+				require.Len(t, queries, 3)
+				prev := time.Duration(1<<63 - 1)
+				for _, q := range queries {
+					require.Len(t, q["timeout"], 1, "one timeout per attempt")
+					d, err := time.ParseDuration(q.Get("timeout"))
+					require.NoError(t, err)
+					require.LessOrEqual(t, d, 900*time.Millisecond)
+					require.LessOrEqual(t, d, prev)
+					prev = d
+				}
+			},
+		},
+		{
+			name: "an earlier context deadline takes the budget's place", method: http.MethodPut, target: "/logs/_doc/1",
+			budget: time.Hour, ctxTimeout: 500 * time.Millisecond,
+			want: func(t *testing.T, queries []url.Values) {
+				d, err := time.ParseDuration(queries[0].Get("timeout"))
+				require.NoError(t, err)
+				require.LessOrEqual(t, d, 500*time.Millisecond)
+			},
+		},
+		{
+			name: "a shorter RequestTimeout bounds the server timeout", method: http.MethodPost, target: "/logs/_search",
+			budget: time.Hour, requestTimeout: time.Second, reserve: 100 * time.Millisecond,
+			want: func(t *testing.T, queries []url.Values) {
+				for _, q := range queries {
+					require.Equal(t, "900ms", q.Get("timeout"))
+				}
+			},
+		},
+		{
+			name: "a RequestTimeout within the reserve keeps a share of itself", method: http.MethodPost, target: "/_bulk",
+			budget: time.Hour, requestTimeout: time.Second,
+			want: func(t *testing.T, queries []url.Values) {
+				for _, q := range queries {
+					require.Equal(t, "900ms", q.Get("timeout"))
+				}
+			},
+		},
+		{
+			name: "an explicit timeout is kept", method: http.MethodPost, target: "/_bulk?timeout=5s",
+			budget: time.Second,
+			want: func(t *testing.T, queries []url.Values) {
+				for _, q := range queries {
+					require.Equal(t, []string{"5s"}, q["timeout"])
+				}
+			},
+		},
+		{
+			name: "other operations are left alone", method: http.MethodGet, target: "/logs/_doc/1",
+			budget: time.Second,
+			want: func(t *testing.T, queries []url.Values) {
+				for _, q := range queries {
+					require.False(t, q.Has("timeout"))
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var queries []url.Values
+			tp := newBudgetTransport(t, Config{
+				RequestBudget:  tt.budget,
+				RequestTimeout: tt.requestTimeout,
+				BudgetReserve:  tt.reserve,
+				MaxRetries:     2,
+			}, func(req *http.Request) (*http.Response, error) {
+				queries = append(queries, req.URL.Query())
+				if len(queries) < 3 {
+					return budgetResponse(req, http.StatusServiceUnavailable), nil
+				}
+				return budgetResponse(req, http.StatusOK), nil
+			})
+
+			ctx := t.Context()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+			req, _ := http.NewRequestWithContext(ctx, tt.method, tt.target, nil)
+			res, err := tp.Request(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			tt.want(t, queries)
+		})
+	}
+}
+
+func TestRequestBudgetExhausted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries stop when the budget runs out", func(t *testing.T) {
+		t.Parallel()
+		var attempts int
+		tp := newBudgetTransport(t, Config{RequestBudget: 100 * time.Millisecond, MaxRetries: 100}, func(req *http.Request) (*http.Response, error) {
+			attempts++
+			time.Sleep(20 * time.Millisecond)
+			return budgetResponse(req, http.StatusServiceUnavailable), nil
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		res, err := tp.Request(req)
+		require.ErrorIs(t, err, ErrBudgetExhausted)
+		require.ErrorContains(t, err, "last attempt returned")
+		require.Nil(t, res)
+		require.Less(t, attempts, 10)
+	})
+
+	t.Run("an attempt is cut short at the budget", func(t *testing.T) {
+		t.Parallel()
+		tp := newBudgetTransport(t, Config{RequestBudget: 50 * time.Millisecond}, func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+
+		req, _ := http.NewRequest(http.MethodPost, "/logs/_search", nil)
+		start := time.Now()
+		_, err := tp.Request(req)
+		require.ErrorIs(t, err, ErrBudgetExhausted)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("a backoff past the budget fails without waiting", func(t *testing.T) {
+		t.Parallel()
+		tp := newBudgetTransport(t, Config{
+			RequestBudget: 200 * time.Millisecond,
+			RetryBackoff:  func(int) time.Duration { return time.Minute },
+		}, func(req *http.Request) (*http.Response, error) {
+			return budgetResponse(req, http.StatusBadGateway), nil
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		start := time.Now()
+		_, err := tp.Request(req)
+		require.ErrorIs(t, err, ErrBudgetExhausted)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("the caller's deadline is not the budget's", func(t *testing.T) {
+		t.Parallel()
+		tp := newBudgetTransport(t, Config{RequestBudget: time.Hour}, func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+		_, err := tp.Request(req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.False(t, errors.Is(err, ErrBudgetExhausted))
+	})
+}
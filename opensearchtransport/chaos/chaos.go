@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package chaos provides a fault-injecting http.RoundTripper for testing the
+// failover behavior of an opensearchtransport.Transport.
+//
+// A Transport wraps the RoundTripper that reaches the cluster (a real one,
+// an httptest server, or a mock) and applies Rules to each request. A rule
+// selects requests by node, method, and path, fires at a rate, a number of
+// times, or within a time window, and injects latency and a Fault: a dropped
+// connection, an error status, an HTTP/2 stream reset, or a truncated body.
+// Rules can be added, replaced, and removed while requests are in flight,
+// so a test can script an outage and assert on the transport's Metrics and
+// observer events as it unfolds:
+//
+//	ct, _ := chaos.New(chaos.Config{Transport: http.DefaultTransport})
+//	tp, _ := opensearchtransport.New(opensearchtransport.Config{URLs: urls, Transport: ct})
+//
+//	// Node 9201 refuses every request for two seconds.
+//	_ = ct.AddRule(chaos.Rule{Name: "outage", Host: "127.0.0.1:9201", For: 2 * time.Second, Fault: chaos.Drop()})
+package chaos
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// Config configures a Transport.
+type Config struct {
+	// Transport sends the requests that no rule fails. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Seed seeds the random source used for rule rates and latency
+	// distributions, making a run reproducible. Zero picks a random seed.
+	Seed uint64
+
+	// Rules are the rules installed when the Transport is created.
+	Rules []Rule
+}
+
+// Rule injects a fault into the requests it matches.
+//
+// A request matches a rule when it has the rule's Host, Method, and Path;
+// an empty field matches any request. Of the matching rules that are
+// active, the first, in the order added, that fires by its Rate is
+// applied: its Latency is waited out, then its Fault decides the response.
+type Rule struct {
+	// Name identifies the rule to RemoveRule and Fired. Names need not be
+	// unique; rules sharing a name are removed and counted together.
+	Name string
+
+	// Host matches the request URL host, as "host:port" or a host name
+	// matching every port.
+	Host string
+
+	// Method matches the request method.
+	Method string
+
+	// Path matches the request URL path with path.Match, as "/_bulk" or
+	// "/*/_search".
+	Path string
+
+	// Rate is the probability, in [0, 1], that the rule fires for a
+	// matching request. Zero fires for every matching request.
+	Rate float64
+
+	// After delays the start of the rule by a duration from when it is
+	// added; For limits how long it is active after that. Zero For keeps
+	// the rule active until removed.
+	After, For time.Duration
+
+	// Times limits how many times the rule fires. Zero is unlimited.
+	Times int
+
+	// Latency delays the request before the fault is applied. The delay is
+	// cut short when the request context is done.
+	Latency Distribution
+
+	// Fault produces the response of the request. A nil Fault sends the
+	// request on unchanged, so a rule with only Latency slows a node down.
+	Fault Fault
+}
+
+func (r Rule) validate() error {
+	if r.Rate < 0 || r.Rate > 1 {
+		return fmt.Errorf("chaos: rule %q: rate %v out of range [0, 1]", r.Name, r.Rate)
+	}
+	if r.After < 0 || r.For < 0 {
+		return fmt.Errorf("chaos: rule %q: negative After or For", r.Name)
+	}
+	if r.Times < 0 {
+		return fmt.Errorf("chaos: rule %q: negative Times", r.Name)
+	}
+	if r.Path != "" {
+		if _, err := path.Match(r.Path, ""); err != nil {
+			return fmt.Errorf("chaos: rule %q: path %q: %w", r.Name, r.Path, err)
+		}
+	}
+	return nil
+}
+
+func (r Rule) matches(req *http.Request) bool {
+	if r.Host != "" && r.Host != req.URL.Host && r.Host != req.URL.Hostname() {
+		return false
+	}
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+	if r.Path != "" {
+		if ok, _ := path.Match(r.Path, req.URL.Path); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// installed is a Rule added to a Transport.
+type installed struct {
+	Rule
+	start time.Time // when the rule becomes active
+	fired int
+}
+
+func (r *installed) active(now time.Time) bool {
+	if now.Before(r.start) {
+		return false
+	}
+	if r.For > 0 && !now.Before(r.start.Add(r.For)) {
+		return false
+	}
+	return r.Times == 0 || r.fired < r.Times
+}
+
+// Transport is an http.RoundTripper that injects faults into the requests
+// it sends. It is safe for concurrent use.
+type Transport struct {
+	next http.RoundTripper
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	rules []*installed
+	fired map[string]int
+}
+
+// New returns a Transport for cfg. It fails when a rule is invalid.
+func New(cfg Config) (*Transport, error) {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	t := &Transport{
+		next:  cfg.Transport,
+		rng:   rand.New(rand.NewPCG(seed, seed)), //nolint:gosec // fault injection does not need a secure source
+		fired: make(map[string]int),
+	}
+	if t.next == nil {
+		t.next = http.DefaultTransport
+	}
+	if err := t.SetRules(cfg.Rules...); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// AddRule adds a rule after the existing ones. Its After and For are
+// measured from now.
+func (t *Transport) AddRule(r Rule) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = append(t.rules, &installed{Rule: r, start: time.Now().Add(r.After)})
+	return nil
+}
+
+// SetRules replaces all rules with rules, measuring their After and For
+// from now. On error the existing rules are kept.
+func (t *Transport) SetRules(rules ...Rule) error {
+	now := time.Now()
+	next := make([]*installed, 0, len(rules))
+	for _, r := range rules {
+		if err := r.validate(); err != nil {
+			return err
+		}
+		next = append(next, &installed{Rule: r, start: now.Add(r.After)})
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = next
+	return nil
+}
+
+// RemoveRule removes the rules named name and reports whether there were
+// any.
+func (t *Transport) RemoveRule(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := len(t.rules)
+	kept := t.rules[:0]
+	for _, r := range t.rules {
+		if r.Name != name {
+			kept = append(kept, r)
+		}
+	}
+	clear(t.rules[len(kept):])
+	t.rules = kept
+	return len(kept) < n
+}
+
+// ClearRules removes all rules, so that requests pass through unchanged.
+func (t *Transport) ClearRules() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = nil
+}
+
+// Fired returns how many times rules named name have fired, including
+// rules since removed.
+func (t *Transport) Fired(name string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.fired[name]
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rule, delay := t.pick(req)
+	if rule == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			closeBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+
+	if rule.Fault == nil {
+		return t.next.RoundTrip(req)
+	}
+	return rule.Fault(req, t.next)
+}
+
+// pick returns the rule to apply to req, if any, and the latency it adds.
+func (t *Transport) pick(req *http.Request) (*installed, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for _, r := range t.rules {
+		if !r.active(now) || !r.matches(req) {
+			continue
+		}
+		if r.Rate > 0 && t.rng.Float64() >= r.Rate {
+			continue
+		}
+		r.fired++
+		t.fired[r.Name]++
+		var delay time.Duration
+		if r.Latency != nil {
+			delay = max(r.Latency(t.rng), 0)
+		}
+		return r, delay
+	}
+	return nil, 0
+}
+
+// closeBody closes the body of a request that is not sent on, as
+// http.RoundTripper requires.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package chaos_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport/chaos"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport/testutil/mockhttp"
+)
+
+const (
+	node1 = "127.0.0.1:9200"
+	node2 = "127.0.0.1:9201"
+)
+
+// newTransport returns a chaos Transport in front of a mock cluster, and an
+// opensearchtransport.Transport for two of its nodes sending through it.
+func newTransport(t *testing.T, rules ...chaos.Rule) (*chaos.Transport, *opensearchtransport.Transport) {
+	t.Helper()
+	routes := mockhttp.GetDefaultHandlers(t)
+	routes["/logs/_search"] = func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"hits":{"total":{"value":1},"hits":[{"_id":"1"}]}}`)
+	}
+	ct, err := chaos.New(chaos.Config{Transport: mockhttp.NewTransportFromRoutes(t, routes), Seed: 1, Rules: rules})
+	require.NoError(t, err)
+
+	tp, err := opensearchtransport.New(opensearchtransport.Config{
+		URLs:      []*url.URL{{Scheme: "http", Host: node1}, {Scheme: "http", Host: node2}},
+		Transport: ct,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Close() })
+	return ct, tp
+}
+
+func search(ctx context.Context, tp *opensearchtransport.Transport) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "/logs/_search", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	return tp.Request(req)
+}
+
+func TestFailover(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		fault chaos.Fault
+	}{
+		{"drop", chaos.Drop()},
+		{"status", chaos.Status(http.StatusServiceUnavailable)},
+		{"stream reset", chaos.StreamReset(chaos.ErrCodeRefusedStream)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ct, tp := newTransport(t)
+
+			// Find the node the search is routed to, then fail it.
+			res, err := search(t.Context(), tp)
+			require.NoError(t, err)
+			down := res.Request.URL.Host
+			require.NoError(t, ct.AddRule(chaos.Rule{Name: "down", Host: down, Path: "/logs/*", Fault: tt.fault}))
+
+			// Every request succeeds, retried on the other node.
+			for range 10 {
+				res, err := search(t.Context(), tp)
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.NotEqual(t, down, res.Request.URL.Host)
+			}
+			require.Positive(t, ct.Fired("down"))
+
+			m, err := tp.Metrics()
+			require.NoError(t, err)
+			require.Equal(t, 11, m.Requests)
+			require.Equal(t, ct.Fired("down"), m.Failures+m.Responses[http.StatusServiceUnavailable])
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	_, tp := newTransport(t, chaos.Rule{Path: "/logs/_search", Fault: chaos.Truncate(10)})
+
+	// Request buffers the body, returning what it read with the error.
+	res, err := search(t.Context(), tp)
+	require.ErrorIs(t, err, opensearchtransport.ErrResponseBodyRead)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.NotNil(t, res)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Len(t, body, 10)
+}
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+	ct, tp := newTransport(t)
+	ctx := t.Context()
+
+	require.NoError(t, ct.AddRule(chaos.Rule{Name: "once", Path: "/logs/_search", Times: 1, Fault: chaos.Status(http.StatusBadRequest)}))
+	require.NoError(t, ct.AddRule(chaos.Rule{Name: "later", After: time.Hour, Fault: chaos.Drop()}))
+	for _, want := range []int{http.StatusBadRequest, http.StatusOK, http.StatusOK} {
+		res, err := search(ctx, tp)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, want, res.StatusCode)
+	}
+	require.Equal(t, 1, ct.Fired("once"))
+	require.Zero(t, ct.Fired("later"))
+
+	require.True(t, ct.RemoveRule("later"))
+	require.False(t, ct.RemoveRule("later"))
+
+	// A slow node: the request context expires during the injected latency.
+	require.NoError(t, ct.SetRules(chaos.Rule{For: time.Hour, Latency: chaos.Fixed(time.Second)}))
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err := search(short, tp)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ct.ClearRules()
+	res, err := search(ctx, tp)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+}
+
+func TestRate(t *testing.T) {
+	t.Parallel()
+	ok := mockhttp.NewRoundTripFunc(t, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	ct, err := chaos.New(chaos.Config{
+		Transport: ok,
+		Seed:      42,
+		Rules:     []chaos.Rule{{Name: "flaky", Rate: 0.25, Fault: chaos.Status(http.StatusTooManyRequests)}},
+	})
+	require.NoError(t, err)
+
+	var rejected int
+	for range 1000 {
+		req, _ := http.NewRequest(http.MethodGet, "http://"+node1+"/", nil)
+		res, err := ct.RoundTrip(req)
+		require.NoError(t, err)
+		if res.StatusCode == http.StatusTooManyRequests {
+			rejected++
+		}
+	}
+	require.Equal(t, ct.Fired("flaky"), rejected)
+	require.InDelta(t, 250, rejected, 60)
+
+	_, err = chaos.New(chaos.Config{Rules: []chaos.Rule{{Rate: 2}}})
+	require.Error(t, err)
+	_, err = chaos.New(chaos.Config{Rules: []chaos.Rule{{Path: "["}}})
+	require.Error(t, err)
+}
+
+// streamError has the layout of the HTTP/2 stream error net/http returns,
+// as callers declare it to detect resets without importing x/net.
+type streamError struct {
+	StreamID uint32
+	Code     uint32
+	Cause    error
+}
+
+func (streamError) Error() string { return "stream error" }
+
+// otherError has a different layout.
+type otherError struct{ StreamID, Code uint32 }
+
+func (otherError) Error() string { return "other error" }
+
+func TestStreamError(t *testing.T) {
+	t.Parallel()
+	err := error(chaos.StreamError{StreamID: 3, Code: chaos.ErrCodeRefusedStream})
+	require.EqualError(t, err, "stream error: stream ID 3; REFUSED_STREAM")
+
+	var target streamError
+	require.ErrorAs(t, err, &target)
+	require.Equal(t, streamError{StreamID: 3, Code: chaos.ErrCodeRefusedStream}, target)
+
+	var other otherError
+	require.False(t, errors.As(err, &other))
+}
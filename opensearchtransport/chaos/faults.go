@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package chaos
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Fault produces the response of a request selected by a Rule. next sends
+// the request on to the cluster; a fault that does not call it must close
+// the request body.
+type Fault func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// Drop fails the request with a connection reset, as if the node closed the
+// connection before answering. The request does not reach the node.
+func Drop() Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	}
+}
+
+// Status answers the request with status and an OpenSearch error body
+// instead of sending it: a rejected_execution_exception for 429, as an
+// overloaded node returns, and a generic exception otherwise.
+func Status(status int) Fault {
+	typ, reason := "exception", http.StatusText(status)+" [injected by chaos]"
+	if status == http.StatusTooManyRequests {
+		typ, reason = "rejected_execution_exception", "rejected execution of coordinating operation [injected by chaos]"
+	}
+	cause := map[string]any{"type": typ, "reason": reason}
+	body, _ := json.Marshal(map[string]any{
+		"error":  map[string]any{"root_cause": []any{cause}, "type": typ, "reason": reason},
+		"status": status,
+	})
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		return &http.Response{
+			Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header: http.Header{
+				"Content-Type":   {"application/json; charset=UTF-8"},
+				"Content-Length": {strconv.Itoa(len(body))},
+			},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+}
+
+// StreamReset fails the request with an HTTP/2 RST_STREAM carrying code,
+// such as ErrCodeRefusedStream. The request does not reach the node.
+func StreamReset(code uint32) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		return nil, StreamError{StreamID: nextStreamID(), Code: code}
+	}
+}
+
+// Truncate sends the request on and cuts the response body short after n
+// bytes: reading past them fails with io.ErrUnexpectedEOF, as when a node
+// closes the connection mid-response.
+func Truncate(n int64) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		res, err := next.RoundTrip(req)
+		if err != nil || res.Body == nil {
+			return res, err
+		}
+		res.Body = &truncatedBody{rc: res.Body, remaining: n}
+		return res, nil
+	}
+}
+
+// truncatedBody is a response body that fails after remaining bytes.
+type truncatedBody struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Only a body with bytes past the cut is truncated.
+		var probe [1]byte
+		if n, err := b.rc.Read(probe[:]); n == 0 && errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	// A body ending before the cut returns io.EOF: it is not truncated.
+	n, err := b.rc.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *truncatedBody) Close() error { return b.rc.Close() }
+
+// Distribution returns a latency drawn from r.
+type Distribution func(r *rand.Rand) time.Duration
+
+// Fixed is a latency of exactly d.
+func Fixed(d time.Duration) Distribution {
+	return func(*rand.Rand) time.Duration { return d }
+}
+
+// Uniform is a latency drawn uniformly from [lo, hi).
+func Uniform(lo, hi time.Duration) Distribution {
+	return func(r *rand.Rand) time.Duration {
+		if hi <= lo {
+			return lo
+		}
+		return lo + time.Duration(r.Int64N(int64(hi-lo)))
+	}
+}
+
+// Normal is a latency drawn from a normal distribution, clamped at zero.
+func Normal(mean, stddev time.Duration) Distribution {
+	return func(r *rand.Rand) time.Duration {
+		return max(time.Duration(r.NormFloat64()*float64(stddev))+mean, 0)
+	}
+}
+
+// Exponential is a latency drawn from an exponential distribution with the
+// given mean: mostly short, with a long tail.
+func Exponential(mean time.Duration) Distribution {
+	return func(r *rand.Rand) time.Duration {
+		return time.Duration(math.Round(r.ExpFloat64() * float64(mean)))
+	}
+}
+
+// HTTP/2 error codes for StreamReset, from RFC 9113 section 7.
+const (
+	ErrCodeProtocol        uint32 = 0x1
+	ErrCodeInternal        uint32 = 0x2
+	ErrCodeRefusedStream   uint32 = 0x7
+	ErrCodeCancel          uint32 = 0x8
+	ErrCodeEnhanceYourCalm uint32 = 0xb
+)
+
+var errCodeNames = map[uint32]string{
+	ErrCodeProtocol:        "PROTOCOL_ERROR",
+	ErrCodeInternal:        "INTERNAL_ERROR",
+	ErrCodeRefusedStream:   "REFUSED_STREAM",
+	ErrCodeCancel:          "CANCEL",
+	ErrCodeEnhanceYourCalm: "ENHANCE_YOUR_CALM",
+}
+
+// streamIDs numbers the streams of StreamErrors. Client streams are odd.
+var streamIDs atomic.Uint32
+
+func nextStreamID() uint32 { return streamIDs.Add(1)*2 - 1 }
+
+// StreamError is an HTTP/2 stream reset, as returned by StreamReset.
+//
+// It has the field layout of golang.org/x/net/http2.StreamError and, like
+// the copy of that type vendored into net/http, an As method matching any
+// struct with the same field names and convertible types. Code that
+// detects stream resets from net/http with errors.As therefore detects
+// these too.
+type StreamError struct {
+	StreamID uint32
+	Code     uint32
+	Cause    error
+}
+
+func (e StreamError) Error() string {
+	code, ok := errCodeNames[e.Code]
+	if !ok {
+		code = fmt.Sprintf("unknown error code 0x%x", e.Code)
+	}
+	if e.Cause != nil {
+		return fmt.Sprintf("stream error: stream ID %d; %s; %v", e.StreamID, code, e.Cause)
+	}
+	return fmt.Sprintf("stream error: stream ID %d; %s", e.StreamID, code)
+}
+
+// Unwrap returns the cause of the reset, if any.
+func (e StreamError) Unwrap() error { return e.Cause }
+
+// As sets target, a pointer to a struct with the fields of StreamError, to
+// e. It mirrors the As method net/http defines on its HTTP/2 stream error.
+func (e StreamError) As(target any) bool {
+	dst := reflect.ValueOf(target)
+	if dst.Kind() != reflect.Pointer || dst.IsNil() {
+		return false
+	}
+	dst = dst.Elem()
+	src := reflect.ValueOf(e)
+	if dst.Kind() != reflect.Struct || dst.Type() == src.Type() || dst.NumField() != src.NumField() {
+		return false
+	}
+	for i := range src.NumField() {
+		sf, df := src.Type().Field(i), dst.Type().Field(i)
+		if sf.Name != df.Name || !df.IsExported() || !sf.Type.ConvertibleTo(df.Type) {
+			return false
+		}
+	}
+	for i := range src.NumField() {
+		df := dst.Field(i)
+		df.Set(src.Field(i).Convert(df.Type()))
+	}
+	return true
+}
@@ -203,11 +203,34 @@ the traditional connection pool with Selector-based node selection:
 # Logging and Metrics
 
 The package defines the Logger interface for logging information about request and response.
-It comes with several bundled loggers for logging in text and JSON.
+It comes with several bundled loggers for logging in text and JSON, and SlogLogger, which
+writes each round trip as a structured log/slog record with its attempt, node, and route.
 
 Use the EnableDebugLogger option to enable the debugging logger for connection management.
 Alternatively, set the OPENSEARCH_GO_DEBUG environment variable to "true" to enable debug
 logging globally without code changes. When enabled, debug output is written to stderr.
+Set the DebugLogger option to a SlogDebugLogger to write it as slog debug records instead,
+and wire a SlogObserver as the Observer to log discovery and health check events:
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	transport, err := opensearchtransport.New(opensearchtransport.Config{
+		URLs:        []*url.URL{{Scheme: "http", Host: "localhost:9200"}},
+		Logger:      &opensearchtransport.SlogLogger{Logger: logger},
+		DebugLogger: opensearchtransport.SlogDebugLogger{Logger: logger},
+		Observer:    &opensearchtransport.SlogObserver{Logger: logger},
+	})
+
+Every logger, the debug output, and opensearch.Response.String mask secrets with a Redactor:
+the Authorization and cookie headers, password fields, URL credentials, and the bodies of the
+security plugin's user APIs by default. Set a logger's Redactor, or Config.Redactor for the
+debug output, to mask more headers, JSON fields by path, regular expression matches, or whole
+operations:
+
+	redactor := &opensearchtransport.Redactor{
+		Headers: []string{"X-Api-Key"},
+		Fields:  []string{"**.email", "customer.ssn"},
+	}
+	logger := &opensearchtransport.JSONLogger{Output: os.Stderr, EnableRequestBody: true, Redactor: redactor}
 
 The Metrics method returns transport metrics. It always returns the full
 snapshot: the per-request counters (requests, failures, responses by status,
@@ -72,7 +72,7 @@ func storeDebugLogger(dl DebuggingLogger) {
 
 func init() { //nolint:gochecknoinits // Only set implicitly once at startup
 	if enabled, _ := strconv.ParseBool(os.Getenv(envvars.Debug)); enabled {
-		storeDebugLogger(&debuggingLogger{Output: os.Stderr})
+		storeDebugLogger(redactingDebugLogger{next: &debuggingLogger{Output: os.Stderr}})
 	}
 }
 
@@ -87,6 +87,39 @@ type Logger interface {
 	ResponseBodyEnabled() bool
 }
 
+// AttemptLogger is a Logger that also records which attempt, node, and
+// operation a round trip belongs to. When the configured Logger implements it,
+// the transport calls LogAttempt in place of LogRoundTrip.
+type AttemptLogger interface {
+	Logger
+	// LogAttempt logs a round trip, with the same contract as LogRoundTrip.
+	LogAttempt(RoundTrip) error
+}
+
+// RoundTrip describes a round trip passed to an [AttemptLogger].
+type RoundTrip struct {
+	Request  *http.Request
+	Response *http.Response
+	Err      error
+	Start    time.Time
+	Duration time.Duration
+
+	// Attempt is the zero-based attempt index of the request. The seed
+	// fallback round trip reports the index of the last regular attempt.
+	Attempt int
+
+	// Node is the "scheme://host[:port]" of the node contacted, empty when no
+	// connection could be selected.
+	Node string
+
+	// PoolName is the pool that dispatched the attempt, empty without a Router.
+	PoolName string
+
+	// RouteName is the operation of the request, as in
+	// [RequestEvent.RouteName].
+	RouteName string
+}
+
 // DebuggingLogger defines the interface for a debugging logger.
 type DebuggingLogger interface {
 	Log(a ...any) error
@@ -98,6 +131,7 @@ type TextLogger struct {
 	Output             io.Writer
 	EnableRequestBody  bool
 	EnableResponseBody bool
+	Redactor           *Redactor // Masks secrets in the output; nil applies the defaults.
 }
 
 // ColorLogger prints the log message in a terminal-optimized plain text.
@@ -105,6 +139,7 @@ type ColorLogger struct {
 	Output             io.Writer
 	EnableRequestBody  bool
 	EnableResponseBody bool
+	Redactor           *Redactor // Masks secrets in the output; nil applies the defaults.
 }
 
 // CurlLogger prints the log message as a runnable curl command.
@@ -112,6 +147,7 @@ type CurlLogger struct {
 	Output             io.Writer
 	EnableRequestBody  bool
 	EnableResponseBody bool
+	Redactor           *Redactor // Masks secrets in the output; nil applies the defaults.
 }
 
 // JSONLogger prints the log message as JSON.
@@ -119,6 +155,7 @@ type JSONLogger struct {
 	Output             io.Writer
 	EnableRequestBody  bool
 	EnableResponseBody bool
+	Redactor           *Redactor // Masks secrets in the output; nil applies the defaults.
 }
 
 // debuggingLogger prints debug messages as plain text.
@@ -128,28 +165,25 @@ type debuggingLogger struct {
 
 // LogRoundTrip prints the information about request and response.
 func (l *TextLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
+	method, target := "-", "-"
+	if req != nil {
+		method, target = req.Method, l.Redactor.RedactURL(req.URL)
+	}
 	fmt.Fprintf(l.Output, "%s %s %s [status:%d request:%s]\n", // #nosec G705
 		start.Format(time.RFC3339),
-		req.Method,
-		req.URL.String(),
+		method,
+		target,
 		resStatusCode(res),
 		dur.Truncate(time.Millisecond),
 	)
 	if l.RequestBodyEnabled() && req != nil && req.Body != nil && req.Body != http.NoBody {
-		var buf bytes.Buffer
-		if req.GetBody != nil {
-			b, _ := req.GetBody()
-			buf.ReadFrom(b)
-		} else {
-			buf.ReadFrom(req.Body)
-		}
-		logBodyAsText(l.Output, &buf, ">")
+		buf := bytes.NewBuffer(readRequestBody(req, l.Redactor))
+		logBodyAsText(l.Output, buf, ">")
 	}
 	if l.ResponseBodyEnabled() && res != nil && res.Body != nil && res.Body != http.NoBody {
 		defer res.Body.Close()
-		var buf bytes.Buffer
-		buf.ReadFrom(res.Body)
-		logBodyAsText(l.Output, &buf, "<")
+		buf := bytes.NewBuffer(readResponseBody(req, res, l.Redactor))
+		logBodyAsText(l.Output, buf, "<")
 	}
 	if err != nil {
 		fmt.Fprintf(l.Output, "! ERROR: %v\n", err)
@@ -165,7 +199,15 @@ func (l *TextLogger) ResponseBodyEnabled() bool { return l.EnableResponseBody }
 
 // LogRoundTrip prints the information about request and response.
 func (l *ColorLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, _ time.Time, dur time.Duration) error {
-	query, _ := url.QueryUnescape(req.URL.RawQuery)
+	var method string
+	u := &url.URL{}
+	if req != nil {
+		method = req.Method
+		if req.URL != nil {
+			u = req.URL
+		}
+	}
+	query, _ := url.QueryUnescape(u.RawQuery)
 	if query != "" {
 		query = "?" + query
 	}
@@ -175,13 +217,18 @@ func (l *ColorLogger) LogRoundTrip(req *http.Request, res *http.Response, err er
 		color  string
 	)
 
-	status = res.Status
 	switch {
+	case res == nil:
+		status = "ERROR"
+		color = "\x1b[31;4m"
 	case res.StatusCode >= http.StatusContinue && res.StatusCode < http.StatusMultipleChoices:
+		status = res.Status
 		color = "\x1b[32m"
 	case res.StatusCode >= http.StatusMultipleChoices && res.StatusCode < http.StatusInternalServerError:
+		status = res.Status
 		color = "\x1b[33m"
 	case res.StatusCode >= http.StatusInternalServerError:
+		status = res.Status
 		color = "\x1b[31m"
 	default:
 		status = "ERROR"
@@ -190,10 +237,10 @@ func (l *ColorLogger) LogRoundTrip(req *http.Request, res *http.Response, err er
 
 	fmt.Fprintf(l.Output, // #nosec G705
 		"%6s \x1b[1;4m%s://%s%s\x1b[0m%s %s%s\x1b[0m \x1b[2m%s\x1b[0m\n",
-		req.Method,
-		req.URL.Scheme,
-		req.URL.Host,
-		req.URL.Path,
+		method,
+		u.Scheme,
+		u.Host,
+		u.Path,
 		query,
 		color,
 		status,
@@ -201,24 +248,17 @@ func (l *ColorLogger) LogRoundTrip(req *http.Request, res *http.Response, err er
 	)
 
 	if l.RequestBodyEnabled() && req != nil && req.Body != nil && req.Body != http.NoBody {
-		var buf bytes.Buffer
-		if req.GetBody != nil {
-			b, _ := req.GetBody()
-			buf.ReadFrom(b)
-		} else {
-			buf.ReadFrom(req.Body)
-		}
+		buf := bytes.NewBuffer(readRequestBody(req, l.Redactor))
 		fmt.Fprint(l.Output, "\x1b[2m")
-		logBodyAsText(l.Output, &buf, "       >>")
+		logBodyAsText(l.Output, buf, "       >>")
 		fmt.Fprint(l.Output, "\x1b[0m")
 	}
 
 	if l.ResponseBodyEnabled() && res != nil && res.Body != nil && res.Body != http.NoBody {
 		defer res.Body.Close()
-		var buf bytes.Buffer
-		buf.ReadFrom(res.Body)
+		buf := bytes.NewBuffer(readResponseBody(req, res, l.Redactor))
 		fmt.Fprint(l.Output, "\x1b[2m")
-		logBodyAsText(l.Output, &buf, "       <<")
+		logBodyAsText(l.Output, buf, "       <<")
 		fmt.Fprint(l.Output, "\x1b[0m")
 	}
 
@@ -239,9 +279,17 @@ func (l *ColorLogger) RequestBodyEnabled() bool { return l.EnableRequestBody }
 func (l *ColorLogger) ResponseBodyEnabled() bool { return l.EnableResponseBody }
 
 // LogRoundTrip prints the information about request and response.
-func (l *CurlLogger) LogRoundTrip(req *http.Request, res *http.Response, _ error, start time.Time, dur time.Duration) error {
+func (l *CurlLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
 	var b bytes.Buffer
 
+	// Errors logged without a request, such as failed discovery, have no
+	// command to print.
+	if req == nil {
+		fmt.Fprintf(&b, "# => %s [ERROR] %v\n\n", start.UTC().Format(time.RFC3339), err) // #nosec G705
+		_, err = b.WriteTo(l.Output)
+		return err
+	}
+
 	var query string
 	qvalues := url.Values{}
 	for k, v := range req.URL.Query() {
@@ -268,7 +316,7 @@ func (l *CurlLogger) LogRoundTrip(req *http.Request, res *http.Response, _ error
 			if k == "Authorization" || k == "User-Agent" {
 				continue
 			}
-			v := strings.Join(vv, ",")
+			v := l.Redactor.RedactHeader(k, strings.Join(vv, ","))
 			fmt.Fprintf(&b, " -H '%s: %s'", k, v)
 		}
 	}
@@ -286,32 +334,32 @@ func (l *CurlLogger) LogRoundTrip(req *http.Request, res *http.Response, _ error
 	b.WriteString("'")
 
 	if req != nil && req.Body != nil && req.Body != http.NoBody {
-		var buf bytes.Buffer
-		if req.GetBody != nil {
-			b, _ := req.GetBody()
-			buf.ReadFrom(b)
-		} else {
-			buf.ReadFrom(req.Body)
-		}
+		body := readRequestBody(req, l.Redactor)
 
-		b.Grow(buf.Len())
+		b.Grow(len(body))
 		b.WriteString(" -d \\\n'")
-		json.Indent(&b, buf.Bytes(), "", " ")
+		if err := json.Indent(&b, body, "", " "); err != nil {
+			b.Write(body)
+		}
 		b.WriteString("'")
 	}
 
 	b.WriteRune('\n')
 
-	status := res.Status
+	status := "ERROR"
+	if res != nil {
+		status = res.Status
+	}
 
 	fmt.Fprintf(&b, "# => %s [%s] %s\n", start.UTC().Format(time.RFC3339), status, dur.Truncate(time.Millisecond)) // #nosec G705
 	if l.ResponseBodyEnabled() && res != nil && res.Body != nil && res.Body != http.NoBody {
-		var buf bytes.Buffer
-		buf.ReadFrom(res.Body)
+		body := readResponseBody(req, res, l.Redactor)
 
-		b.Grow(buf.Len())
+		b.Grow(len(body))
 		b.WriteString("# ")
-		json.Indent(&b, buf.Bytes(), "# ", " ")
+		if err := json.Indent(&b, body, "# ", " "); err != nil {
+			b.Write(body)
+		}
 	}
 
 	b.WriteString("\n")
@@ -319,7 +367,7 @@ func (l *CurlLogger) LogRoundTrip(req *http.Request, res *http.Response, _ error
 		b.WriteString("\n")
 	}
 
-	_, err := b.WriteTo(l.Output)
+	_, err = b.WriteTo(l.Output)
 	return err
 }
 
@@ -366,38 +414,36 @@ func (l *JSONLogger) LogRoundTrip(req *http.Request, res *http.Response, err err
 	appendInt(dur.Nanoseconds())
 	b.WriteRune('}')
 	// -- URL
-	b.WriteString(`,"url":{`)
-	b.WriteString(`"scheme":`)
-	appendQuote(req.URL.Scheme)
-	b.WriteString(`,"domain":`)
-	appendQuote(req.URL.Hostname())
-	if port := req.URL.Port(); port != "" {
-		b.WriteString(`,"port":`)
-		b.WriteString(port)
-	}
-	b.WriteString(`,"path":`)
-	appendQuote(req.URL.Path)
-	b.WriteString(`,"query":`)
-	appendQuote(req.URL.RawQuery)
-	b.WriteRune('}') // Close "url"
+	if req != nil && req.URL != nil {
+		b.WriteString(`,"url":{`)
+		b.WriteString(`"scheme":`)
+		appendQuote(req.URL.Scheme)
+		b.WriteString(`,"domain":`)
+		appendQuote(req.URL.Hostname())
+		if port := req.URL.Port(); port != "" {
+			b.WriteString(`,"port":`)
+			b.WriteString(port)
+		}
+		b.WriteString(`,"path":`)
+		appendQuote(req.URL.Path)
+		b.WriteString(`,"query":`)
+		appendQuote(req.URL.RawQuery)
+		b.WriteRune('}') // Close "url"
+	}
 	// -- HTTP
 	b.WriteString(`,"http":`)
 	// ---- Request
 	b.WriteString(`{"request":{`)
-	b.WriteString(`"method":`)
-	appendQuote(req.Method)
+	if req != nil {
+		b.WriteString(`"method":`)
+		appendQuote(req.Method)
+	}
 	if l.RequestBodyEnabled() && req != nil && req.Body != nil && req.Body != http.NoBody {
-		var buf bytes.Buffer
-		if req.GetBody != nil {
-			b, _ := req.GetBody()
-			buf.ReadFrom(b)
-		} else {
-			buf.ReadFrom(req.Body)
-		}
+		body := readRequestBody(req, l.Redactor)
 
-		b.Grow(buf.Len() + 8)
+		b.Grow(len(body) + 8)
 		b.WriteString(`,"body":`)
-		appendQuote(buf.String())
+		appendQuote(string(body))
 	}
 	b.WriteRune('}') // Close "http.request"
 	// ---- Response
@@ -406,12 +452,11 @@ func (l *JSONLogger) LogRoundTrip(req *http.Request, res *http.Response, err err
 	appendInt(int64(resStatusCode(res)))
 	if l.ResponseBodyEnabled() && res != nil && res.Body != nil && res.Body != http.NoBody {
 		defer res.Body.Close()
-		var buf bytes.Buffer
-		buf.ReadFrom(res.Body)
+		body := readResponseBody(req, res, l.Redactor)
 
-		b.Grow(buf.Len() + 8)
+		b.Grow(len(body) + 8)
 		b.WriteString(`,"body":`)
-		appendQuote(buf.String())
+		appendQuote(string(body))
 	}
 	b.WriteRune('}') // Close "http.response"
 	b.WriteRune('}') // Close "http"
@@ -476,6 +521,25 @@ func duplicateBody(body io.ReadCloser) (io.ReadCloser, io.ReadCloser, error) {
 	return io.NopCloser(&b1), io.NopCloser(&b2), nil
 }
 
+// readRequestBody returns a copy of the body of req, redacted by r.
+func readRequestBody(req *http.Request, r *Redactor) []byte {
+	var buf bytes.Buffer
+	if req.GetBody != nil {
+		b, _ := req.GetBody()
+		buf.ReadFrom(b)
+	} else {
+		buf.ReadFrom(req.Body)
+	}
+	return r.RedactBodyFor(req, buf.Bytes())
+}
+
+// readResponseBody reads the body of res, the response to req, redacted by r.
+func readResponseBody(req *http.Request, res *http.Response, r *Redactor) []byte {
+	var buf bytes.Buffer
+	buf.ReadFrom(res.Body)
+	return r.RedactBodyFor(req, buf.Bytes())
+}
+
 func resStatusCode(res *http.Response) int {
 	if res == nil {
 		return -1
@@ -416,6 +416,35 @@ func TestTransportLogger(t *testing.T) {
 		}
 	})
 
+	t.Run("Without request", func(t *testing.T) {
+		loggers := map[string]func(io.Writer) Logger{
+			"Text": func(w io.Writer) Logger {
+				return &TextLogger{Output: w, EnableRequestBody: true, EnableResponseBody: true}
+			},
+			"Color": func(w io.Writer) Logger {
+				return &ColorLogger{Output: w, EnableRequestBody: true, EnableResponseBody: true}
+			},
+			"Curl": func(w io.Writer) Logger {
+				return &CurlLogger{Output: w, EnableRequestBody: true, EnableResponseBody: true}
+			},
+			"JSON": func(w io.Writer) Logger {
+				return &JSONLogger{Output: w, EnableRequestBody: true, EnableResponseBody: true}
+			},
+		}
+		for name, newLogger := range loggers {
+			t.Run(name, func(t *testing.T) {
+				var dst strings.Builder
+				err := newLogger(&dst).LogRoundTrip(nil, nil, errors.New("discovery failed"), time.Now(), 0)
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if !strings.Contains(dst.String(), "discovery failed") {
+					t.Errorf("Unexpected output: %s", dst.String())
+				}
+			})
+		}
+	})
+
 	t.Run("Duplicate body", func(t *testing.T) {
 		input := ResponseBody{content: strings.NewReader("FOOBAR")}
 
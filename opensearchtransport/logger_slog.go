@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtransport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// slogMessage is the message of the records SlogLogger writes.
+	slogMessage = "opensearch request"
+
+	// defaultSlogBodyBytes caps body excerpts when SlogLogger.MaxBodyBytes is 0.
+	defaultSlogBodyBytes = 1024
+)
+
+// SlogLogger logs each round trip as a structured [log/slog] record with the
+// method, URL, status, duration, attempt, node, pool, and route of the request,
+// and excerpts of the bodies when enabled. No headers are logged; the URL and
+// bodies are masked by Redactor.
+//
+// Round trips are logged at Level, responses with a status of 400 or more at
+// [slog.LevelWarn] or Level if higher, and transport errors at
+// [slog.LevelError]. Each record is checked against the handler's Enabled
+// first, so a disabled level costs no formatting. Bodies are copied, and so
+// logged, only when Level is enabled, even on records raised above it.
+type SlogLogger struct {
+	Logger             *slog.Logger // Defaults to slog.Default().
+	Level              slog.Level
+	EnableRequestBody  bool
+	EnableResponseBody bool
+	Redactor           *Redactor // Masks secrets in the output; nil applies the defaults.
+
+	// MaxBodyBytes caps each body excerpt; longer bodies are cut and end in
+	// "...". 0 = default (1024), <0 = no limit.
+	MaxBodyBytes int
+}
+
+// Compile-time check that SlogLogger records attempt details.
+var _ AttemptLogger = (*SlogLogger)(nil)
+
+// LogRoundTrip logs a round trip without attempt details, as from a caller
+// using the Logger interface directly.
+func (l *SlogLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
+	return l.LogAttempt(RoundTrip{Request: req, Response: res, Err: err, Start: start, Duration: dur})
+}
+
+// LogAttempt logs a round trip as a structured record.
+func (l *SlogLogger) LogAttempt(rt RoundTrip) error {
+	logger := l.logger()
+	ctx := context.Background()
+	if rt.Request != nil {
+		ctx = rt.Request.Context()
+	}
+	status := 0
+	if rt.Response != nil {
+		status = rt.Response.StatusCode
+	}
+	level := l.level(status, rt.Err)
+	if !logger.Enabled(ctx, level) {
+		return nil
+	}
+
+	attrs := make([]slog.Attr, 0, 12) //nolint:mnd // every attribute below
+	if req := rt.Request; req != nil {
+		attrs = append(attrs, slog.String("method", req.Method))
+		if req.URL != nil {
+			attrs = append(attrs, slog.String("url", l.Redactor.RedactURL(req.URL)))
+		}
+	}
+	if status > 0 {
+		attrs = append(attrs, slog.Int("status", status))
+	}
+	attrs = append(attrs, slog.Duration("duration", rt.Duration), slog.Int("attempt", rt.Attempt))
+	if rt.Node != "" {
+		attrs = append(attrs, slog.String("node", rt.Node))
+	}
+	if rt.PoolName != "" {
+		attrs = append(attrs, slog.String("pool", rt.PoolName))
+	}
+	if rt.RouteName != "" {
+		attrs = append(attrs, slog.String("route", rt.RouteName))
+	}
+
+	// The transport copies the bodies only when these report true; otherwise
+	// the bodies are the caller's and are left unread.
+	if req := rt.Request; l.RequestBodyEnabled() && req != nil && req.Body != nil && req.Body != http.NoBody {
+		attrs = append(attrs, slog.String("request_body", l.excerpt(readRequestBody(req, l.Redactor))))
+	}
+	if res := rt.Response; l.ResponseBodyEnabled() && res != nil && res.Body != nil && res.Body != http.NoBody {
+		attrs = append(attrs, slog.String("response_body", l.excerpt(readResponseBody(rt.Request, res, l.Redactor))))
+	}
+	if rt.Err != nil {
+		attrs = append(attrs, slog.String("error", rt.Err.Error()))
+	}
+
+	logger.LogAttrs(ctx, level, slogMessage, attrs...)
+	return nil
+}
+
+// RequestBodyEnabled returns true when the request body should be logged and
+// Level is enabled.
+func (l *SlogLogger) RequestBodyEnabled() bool {
+	return l.EnableRequestBody && l.logger().Enabled(context.Background(), l.Level)
+}
+
+// ResponseBodyEnabled returns true when the response body should be logged
+// and Level is enabled.
+func (l *SlogLogger) ResponseBodyEnabled() bool {
+	return l.EnableResponseBody && l.logger().Enabled(context.Background(), l.Level)
+}
+
+func (l *SlogLogger) logger() *slog.Logger {
+	if l.Logger != nil {
+		return l.Logger
+	}
+	return slog.Default()
+}
+
+// level returns the level of a round trip with the given outcome.
+func (l *SlogLogger) level(status int, err error) slog.Level {
+	switch {
+	case err != nil:
+		return max(l.Level, slog.LevelError)
+	case status >= http.StatusBadRequest:
+		return max(l.Level, slog.LevelWarn)
+	default:
+		return l.Level
+	}
+}
+
+// excerpt cuts body to MaxBodyBytes.
+func (l *SlogLogger) excerpt(body []byte) string {
+	limit := l.MaxBodyBytes
+	if limit == 0 {
+		limit = defaultSlogBodyBytes
+	}
+	if limit > 0 && len(body) > limit {
+		return string(body[:limit]) + "..."
+	}
+	return string(body)
+}
+
+// SlogDebugLogger is a [DebuggingLogger] writing the transport's debug output,
+// such as discovery and health check progress, as [slog.LevelDebug] records.
+// Set it as Config.DebugLogger.
+type SlogDebugLogger struct {
+	Logger *slog.Logger // Defaults to slog.Default().
+}
+
+// Compile-time check that SlogDebugLogger implements DebuggingLogger.
+var _ DebuggingLogger = SlogDebugLogger{}
+
+// Log writes the arguments, formatted as by fmt.Sprint, as a debug record.
+func (l SlogDebugLogger) Log(a ...any) error {
+	logger := l.logger()
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return nil
+	}
+	logger.LogAttrs(context.Background(), slog.LevelDebug, strings.TrimSuffix(fmt.Sprint(a...), "\n"))
+	return nil
+}
+
+// Logf writes the arguments, formatted as by fmt.Sprintf, as a debug record.
+func (l SlogDebugLogger) Logf(format string, a ...any) error {
+	logger := l.logger()
+	if !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return nil
+	}
+	logger.LogAttrs(context.Background(), slog.LevelDebug, strings.TrimSuffix(fmt.Sprintf(format, a...), "\n"))
+	return nil
+}
+
+func (l SlogDebugLogger) logger() *slog.Logger {
+	if l.Logger != nil {
+		return l.Logger
+	}
+	return slog.Default()
+}
+
+// SlogObserver is a [ConnectionObserver] logging connection lifecycle events,
+// such as nodes discovered and removed, health check failures, and overload,
+// as slog records. State changes are logged at [slog.LevelInfo], failures and
+// overload at [slog.LevelWarn], and routine events (unchanged discovery,
+// passing health checks, warmup requests) at [slog.LevelDebug]. Request
+// hooks are not logged; pair it with [SlogLogger] for round trips.
+type SlogObserver struct {
+	BaseConnectionObserver
+
+	Logger *slog.Logger // Defaults to slog.Default().
+}
+
+// Compile-time check that SlogObserver implements ConnectionObserver.
+var _ ConnectionObserver = (*SlogObserver)(nil)
+
+// OnPromote logs a connection moved from dead to ready.
+func (o *SlogObserver) OnPromote(event ConnectionEvent) {
+	o.log(slog.LevelInfo, "opensearch connection promoted", event)
+}
+
+// OnDemote logs a connection moved from ready to dead.
+func (o *SlogObserver) OnDemote(event ConnectionEvent) {
+	o.log(slog.LevelWarn, "opensearch connection demoted", event)
+}
+
+// OnOverloadDetected logs a node marked overloaded.
+func (o *SlogObserver) OnOverloadDetected(event ConnectionEvent) {
+	o.log(slog.LevelWarn, "opensearch node overloaded", event)
+}
+
+// OnOverloadCleared logs a node no longer overloaded.
+func (o *SlogObserver) OnOverloadCleared(event ConnectionEvent) {
+	o.log(slog.LevelInfo, "opensearch node overload cleared", event)
+}
+
+// OnDiscoveryAdd logs a node found by discovery.
+func (o *SlogObserver) OnDiscoveryAdd(event ConnectionEvent) {
+	o.log(slog.LevelInfo, "opensearch node discovered", event)
+}
+
+// OnDiscoveryRemove logs a node no longer reported by discovery.
+func (o *SlogObserver) OnDiscoveryRemove(event ConnectionEvent) {
+	o.log(slog.LevelInfo, "opensearch node removed", event)
+}
+
+// OnDiscoveryUnchanged logs a node discovery found unchanged.
+func (o *SlogObserver) OnDiscoveryUnchanged(event ConnectionEvent) {
+	o.log(slog.LevelDebug, "opensearch node unchanged", event)
+}
+
+// OnHealthCheckPass logs a passing health check.
+func (o *SlogObserver) OnHealthCheckPass(event ConnectionEvent) {
+	o.log(slog.LevelDebug, "opensearch health check passed", event)
+}
+
+// OnHealthCheckFail logs a failing health check.
+func (o *SlogObserver) OnHealthCheckFail(event ConnectionEvent) {
+	o.log(slog.LevelWarn, "opensearch health check failed", event)
+}
+
+// OnStandbyPromote logs a standby connection moved to the active list.
+func (o *SlogObserver) OnStandbyPromote(event ConnectionEvent) {
+	o.log(slog.LevelInfo, "opensearch standby connection promoted", event)
+}
+
+// OnStandbyDemote logs an active connection moved to standby.
+func (o *SlogObserver) OnStandbyDemote(event ConnectionEvent) {
+	o.log(slog.LevelInfo, "opensearch connection moved to standby", event)
+}
+
+// OnWarmupRequest logs a warmup request.
+func (o *SlogObserver) OnWarmupRequest(event ConnectionEvent) {
+	o.log(slog.LevelDebug, "opensearch warmup request", event)
+}
+
+// log writes event as a record at level, if enabled.
+func (o *SlogObserver) log(level slog.Level, msg string, event ConnectionEvent) {
+	logger := o.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ctx := context.Background()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := make([]slog.Attr, 0, 8) //nolint:mnd // every attribute below
+	attrs = append(attrs, slog.String("node", event.URL))
+	if event.ID != "" {
+		attrs = append(attrs, slog.String("node_id", event.ID))
+	}
+	if event.Name != "" {
+		attrs = append(attrs, slog.String("node_name", event.Name))
+	}
+	if event.PoolName != "" {
+		attrs = append(attrs, slog.String("pool", event.PoolName))
+	}
+	if len(event.Roles) > 0 {
+		attrs = append(attrs, slog.Any("roles", event.Roles))
+	}
+	attrs = append(attrs, slog.Int("active", event.ActiveCount), slog.Int("dead", event.DeadCount))
+	if event.Error != nil {
+		attrs = append(attrs, slog.String("error", event.Error.Error()))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
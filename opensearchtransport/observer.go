@@ -8,6 +8,7 @@ package opensearchtransport
 
 import (
 	"context"
+	"net/http"
 	"sync/atomic"
 )
 
@@ -137,6 +138,29 @@ type ConnectionObserver interface { //nolint:interfacebloat // lifecycle + routi
 	OnStreamResponse(ctx context.Context, event StreamResponseEvent)
 }
 
+// AttemptRequestObserver is an optional interface a [ConnectionObserver] may
+// implement to see the outgoing request of each round-trip attempt, for
+// example to propagate trace context in its headers.
+type AttemptRequestObserver interface {
+	// OnAttemptRequest is called after OnAttemptStart with the attempt's
+	// context and the outgoing request, already addressed to the selected node
+	// and signed. An observer may read req.URL (e.g. the node for a span
+	// attribute) and set headers on req, such as trace-context propagation
+	// headers; headers set here are not covered by a request signature. It must
+	// not read or replace the body or change the method or URL.
+	OnAttemptRequest(ctx context.Context, req *http.Request)
+}
+
+// CacheObserver is an optional interface a [ConnectionObserver] may
+// implement to be told of requests answered from an API client's response
+// cache (see opensearchapi.Config.Cache).
+type CacheObserver interface {
+	// OnCacheHit is called when an API client with a response cache answers
+	// a request from the cache instead of sending it. No transport hook fires
+	// for such a request. ctx is the caller's request context.
+	OnCacheHit(ctx context.Context, event CacheHitEvent)
+}
+
 // BaseConnectionObserver is an embeddable no-op implementation of
 // ConnectionObserver. Embed it in your own struct and override only the
 // methods you care about.
@@ -226,6 +250,14 @@ func (BaseConnectionObserver) OnStreamResponse(ctx context.Context, event Stream
 // Compile-time check that BaseConnectionObserver implements ConnectionObserver.
 var _ ConnectionObserver = (*BaseConnectionObserver)(nil)
 
+// notifyAttemptRequest hands req to obs when it implements
+// [AttemptRequestObserver].
+func notifyAttemptRequest(ctx context.Context, obs ConnectionObserver, req *http.Request) {
+	if ar, ok := obs.(AttemptRequestObserver); ok {
+		ar.OnAttemptRequest(ctx, req)
+	}
+}
+
 // observerFromAtomic loads a ConnectionObserver from an atomic pointer.
 // Returns nil if the pointer is nil or stores nil.
 func observerFromAtomic(p *atomic.Pointer[ConnectionObserver]) ConnectionObserver {
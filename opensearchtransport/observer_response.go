@@ -97,3 +97,25 @@ type StreamResponseEvent struct {
 	// length is unknown (chunked transfer, compressed, or absent).
 	ContentLength int64
 }
+
+// CacheHitEvent is fired when a request is answered from an API client's
+// response cache. Its Request carries Method, Path, RouteName, and Index;
+// Host, PoolName, and Attempt are zero because no node was contacted.
+type CacheHitEvent struct {
+	// Request is the originating request snapshot.
+	Request RequestEvent
+
+	// StatusCode is the status of the cached response.
+	StatusCode int
+
+	// Age is the time since the cached response was received from the
+	// cluster.
+	Age time.Duration
+
+	// Revalidated is true when the entry had outlived its TTL and was served
+	// after a check that the cluster state version had not changed since.
+	Revalidated bool
+
+	// ResponseBytes is the size of the cached response body in bytes.
+	ResponseBytes int64
+}
@@ -17,6 +17,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport/testutil/mockhttp"
 )
 
 func TestResponseEventFieldPromotion(t *testing.T) {
@@ -320,8 +322,9 @@ type tracingObserver struct {
 	mu           sync.Mutex
 	calls        []string // ordered hook names
 	startEvent   RequestEvent
-	attemptCtxOK []bool // per-attempt: did the request-scoped value reach OnAttemptStart?
-	respCtxOK    bool   // did it reach the response hook?
+	attemptCtxOK []bool   // per-attempt: did the request-scoped value reach OnAttemptStart?
+	attemptHosts []string // per-attempt: the node OnAttemptRequest saw
+	respCtxOK    bool     // did it reach the response hook?
 	attempts     int
 }
 
@@ -341,6 +344,16 @@ func (o *tracingObserver) OnAttemptStart(ctx context.Context, attempt int) conte
 	return ctx
 }
 
+func (o *tracingObserver) OnAttemptRequest(ctx context.Context, req *http.Request) {
+	o.mu.Lock()
+	o.calls = append(o.calls, "attempt_request")
+	o.attemptHosts = append(o.attemptHosts, req.URL.Host)
+	o.mu.Unlock()
+	if v, ok := ctx.Value(traceKey{}).(string); ok {
+		req.Header.Set("Traceparent", v)
+	}
+}
+
 func (o *tracingObserver) OnAttemptEnd(_ context.Context, attempt int, _ int, _ error) {
 	o.mu.Lock()
 	o.calls = append(o.calls, "attempt_end")
@@ -377,10 +390,90 @@ func TestTracingHooksLifecycle(t *testing.T) {
 
 	obs.mu.Lock()
 	defer obs.mu.Unlock()
-	require.Equal(t, []string{"start", "attempt_start", "attempt_end", "response"}, obs.calls,
+	require.Equal(t, []string{"start", "attempt_start", "attempt_request", "attempt_end", "response"}, obs.calls,
 		"hooks fire in lifecycle order")
 	require.Equal(t, "search", obs.startEvent.RouteName, "OnRequestStart sees the classified route")
 	require.Equal(t, []bool{true}, obs.attemptCtxOK, "request-scoped context reaches OnAttemptStart")
 	require.True(t, obs.respCtxOK, "request-scoped context reaches OnRequestResponse")
 	require.Equal(t, 1, obs.attempts)
 }
+
+// TestAttemptRequestHeaders proves OnAttemptRequest sees each attempt's request
+// addressed to the selected node, and that headers it sets reach the server on
+// every attempt, retries included.
+func TestAttemptRequestHeaders(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs/_search" {
+			// Health checks carry no attempt context.
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		mu.Lock()
+		headers = append(headers, r.Header.Get("Traceparent"))
+		n := len(headers)
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+
+	u := mustParseURL(ts.URL)
+	obs := &tracingObserver{}
+	tp, err := New(Config{URLs: []*url.URL{u}, Observer: obs})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Close() })
+
+	req, err := http.NewRequest(http.MethodGet, "/logs/_search", nil)
+	require.NoError(t, err)
+	res, err := tp.Request(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []string{"span", "span"}, headers, "the header is set on the retry too")
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	require.Equal(t, []string{u.Host, u.Host}, obs.attemptHosts, "OnAttemptRequest sees the addressed request")
+}
+
+// TestAttemptRequestHeadersSeedFallback proves the seed-fallback attempt is
+// observed like any other, so its request carries the attempt's headers.
+func TestAttemptRequestHeadersSeedFallback(t *testing.T) {
+	var header string
+	obs := &tracingObserver{}
+	tp, err := New(Config{
+		URLs:              []*url.URL{mustParseURL("http://seed-node:9200")},
+		HealthCheck:       NoOpHealthCheck,
+		NodeStatsInterval: -1,
+		Router:            &emptyRouter{},
+		Observer:          obs,
+		Transport: mockhttp.NewRoundTripFunc(t, func(req *http.Request) (*http.Response, error) {
+			header = req.Header.Get("Traceparent")
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: http.NoBody}, nil
+		}),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Close() })
+
+	req, err := http.NewRequest(http.MethodGet, "/logs/_search", nil)
+	require.NoError(t, err)
+	res, err := tp.Request(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+
+	require.Equal(t, "span", header)
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	require.Equal(t, []string{"seed-node:9200"}, obs.attemptHosts)
+	require.Equal(t, 1, obs.attempts, "the fallback attempt ends")
+}
@@ -164,6 +164,26 @@ type Config struct {
 	// 0 = no per-attempt timeout (default), >0 = explicit timeout.
 	RequestTimeout time.Duration
 
+	// RequestBudget bounds the total time of a request across all of its
+	// attempts and retry backoffs, where RequestTimeout bounds each attempt
+	// and so is multiplied by MaxRetries in the worst case. A context
+	// deadline earlier than the budget takes its place. Each attempt gets
+	// the remaining budget (or RequestTimeout, if shorter). Search, bulk,
+	// index, and delete_by_query requests without a "timeout" query
+	// parameter are sent that attempt timeout less BudgetReserve as their
+	// server-side timeout, so the server stops work the client would
+	// abandon. An attempt or retry backoff that would leave no more than
+	// BudgetReserve fails with ErrBudgetExhausted.
+	// 0 = no budget (default), >0 = explicit budget.
+	RequestBudget time.Duration
+
+	// BudgetReserve is the part of the remaining RequestBudget held back
+	// from the server-side timeout of each attempt, for the response to
+	// reach the client; an attempt needs more than this to start.
+	// 0 = default (10% of the budget, or of the time to an earlier context
+	// deadline), >0 = explicit reserve.
+	BudgetReserve time.Duration
+
 	// DNSCacheRefresh controls how often the client-side DNS cache re-resolves
 	// cached hostnames. When the resolver is briefly unreachable, the
 	// last-known-good address continues to be served until the resolver recovers.
@@ -197,6 +217,16 @@ type Config struct {
 
 	EnableDebugLogger bool
 
+	// DebugLogger receives the debug output of connection management, node
+	// discovery, and health checks, such as a [SlogDebugLogger]. It takes
+	// precedence over EnableDebugLogger. Like EnableDebugLogger, it is
+	// installed process-wide.
+	DebugLogger DebuggingLogger
+
+	// Redactor masks credentials in the debug output. Nil applies the
+	// default rules of [Redactor]. Loggers carry their own Redactor.
+	Redactor *Redactor
+
 	DiscoverNodesInterval time.Duration
 
 	// DiscoveryHealthCheckRetries sets the number of health check retries during node discovery.
@@ -460,6 +490,8 @@ type Transport struct {
 	maxRetries            int
 	retryBackoff          func(attempt int) time.Duration
 	requestTimeout        time.Duration
+	requestBudget         time.Duration
+	budgetReserve         time.Duration
 	discoverNodesInterval time.Duration
 	verifyDeadAfter       time.Duration
 
@@ -523,6 +555,9 @@ type Transport struct {
 	observer   atomic.Pointer[ConnectionObserver]
 	poolFunc   func([]*Connection, Selector) ConnectionPool
 
+	// attemptLogger is logger when it also records attempt details.
+	attemptLogger AttemptLogger
+
 	// Address resolver
 	addressResolver       AddressResolverFunc
 	maxAddressResolvers   int
@@ -556,6 +591,10 @@ type Transport struct {
 	// cluster membership change detected by the request path).
 	discoveryNeeded atomic.Bool
 
+	// lastVersion is the server version reported by the most recent
+	// successful baseline health check (string). See ServerVersion.
+	lastVersion atomic.Value
+
 	mu struct {
 		sync.RWMutex
 		connectionPool ConnectionPool // Used for both single-node and multi-node
@@ -635,6 +674,15 @@ func New(cfg Config) (*Transport, error) {
 		}
 	}
 
+	// RequestBudget: 0 = no budget (default), >0 = explicit.
+	// OPENSEARCH_GO_REQUEST_BUDGET: same formats as OPENSEARCH_GO_REQUEST_TIMEOUT.
+	requestBudget := cfg.RequestBudget
+	if envVal, ok := os.LookupEnv(envvars.RequestBudget); ok && envVal != "" {
+		if d, ok := parseDuration(envVal); ok {
+			requestBudget = d
+		}
+	}
+
 	// VerifyDeadAfter: 0 = default, <0 = disabled, >0 = explicit.
 	// OPENSEARCH_GO_VERIFY_DEAD_AFTER overrides the programmatic value: bool
 	// true = default, false = disabled, otherwise a duration string. An
@@ -944,6 +992,8 @@ func New(cfg Config) (*Transport, error) {
 		maxRetries:            cfg.MaxRetries,
 		retryBackoff:          cfg.RetryBackoff,
 		requestTimeout:        requestTimeout,
+		requestBudget:         requestBudget,
+		budgetReserve:         cfg.BudgetReserve,
 		discoverNodesInterval: cfg.DiscoverNodesInterval,
 		verifyDeadAfter:       verifyDeadAfter,
 
@@ -1135,8 +1185,15 @@ func New(cfg Config) (*Transport, error) {
 		client.seedFallbackPool.mu.Unlock()
 	}
 
-	if cfg.EnableDebugLogger {
-		storeDebugLogger(&debuggingLogger{Output: os.Stdout})
+	if al, ok := cfg.Logger.(AttemptLogger); ok {
+		client.attemptLogger = al
+	}
+
+	switch {
+	case cfg.DebugLogger != nil:
+		storeDebugLogger(redactingDebugLogger{next: cfg.DebugLogger, redactor: cfg.Redactor})
+	case cfg.EnableDebugLogger:
+		storeDebugLogger(redactingDebugLogger{next: &debuggingLogger{Output: os.Stdout}, redactor: cfg.Redactor})
 	}
 
 	// Per-request counters are recorded on the hot path; the detailed snapshot
@@ -1445,6 +1502,10 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 			req = req.WithContext(ctx)
 			sr.ctx = ctx
 		}
+	} else if c.attemptLogger != nil {
+		// An AttemptLogger records the route as well; classify it here, before
+		// the URL is rewritten, as for an observer.
+		sr.routeName = c.operationClassifier().Classify(req.Method, req.URL.Path).String()
 	}
 
 	if req.Body != nil && req.Body != http.NoBody {
@@ -1481,14 +1542,27 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 		}
 	}
 
+	budget := c.startBudget(req)
+
 	for i := 0; i <= c.maxRetries; i++ {
 		var (
 			conn            *Connection
 			poolName        string
 			shouldRetry     bool
 			shouldCloseBody bool
+			budgetTimeout   time.Duration
 		)
 
+		// Under a request budget, start the attempt only when budget remains,
+		// and hand the server what is left of it.
+		if budget.enabled() {
+			var berr error
+			if budgetTimeout, berr = budget.attempt(req, i, lastFailure(res, err)); berr != nil {
+				res, err = nil, berr
+				break
+			}
+		}
+
 		if c.router != nil {
 			var hop NextHop
 			hop, err = c.router.Route(req.Context(), req)
@@ -1509,7 +1583,7 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 		}
 		if err != nil {
 			if c.logger != nil {
-				c.logRoundTrip(req, nil, err, time.Time{}, time.Duration(0))
+				c.logRoundTrip(RoundTrip{Request: req, Err: err, Attempt: i, PoolName: poolName, RouteName: sr.routeName})
 			}
 			// Wrap the error for context. If all pools are exhausted
 			// (ErrNoConnections), break to allow post-loop seed fallback.
@@ -1558,21 +1632,32 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 		attemptReq := req
 		var attemptCancel context.CancelFunc
 		attemptCtx := req.Context()
-		if c.requestTimeout > 0 {
-			attemptCtx, attemptCancel = context.WithTimeout(attemptCtx, c.requestTimeout)
+		attemptTimeout := c.requestTimeout
+		budgetBound := budgetTimeout > 0 && (attemptTimeout <= 0 || budgetTimeout < attemptTimeout)
+		if budgetBound {
+			attemptTimeout = budgetTimeout
+		}
+		if attemptTimeout > 0 {
+			attemptCtx, attemptCancel = context.WithTimeout(attemptCtx, attemptTimeout)
 		}
 		// Let an observer open a per-attempt span. Base returns ctx unchanged, so
 		// a non-tracing observer adds no context derivation here.
-		if obs := observerFromAtomic(&c.observer); obs != nil {
+		obs := observerFromAtomic(&c.observer)
+		if obs != nil {
 			attemptCtx = obs.OnAttemptStart(attemptCtx, i)
 		}
 		if attemptCtx != req.Context() {
 			attemptReq = req.WithContext(attemptCtx)
 		}
+		// Hand the observer the addressed request so it can propagate the
+		// attempt's trace context in its headers.
+		if obs != nil {
+			notifyAttemptRequest(attemptCtx, obs, attemptReq)
+		}
 
 		res, err = c.transport.RoundTrip(attemptReq)
 
-		if obs := observerFromAtomic(&c.observer); obs != nil {
+		if obs != nil {
 			statusCode := 0
 			if res != nil {
 				statusCode = res.StatusCode
@@ -1611,7 +1696,10 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 				//nolint:errcheck // ignored as this is only for logging
 				req.Body, _ = req.GetBody()
 			}
-			c.logRoundTrip(req, res, err, start.UTC(), dur)
+			c.logRoundTrip(RoundTrip{
+				Request: req, Response: res, Err: err, Start: start.UTC(), Duration: dur,
+				Attempt: i, Node: conn.hostPort, PoolName: poolName, RouteName: sr.routeName,
+			})
 		}
 
 		if err != nil {
@@ -1619,6 +1707,12 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 				c.metrics.failures.Add(1)
 			}
 
+			// An attempt that ran out of budget, rather than its own
+			// RequestTimeout or the caller's deadline, ends the request.
+			if budgetBound && errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+				err = budgetExhausted(i+1, err)
+			}
+
 			if dl := loadDebugLogger(); dl != nil {
 				dl.Logf("Request to %s failed: %v\n", conn.URL, err)
 			}
@@ -1689,6 +1783,11 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 					shouldRetry = true
 				}
 			}
+
+			// Nothing is left of the budget for a retry.
+			if errors.Is(err, ErrBudgetExhausted) {
+				shouldRetry = false
+			}
 		} else {
 			// Report the connection as successful
 			if c.router != nil {
@@ -1773,8 +1872,15 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 
 		// Delay the retry if a backoff function is configured
 		if c.retryBackoff != nil && i < c.maxRetries {
+			backoff := c.retryBackoff(i + 1)
+			if !budget.allows(backoff) {
+				// The retry would start out of budget; fail now rather
+				// than sleep through what is left.
+				res, err = nil, budgetExhausted(i+1, lastFailure(res, err))
+				break
+			}
 			var cancelled bool
-			timer := time.NewTimer(c.retryBackoff(i + 1))
+			timer := time.NewTimer(backoff)
 			select {
 			case <-req.Context().Done():
 				timer.Stop()
@@ -1791,7 +1897,15 @@ func (c *Transport) stream(req *http.Request) (*http.Response, streamResult, err
 	// Seed URL fallback: absolute last resort when the entire retry loop
 	// failed to obtain a connection from any router policy or pool.
 	if err != nil && errors.Is(err, ErrNoConnections) && !c.seedFallbackDisabled && c.seedFallbackPool != nil {
-		res, err = c.performSeedFallback(req.Context(), req, &sr)
+		var berr error
+		if budget.enabled() {
+			_, berr = budget.attempt(req, c.maxRetries+1, err)
+		}
+		if berr != nil {
+			err = berr
+		} else {
+			res, err = c.performSeedFallback(req.Context(), req, &sr)
+		}
 	}
 
 	// TODO: Consider wrapping the error with request context.
@@ -1885,16 +1999,35 @@ func (c *Transport) performSeedFallback(ctx context.Context, req *http.Request,
 	start := time.Now()
 
 	// Apply per-attempt timeout if configured.
-	attemptReq := req
+	attemptCtx := req.Context()
 	var attemptCancel context.CancelFunc
 	if c.requestTimeout > 0 {
-		var attemptCtx context.Context
-		attemptCtx, attemptCancel = context.WithTimeout(req.Context(), c.requestTimeout)
+		attemptCtx, attemptCancel = context.WithTimeout(attemptCtx, c.requestTimeout)
+	}
+	// The fallback is an attempt like any other: let an observer open its span
+	// and propagate it in the request headers.
+	obs := observerFromAtomic(&c.observer)
+	if obs != nil {
+		attemptCtx = obs.OnAttemptStart(attemptCtx, sr.attempt)
+	}
+	attemptReq := req
+	if attemptCtx != req.Context() {
 		attemptReq = req.WithContext(attemptCtx) //nolint:contextcheck // child of req.Context()
 	}
+	if obs != nil {
+		notifyAttemptRequest(attemptCtx, obs, attemptReq)
+	}
 
 	res, err := c.transport.RoundTrip(attemptReq)
 
+	if obs != nil {
+		statusCode := 0
+		if res != nil {
+			statusCode = res.StatusCode
+		}
+		obs.OnAttemptEnd(attemptCtx, sr.attempt, statusCode, err)
+	}
+
 	if attemptCancel != nil {
 		if err != nil || res == nil {
 			attemptCancel()
@@ -1905,7 +2038,10 @@ func (c *Transport) performSeedFallback(ctx context.Context, req *http.Request,
 	dur := time.Since(start)
 
 	if c.logger != nil {
-		c.logRoundTrip(req, res, err, start.UTC(), dur)
+		c.logRoundTrip(RoundTrip{
+			Request: req, Response: res, Err: err, Start: start.UTC(), Duration: dur,
+			Attempt: sr.attempt, Node: conn.hostPort, RouteName: sr.routeName,
+		})
 	}
 
 	if err != nil {
@@ -2109,29 +2245,27 @@ func (c *Transport) setReqGlobalHeader(req *http.Request) {
 	}
 }
 
-func (c *Transport) logRoundTrip(
-	req *http.Request,
-	res *http.Response,
-	err error,
-	start time.Time,
-	dur time.Duration,
-) {
+func (c *Transport) logRoundTrip(rt RoundTrip) {
 	var dupRes http.Response
-	if res != nil {
+	if res := rt.Response; res != nil {
 		dupRes = *res
-	}
 
-	if c.logger.ResponseBodyEnabled() {
-		if res != nil && res.Body != nil && res.Body != http.NoBody {
+		if c.logger.ResponseBodyEnabled() && res.Body != nil && res.Body != http.NoBody {
 			//nolint:errcheck // ignored as this is only for logging
 			b1, b2, _ := duplicateBody(res.Body)
 			dupRes.Body = b1
 			res.Body = b2
 		}
 	}
+	rt.Response = &dupRes
 
+	if c.attemptLogger != nil {
+		//nolint:errcheck // ignored as this is only for logging
+		c.attemptLogger.LogAttempt(rt)
+		return
+	}
 	//nolint:errcheck // ignored as this is only for logging
-	c.logger.LogRoundTrip(req, &dupRes, err, start, dur)
+	c.logger.LogRoundTrip(rt.Request, rt.Response, rt.Err, rt.Start, rt.Duration)
 }
 
 // DefaultHealthCheck performs a health check on the given connection URL, choosing the best
@@ -2282,6 +2416,7 @@ func (c *Transport) baselineHealthCheck(ctx context.Context, u *url.URL, applyMo
 	if info.Name == "" || info.ClusterName == "" || info.Version.Number == "" {
 		return nil, fmt.Errorf("%w: invalid response structure", errHealthCheckFailed)
 	}
+	c.lastVersion.Store(info.Version.Number)
 
 	res.Body = io.NopCloser(bytes.NewReader(body))
 	return res, nil
@@ -0,0 +1,347 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtransport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// redactedValue replaces masked header values, fields, and bodies.
+const redactedValue = "[redacted]"
+
+// Default redaction rules, applied unless Redactor.NoDefaults is set.
+//
+//nolint:gochecknoglobals // read-only rule tables
+var (
+	defaultRedactHeaders = []string{
+		"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Amz-Security-Token",
+	}
+	defaultRedactFields = []string{"**.password", "**.current_password", "**.new_password"}
+
+	// defaultRedactOperations are the security plugin APIs whose bodies carry
+	// password hashes and credentials.
+	defaultRedactOperations = []RedactOperation{
+		{Path: "/_plugins/_security/api/internalusers"},
+		{Path: "/_plugins/_security/api/internalusers/*"},
+		{Path: "/_plugins/_security/api/account"},
+		{Path: "/_opendistro/_security/api/internalusers"},
+		{Path: "/_opendistro/_security/api/internalusers/*"},
+		{Path: "/_opendistro/_security/api/account"},
+	}
+
+	// urlUserinfo matches the credentials of a URL, as printed by url.URL.String.
+	urlUserinfo = regexp.MustCompile(`://[^/\s@]+@`)
+)
+
+// Redactor masks credentials and personal data in what the loggers print, in
+// the debug output, and in opensearch.Response.String. It masks the values of
+// listed headers, the values of JSON fields by path, the matches of regular
+// expressions, and the whole body of listed operations, each replaced by
+// "[redacted]".
+//
+// A nil *Redactor applies the defaults: the Authorization, Proxy-Authorization,
+// Cookie, Set-Cookie, and X-Amz-Security-Token headers, password fields at any
+// depth, URL credentials, and the bodies of the security plugin's internalusers
+// and account APIs. The rules set on a Redactor add to the defaults unless
+// NoDefaults is set.
+type Redactor struct {
+	// Headers are the names of headers whose values are masked, matched
+	// case-insensitively.
+	Headers []string
+
+	// Fields are paths of JSON fields whose values are masked: object keys
+	// separated by dots, from the document root, where "*" matches any one
+	// key and "**" any number of keys, as in "user.ssn" or "**.card_number".
+	// Array elements share the path of their array. Each document of an
+	// NDJSON body, such as a bulk request, is masked separately.
+	Fields []string
+
+	// Patterns are regular expressions whose matches in bodies and debug
+	// output are masked.
+	Patterns []*regexp.Regexp
+
+	// Operations are the requests whose bodies are masked entirely.
+	Operations []RedactOperation
+
+	// NoDefaults drops the default rules, leaving only those set here.
+	NoDefaults bool
+}
+
+// RedactOperation selects the requests a [Redactor] masks the bodies of.
+type RedactOperation struct {
+	// Method is the request method; empty matches any method.
+	Method string
+
+	// Path is a pattern for the request path, as for path.Match, so "*"
+	// matches one path segment, as in "/_plugins/_security/api/internalusers/*".
+	Path string
+}
+
+// RedactHeader returns value, or "[redacted]" when the header name is masked.
+func (r *Redactor) RedactHeader(name, value string) string {
+	if r.maskedHeader(name) {
+		return redactedValue
+	}
+	return value
+}
+
+// RedactHeaders returns a copy of h with the values of masked headers
+// replaced.
+func (r *Redactor) RedactHeaders(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		if r.maskedHeader(name) {
+			out[name] = []string{redactedValue}
+			continue
+		}
+		out[name] = values
+	}
+	return out
+}
+
+// RedactURL returns u as a string with its credentials masked.
+func (r *Redactor) RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return r.RedactText(u.Redacted())
+}
+
+// RedactText returns s with the URL credentials and pattern matches masked,
+// as applied to debug output.
+func (r *Redactor) RedactText(s string) string {
+	if r == nil || !r.NoDefaults {
+		s = urlUserinfo.ReplaceAllString(s, "://"+redactedValue+"@")
+	}
+	if r != nil {
+		for _, re := range r.Patterns {
+			s = re.ReplaceAllString(s, redactedValue)
+		}
+	}
+	return s
+}
+
+// RedactBody returns the body of a request or response for the request with
+// method and urlPath, with masked fields and pattern matches replaced, or
+// "[redacted]" when the operation is masked. An empty urlPath matches no
+// operation. The result aliases body when nothing is masked.
+func (r *Redactor) RedactBody(method, urlPath string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if urlPath != "" && r.maskedOperation(method, urlPath) {
+		return []byte(redactedValue)
+	}
+	if fields := r.fields(); len(fields) > 0 && mayContainField(body, fields) {
+		if masked, ok := maskJSONFields(body, fields); ok {
+			body = masked
+		}
+	}
+	if r != nil {
+		for _, re := range r.Patterns {
+			body = re.ReplaceAll(body, []byte(redactedValue))
+		}
+	}
+	return body
+}
+
+// RedactBodyFor returns body, of req or of its response, redacted for the
+// method and path of req, as RedactBody.
+func (r *Redactor) RedactBodyFor(req *http.Request, body []byte) []byte {
+	if req == nil || req.URL == nil {
+		return r.RedactBody("", "", body)
+	}
+	return r.RedactBody(req.Method, req.URL.Path, body)
+}
+
+func (r *Redactor) maskedHeader(name string) bool {
+	if r == nil || !r.NoDefaults {
+		for _, h := range defaultRedactHeaders {
+			if strings.EqualFold(h, name) {
+				return true
+			}
+		}
+	}
+	if r != nil {
+		for _, h := range r.Headers {
+			if strings.EqualFold(h, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Redactor) maskedOperation(method, urlPath string) bool {
+	match := func(ops []RedactOperation) bool {
+		for _, op := range ops {
+			if op.Method != "" && !strings.EqualFold(op.Method, method) {
+				continue
+			}
+			if ok, _ := path.Match(op.Path, urlPath); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if (r == nil || !r.NoDefaults) && match(defaultRedactOperations) {
+		return true
+	}
+	return r != nil && match(r.Operations)
+}
+
+// fields returns the field paths, split into keys.
+func (r *Redactor) fields() [][]string {
+	var paths []string
+	if r == nil || !r.NoDefaults {
+		paths = defaultRedactFields
+	}
+	if r != nil && len(r.Fields) > 0 {
+		paths = append(append([]string(nil), paths...), r.Fields...)
+	}
+	fields := make([][]string, 0, len(paths))
+	for _, p := range paths {
+		fields = append(fields, strings.Split(p, "."))
+	}
+	return fields
+}
+
+// mayContainField reports whether body may contain one of fields, by the
+// quoted last key of each, so bodies without them skip JSON decoding.
+func mayContainField(body []byte, fields [][]string) bool {
+	for _, f := range fields {
+		last := f[len(f)-1]
+		if last == "*" || last == "**" {
+			return true
+		}
+		if bytes.Contains(body, []byte(`"`+last+`"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskJSONFields masks fields in each JSON document of body. It returns
+// false when body is not JSON or nothing was masked, leaving body to be used
+// as is.
+func maskJSONFields(body []byte, fields [][]string) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var (
+		docs   []any
+		masked bool
+	)
+	for {
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false
+		}
+		var m bool
+		doc, m = maskValue(doc, nil, fields)
+		masked = masked || m
+		docs = append(docs, doc)
+	}
+	if !masked {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, false
+		}
+	}
+	out := buf.Bytes()
+	if len(docs) == 1 && !bytes.HasSuffix(bytes.TrimRight(body, " \t\r"), []byte("\n")) {
+		out = bytes.TrimSuffix(out, []byte("\n"))
+	}
+	return out, true
+}
+
+// maskValue replaces the values of v at fields, where keys is the path of v.
+func maskValue(v any, keys []string, fields [][]string) (any, bool) {
+	var masked bool
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			p := append(keys[:len(keys):len(keys)], k)
+			if matchesAnyField(fields, p) {
+				t[k] = redactedValue
+				masked = true
+				continue
+			}
+			var m bool
+			t[k], m = maskValue(child, p, fields)
+			masked = masked || m
+		}
+	case []any:
+		for i, child := range t {
+			var m bool
+			t[i], m = maskValue(child, keys, fields)
+			masked = masked || m
+		}
+	}
+	return v, masked
+}
+
+func matchesAnyField(fields [][]string, keys []string) bool {
+	for _, f := range fields {
+		if matchFieldPath(f, keys) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFieldPath reports whether keys matches pattern, where "*" matches one
+// key and "**" any number of keys.
+func matchFieldPath(pattern, keys []string) bool {
+	if len(pattern) == 0 {
+		return len(keys) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(keys); i++ {
+			if matchFieldPath(pattern[1:], keys[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(keys) == 0 || (pattern[0] != "*" && pattern[0] != keys[0]) {
+		return false
+	}
+	return matchFieldPath(pattern[1:], keys[1:])
+}
+
+// redactingDebugLogger masks the URL credentials and patterns of a Redactor
+// in the output of a DebuggingLogger.
+type redactingDebugLogger struct {
+	next     DebuggingLogger
+	redactor *Redactor
+}
+
+func (l redactingDebugLogger) Log(a ...any) error {
+	return l.next.Log(l.redactor.RedactText(fmt.Sprint(a...)))
+}
+
+func (l redactingDebugLogger) Logf(format string, a ...any) error {
+	return l.next.Log(l.redactor.RedactText(fmt.Sprintf(format, a...)))
+}
@@ -99,8 +99,12 @@ func rendezvousTopK(
 		nonShard = part[shardLen:]
 		*partBuf = part // keep slice header in sync for putConnSlice
 	} else {
-		// No shard placement data -- treat all nodes equally.
-		shard = conns
+		// No shard placement data -- treat all nodes equally. Copy into
+		// the pooled buffer anyway: rankByHash sorts in place, and conns
+		// is shared with concurrent requests.
+		partBuf = getConnSlice(len(conns))
+		shard = append((*partBuf)[:0], conns...)
+		*partBuf = shard
 	}
 
 	var slots []*Connection
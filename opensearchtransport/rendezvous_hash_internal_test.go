@@ -8,6 +8,9 @@ package opensearchtransport
 
 import (
 	"net/url"
+	"slices"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -354,3 +357,31 @@ func TestRendezvousTopKConsistency(t *testing.T) {
 	require.Greater(t, stableCount, totalKeys/2,
 		"rendezvous hashing should provide stability when nodes change: %d/%d stable", stableCount, totalKeys)
 }
+
+// TestRendezvousTopKSharedSlice is a regression test: rendezvousTopK ranks a
+// copy of conns, so concurrent requests sharing the pool's connection slice
+// neither race on it (run with -race) nor reorder it.
+func TestRendezvousTopKSharedSlice(t *testing.T) {
+	t.Parallel()
+
+	conns := []*Connection{
+		testConn(t, "node1:9200", "n1", 1*time.Millisecond),
+		testConn(t, "node2:9200", "n2", 1*time.Millisecond),
+		testConn(t, "node3:9200", "n3", 1*time.Millisecond),
+		testConn(t, "node4:9200", "n4", 1*time.Millisecond),
+	}
+	want := slices.Clone(conns)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 200 {
+				key := "index-" + strconv.Itoa(i*200+j)
+				require.Len(t, rendezvousTopK(key, "", conns, 2, nil, nil, nil), 2)
+			}
+		})
+	}
+	wg.Wait()
+
+	require.Equal(t, want, conns, "the shared slice keeps its order")
+}
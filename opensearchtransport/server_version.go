@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchtransport
+
+import (
+	"github.com/opensearch-project/opensearch-go/v5/internal/version"
+)
+
+// Versioned defines the interface for transports that know the version of
+// the cluster they talk to.
+type Versioned interface {
+	ServerVersion() string
+}
+
+// ServerVersion returns the OpenSearch version of the cluster, as reported
+// by GET / during health checks, or "" when no check has succeeded yet.
+//
+// During a rolling upgrade the live nodes run different versions and a
+// request may land on any of them, so the lowest version is returned. A
+// node that has not reported a version yet is skipped; when none has, the
+// version from the most recent baseline health check is used.
+func (c *Transport) ServerVersion() string {
+	var ready, single []*Connection
+	c.mu.RLock()
+	switch pool := c.mu.connectionPool.(type) {
+	case *multiServerPool:
+		ready, _ = pool.connectionsByState()
+	case *singleServerPool:
+		single = pool.connections()
+	}
+	c.mu.RUnlock()
+
+	lowest := ""
+	for _, conns := range [][]*Connection{ready, single} {
+		for _, conn := range conns {
+			if v := conn.loadVersion(); v != "" && (lowest == "" || version.Compare(v, lowest) < 0) {
+				lowest = v
+			}
+		}
+	}
+	if lowest != "" {
+		return lowest
+	}
+	v, _ := c.lastVersion.Load().(string)
+	return v
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package opensearchtransport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown before any health check", func(t *testing.T) {
+		t.Parallel()
+		tp := &Transport{}
+		require.Empty(t, tp.ServerVersion())
+	})
+
+	t.Run("lowest version across live nodes", func(t *testing.T) {
+		t.Parallel()
+		old := createTestConnection("http://node1:9200", "data")
+		old.storeVersion("2.19.1")
+		upgraded := createTestConnection("http://node2:9200", "data")
+		upgraded.storeVersion("3.0.0")
+		pending := createTestConnection("http://node3:9200", "data")
+		down := createTestConnection("http://node4:9200", "data")
+		down.storeVersion("1.3.0")
+
+		pool := &multiServerPool{name: "test"}
+		pool.mu.ready = []*Connection{upgraded, pending, old}
+		pool.mu.dead = []*Connection{down}
+
+		tp := &Transport{}
+		tp.lastVersion.Store("3.0.0")
+		tp.mu.connectionPool = pool
+		require.Equal(t, "2.19.1", tp.ServerVersion())
+	})
+
+	t.Run("falls back to the last baseline health check", func(t *testing.T) {
+		t.Parallel()
+		conn := createTestConnection("http://node1:9200", "data")
+
+		tp := &Transport{}
+		tp.lastVersion.Store("2.12.0")
+		tp.mu.connectionPool = &singleServerPool{connection: conn}
+		require.Equal(t, "2.12.0", tp.ServerVersion())
+
+		conn.storeVersion("2.13.0")
+		require.Equal(t, "2.13.0", tp.ServerVersion())
+	})
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package cassette provides an HTTP round tripper recording real request and
+// response pairs to a file, and replaying them offline, so tests can run the
+// full opensearchtransport.Transport, including node discovery, health checks,
+// and routing, deterministically without a cluster.
+//
+// Record a cassette once against a live cluster:
+//
+//	rec, err := cassette.New(cassette.Config{Path: "testdata/search.json", Mode: cassette.ModeRecord})
+//	tp, err := opensearchtransport.New(opensearchtransport.Config{URLs: urls, Transport: rec})
+//	// ... run the test ...
+//	err = rec.Save()
+//
+// and replay it in CI by setting Mode to [ModeReplay]. Recorded headers and
+// bodies are scrubbed by an [opensearchtransport.Redactor] before they are
+// written, so credentials never reach the file.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// Version is the version of the cassette file format written by [Recorder.Save].
+// Loading a cassette of another version fails, so a change of format is
+// caught rather than replayed wrongly.
+const Version = 1
+
+// Cassette is the content of a cassette file: the recorded interactions in
+// the order they happened.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. URL holds no credentials, and Headers and
+// Body are scrubbed.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body,omitzero"`
+}
+
+// Response is a recorded response. Headers and Body are scrubbed.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       Body        `json:"body,omitzero"`
+}
+
+// Body is a recorded body, written to the file as text when it is valid
+// UTF-8 and as base64 otherwise.
+type Body []byte
+
+type bodyJSON struct {
+	Text   *string `json:"text,omitempty"`
+	Base64 []byte  `json:"base64,omitempty"`
+}
+
+// MarshalJSON encodes b as text or base64.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		s := string(b)
+		return json.Marshal(bodyJSON{Text: &s})
+	}
+	return json.Marshal(bodyJSON{Base64: b})
+}
+
+// UnmarshalJSON decodes b from text or base64.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var v bodyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Text != nil {
+		*b = Body(*v.Text)
+		return nil
+	}
+	*b = v.Base64
+	return nil
+}
+
+// Load reads the cassette file at path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("cassette: %s: %w", path, err)
+	}
+	if c.Version != Version {
+		return nil, fmt.Errorf("cassette: %s: unsupported version %d, want %d", path, c.Version, Version)
+	}
+	return &c, nil
+}
+
+// Save writes c to the file at path, creating its directory as needed. The
+// file is replaced atomically, so an interrupted recording leaves the
+// previous cassette intact.
+func (c *Cassette) Save(path string) error {
+	c.Version = Version
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:mnd // conventional directory mode
+		return fmt.Errorf("cassette: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o644); err != nil { //nolint:gosec,mnd // test fixtures are world-readable
+		tmp.Close()
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package cassette_test
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport/testutil/cassette"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport/testutil/mockhttp"
+)
+
+// newTransport returns a Transport sending through rt, with discovery and
+// health checks left to the test.
+func newTransport(t *testing.T, rt http.RoundTripper) *opensearchtransport.Transport {
+	t.Helper()
+	u, _ := url.Parse("http://127.0.0.1:9200")
+	tp, err := opensearchtransport.New(opensearchtransport.Config{
+		URLs:         []*url.URL{u},
+		Username:     "admin",
+		Password:     "secret",
+		Transport:    rt,
+		DisableRetry: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tp.Close() })
+	return tp
+}
+
+func search(t *testing.T, tp *opensearchtransport.Transport, body string) (*http.Response, error) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, "/logs/_search?size=1&from=0", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return tp.Request(req)
+}
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "testdata", "search.json")
+
+	routes := mockhttp.GetDefaultHandlersWithNodes(t, map[string][]string{
+		"data-1": {"data", "ingest"},
+		"data-2": {"data", "ingest"},
+	})
+	hits := 0
+	routes["/logs/_search"] = func(w http.ResponseWriter, _ *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = io.WriteString(w, `{"hits":{"total":{"value":`+strconv.Itoa(hits)+`}}}`)
+	}
+
+	// Record against the mock cluster.
+	rec, err := cassette.New(cassette.Config{
+		Path:      path,
+		Mode:      cassette.ModeRecord,
+		Transport: mockhttp.NewTransportFromRoutes(t, routes),
+	})
+	require.NoError(t, err)
+	tp := newTransport(t, rec)
+	require.NoError(t, tp.DiscoverNodes(t.Context()))
+	for range 2 {
+		res, err := search(t, tp, `{"query":{"match_all":{}}}`)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+	}
+	require.NoError(t, rec.Save())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), `"version": 1`)
+	require.NotContains(t, string(raw), "YWRtaW46c2VjcmV0", "basic auth credentials are scrubbed")
+	require.NotContains(t, string(raw), "session=abc")
+
+	// Replay offline: discovery, routing, and the searches run without the
+	// mock cluster.
+	replay, err := cassette.New(cassette.Config{Path: path, Mode: cassette.ModeReplay})
+	require.NoError(t, err)
+	tp = newTransport(t, replay)
+	require.NoError(t, tp.DiscoverNodes(t.Context()))
+	require.Len(t, tp.URLs(), 2, "discovered nodes are replayed")
+
+	for _, want := range []string{"1", "2", "2"} {
+		res, err := search(t, tp, "{ \"query\": { \"match_all\": { } } }\n")
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.JSONEq(t, `{"hits":{"total":{"value":`+want+`}}}`, string(body),
+			"responses replay in order, then the last repeats")
+	}
+
+	_, err = search(t, tp, `{"query":{"term":{"level":"error"}}}`)
+	require.ErrorIs(t, err, cassette.ErrNoInteraction)
+}
+
+func TestMatchers(t *testing.T) {
+	t.Parallel()
+
+	recorded := cassette.Request{
+		Method: http.MethodPost,
+		URL:    "http://node-1:9200/logs/_search?size=1&from=0",
+		Body:   cassette.Body(`{"query":{"match":{"msg":"a"}},"size":1}`),
+	}
+	tests := []struct {
+		name    string
+		matcher cassette.Matcher
+		req     cassette.Request
+		want    bool
+	}{
+		{"method", cassette.MatchMethod, cassette.Request{Method: "post"}, true},
+		{"path ignores host", cassette.MatchPath, cassette.Request{URL: "http://node-2:9200/logs/_search"}, true},
+		{"host", cassette.MatchHost, cassette.Request{URL: "http://node-2:9200/logs/_search"}, false},
+		{"query in any order", cassette.MatchQuery, cassette.Request{URL: "/logs/_search?from=0&size=1"}, true},
+		{"query differs", cassette.MatchQuery, cassette.Request{URL: "/logs/_search?size=2&from=0"}, false},
+		{
+			"body normalized", cassette.MatchBody,
+			cassette.Request{Body: cassette.Body("{ \"size\": 1, \"query\": { \"match\": { \"msg\": \"a\" } } }\n")}, true,
+		},
+		{"body differs", cassette.MatchBody, cassette.Request{Body: cassette.Body(`{"size":2}`)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, tt.matcher(tt.req, recorded))
+		})
+	}
+}
+
+func TestLoadVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "old.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":0,"interactions":[]}`), 0o600))
+	_, err := cassette.New(cassette.Config{Path: path, Mode: cassette.ModeReplay})
+	require.ErrorContains(t, err, "unsupported version 0")
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Matcher reports whether an incoming request matches a recorded one. The
+// incoming request is scrubbed as it would be recorded before it is matched.
+type Matcher func(req, recorded Request) bool
+
+// DefaultMatchers returns the matchers used when Config.Matchers is empty:
+// [MatchMethod], [MatchPath], [MatchQuery], and [MatchBody]. The host is not
+// matched, so requests routed to any discovered node replay the same way.
+func DefaultMatchers() []Matcher {
+	return []Matcher{MatchMethod, MatchPath, MatchQuery, MatchBody}
+}
+
+// MatchMethod matches requests with the same method.
+func MatchMethod(req, recorded Request) bool {
+	return strings.EqualFold(req.Method, recorded.Method)
+}
+
+// MatchHost matches requests sent to the same host and port.
+func MatchHost(req, recorded Request) bool {
+	a, b := parseURL(req.URL), parseURL(recorded.URL)
+	return a.Host == b.Host
+}
+
+// MatchPath matches requests with the same URL path.
+func MatchPath(req, recorded Request) bool {
+	a, b := parseURL(req.URL), parseURL(recorded.URL)
+	return a.Path == b.Path
+}
+
+// MatchQuery matches requests with the same query parameters, in any order.
+func MatchQuery(req, recorded Request) bool {
+	a, b := parseURL(req.URL).Query(), parseURL(recorded.URL).Query()
+	if len(a) != len(b) {
+		return false
+	}
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if av[i] != bv[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MatchBody matches requests with the same body after normalization: JSON
+// bodies, and each document of NDJSON bodies, are compared regardless of
+// whitespace and key order. Other bodies are compared byte for byte.
+func MatchBody(req, recorded Request) bool {
+	return bytes.Equal(NormalizeBody(req.Body), NormalizeBody(recorded.Body))
+}
+
+// NormalizeBody returns body with each JSON document re-encoded compactly with
+// sorted keys, one per line, or body unchanged when it is not JSON.
+func NormalizeBody(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	for {
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return buf.Bytes()
+			}
+			return body
+		}
+		out, err := json.Marshal(doc)
+		if err != nil {
+			return body
+		}
+		buf.Write(out)
+		buf.WriteByte('\n')
+	}
+}
+
+func parseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		return &url.URL{}
+	}
+	return u
+}
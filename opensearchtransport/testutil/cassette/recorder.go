@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// ErrNoInteraction is returned in replay mode for a request matching no
+// recorded interaction.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+// Mode selects whether a [Recorder] records or replays.
+type Mode int
+
+const (
+	// ModeReplay serves recorded responses without sending any request.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests with Config.Transport and records each
+	// round trip, to be written by [Recorder.Save].
+	ModeRecord
+)
+
+// String returns the name of m.
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Config configures a [Recorder].
+type Config struct {
+	// Path is the cassette file, read in replay mode and written by
+	// Recorder.Save in record mode.
+	Path string
+
+	Mode Mode
+
+	// Transport sends the requests in record mode. Defaults to
+	// http.DefaultTransport; unused in replay mode.
+	Transport http.RoundTripper
+
+	// Matchers select the recorded interaction served for a request; all
+	// must match. Empty uses DefaultMatchers.
+	Matchers []Matcher
+
+	// Redactor scrubs the headers and bodies of recorded requests and
+	// responses; nil applies the opensearchtransport.Redactor defaults.
+	Redactor *opensearchtransport.Redactor
+}
+
+// Recorder is an [http.RoundTripper] recording or replaying a cassette. Set it
+// as the Transport of an opensearchtransport.Config. It is safe for concurrent
+// use.
+//
+// In replay mode, the recorded interactions matching a request are served in
+// the order they were recorded, each once; when all have been served, the last
+// one is served again. Periodic traffic such as health checks and node
+// discovery thus replays however often it happens, while a sequence of
+// requests to the same endpoint replays its responses in order.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+	matchers  []Matcher
+	redactor  *opensearchtransport.Redactor
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// Compile-time check that Recorder implements http.RoundTripper.
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// New returns a Recorder for cfg. In replay mode, it loads the cassette at
+// cfg.Path.
+func New(cfg Config) (*Recorder, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("cassette: Config.Path is required")
+	}
+	r := &Recorder{
+		mode:      cfg.Mode,
+		path:      cfg.Path,
+		transport: cfg.Transport,
+		matchers:  cfg.Matchers,
+		redactor:  cfg.Redactor,
+		cassette:  &Cassette{Version: Version},
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	if len(r.matchers) == 0 {
+		r.matchers = DefaultMatchers()
+	}
+
+	switch cfg.Mode {
+	case ModeReplay:
+		c, err := Load(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	case ModeRecord:
+	default:
+		return nil, fmt.Errorf("cassette: unknown mode %v", cfg.Mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode of r.
+func (r *Recorder) Mode() Mode { return r.mode }
+
+// Interactions returns a copy of the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing
+// in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip records or replays req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: reading request body: %w", err)
+	}
+	recorded := r.recordRequest(req, body)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded, body)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+	res, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: reading response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	var saved Body
+	if len(resBody) > 0 {
+		saved = r.redactor.RedactBodyFor(req, resBody)
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Headers:    r.redactor.RedactHeaders(res.Header),
+			Body:       saved,
+		},
+	})
+	r.mu.Unlock()
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, in := range r.cassette.Interactions {
+		if !r.matches(recorded, in.Request) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return newResponse(req, in.Response), nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return newResponse(req, r.cassette.Interactions[last].Response), nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+func (r *Recorder) matches(req, recorded Request) bool {
+	for _, m := range r.matchers {
+		if !m(req, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordRequest returns req as recorded, without URL credentials and with its
+// headers and body scrubbed.
+func (r *Recorder) recordRequest(req *http.Request, body []byte) Request {
+	u := *req.URL
+	u.User = nil
+	headers := req.Header.Clone()
+	headers.Del("Content-Encoding")
+	rec := Request{
+		Method:  req.Method,
+		URL:     u.String(),
+		Headers: r.redactor.RedactHeaders(headers),
+	}
+	if len(body) > 0 {
+		rec.Body = r.redactor.RedactBodyFor(req, decodeBody(req.Header, body))
+	}
+	return rec
+}
+
+// readRequestBody reads and closes the body of req, if any.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// decodeBody returns body decompressed when it is gzip encoded, as by
+// Config.CompressRequestBody, so it is recorded and matched as sent.
+func decodeBody(h http.Header, body []byte) []byte {
+	if !strings.EqualFold(h.Get("Content-Encoding"), "gzip") {
+		return body
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return body
+	}
+	defer zr.Close()
+	plain, err := io.ReadAll(zr)
+	if err != nil {
+		return body
+	}
+	return plain
+}
+
+func newResponse(req *http.Request, rec Response) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}
+}
@@ -304,7 +304,7 @@ func TestLogRoundTrip_Coverage(t *testing.T) {
 		c := &Transport{logger: ml}
 
 		req, _ := http.NewRequest(http.MethodGet, "http://localhost:9200/_search", nil)
-		c.logRoundTrip(req, nil, fmt.Errorf("connection refused"), time.Now(), time.Millisecond)
+		c.logRoundTrip(RoundTrip{Request: req, Err: fmt.Errorf("connection refused"), Start: time.Now(), Duration: time.Millisecond})
 
 		require.True(t, ml.called)
 		require.Error(t, ml.lastErr)
@@ -320,7 +320,7 @@ func TestLogRoundTrip_Coverage(t *testing.T) {
 			StatusCode: http.StatusOK,
 			Body:       io.NopCloser(strings.NewReader(`{"hits":{}}`)),
 		}
-		c.logRoundTrip(req, res, nil, time.Now(), time.Millisecond)
+		c.logRoundTrip(RoundTrip{Request: req, Response: res, Start: time.Now(), Duration: time.Millisecond})
 
 		require.True(t, ml.called)
 		// Original body should still be readable after duplicateBody
@@ -336,7 +336,7 @@ func TestLogRoundTrip_Coverage(t *testing.T) {
 
 		req, _ := http.NewRequest(http.MethodGet, "http://localhost:9200/", nil)
 		res := &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
-		c.logRoundTrip(req, res, nil, time.Now(), time.Millisecond)
+		c.logRoundTrip(RoundTrip{Request: req, Response: res, Start: time.Now(), Duration: time.Millisecond})
 
 		require.True(t, ml.called)
 	})
@@ -0,0 +1,382 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/apiutil"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// defaultTimestampField is the data stream timestamp field used when
+// DataStreamManagerConfig.TimestampField is empty.
+const defaultTimestampField = "@timestamp"
+
+// DataStreamManagerConfig represents configuration of the data stream manager.
+type DataStreamManagerConfig struct {
+	// Client is used for every request. When nil, a default client is created.
+	Client *opensearchapi.Client
+
+	// Name is the data stream name. Required.
+	Name string
+
+	// TemplateName is the index template backing the data stream. Defaults
+	// to Name.
+	TemplateName string
+
+	// IndexPatterns are the template's index patterns. Defaults to Name.
+	IndexPatterns []string
+
+	// TimestampField is the data stream timestamp field. Defaults to
+	// "@timestamp".
+	TimestampField string
+
+	// Priority is the template priority, which must exceed that of any other
+	// template matching the same patterns.
+	Priority int
+
+	// Template holds the settings, mappings, and aliases applied to every
+	// backing index.
+	Template *opensearchapi.IndicesPutIndexTemplateIndexTemplateMapping
+
+	// Rollover holds the conditions evaluated by Rollover.
+	Rollover RolloverConditions
+
+	// Retention is the maximum age of a backing index before Expire deletes
+	// it. Zero disables expiry.
+	Retention time.Duration
+}
+
+// RolloverConditions trigger a rollover of the write index when any one of
+// them is met. Zero values disable the corresponding condition. Sizes and
+// document counts are those of the primary shards, as on the server.
+type RolloverConditions struct {
+	MaxAge       time.Duration
+	MaxDocs      int64
+	MaxSizeBytes int64
+}
+
+// Rollover condition names, as reported by the rollover API.
+const (
+	RolloverConditionMaxAge  = "max_age"
+	RolloverConditionMaxDocs = "max_docs"
+	RolloverConditionMaxSize = "max_size"
+)
+
+// BackingIndex describes one backing index of a data stream.
+type BackingIndex struct {
+	Name       string
+	Generation int64
+	CreatedAt  time.Time
+	Age        time.Duration
+
+	// Docs and SizeBytes cover primary shards; TotalSizeBytes includes
+	// replicas.
+	Docs           int64
+	SizeBytes      int64
+	TotalSizeBytes int64
+
+	WriteIndex bool
+}
+
+// RolloverResult reports the outcome of Rollover.
+type RolloverResult struct {
+	// WriteIndex is the write index the conditions were evaluated against.
+	WriteIndex string
+
+	// Met lists the conditions met by WriteIndex on the client side. When it
+	// is empty, no rollover request was sent.
+	Met []string
+
+	// Response is the server's rollover response, nil when no request was
+	// sent.
+	Response *opensearchapi.IndicesRolloverResp
+}
+
+// RolledOver reports whether the server created a new write index.
+func (r *RolloverResult) RolledOver() bool {
+	return r.Response != nil && r.Response.RolledOver
+}
+
+// DataStreamManager manages the lifecycle of one data stream on clusters
+// without Index State Management: it creates the template and the stream,
+// rolls the write index over on size, document count, or age, and deletes
+// expired backing indices. Call its methods periodically, e.g. from a ticker.
+type DataStreamManager struct {
+	client *opensearchapi.Client
+	config DataStreamManagerConfig
+}
+
+// NewDataStreamManager creates a new data stream manager.
+func NewDataStreamManager(cfg DataStreamManagerConfig) (*DataStreamManager, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("opensearchutil: data stream manager requires a name")
+	}
+	if cfg.Client == nil {
+		var err error
+		cfg.Client, err = opensearchapi.NewDefaultClient()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.TemplateName == "" {
+		cfg.TemplateName = cfg.Name
+	}
+	if len(cfg.IndexPatterns) == 0 {
+		cfg.IndexPatterns = []string{cfg.Name}
+	}
+	if cfg.TimestampField == "" {
+		cfg.TimestampField = defaultTimestampField
+	}
+	return &DataStreamManager{client: cfg.Client, config: cfg}, nil
+}
+
+// Ensure creates the index template and the data stream when they do not
+// exist, and returns the data stream. An existing template is left as is,
+// but one without data streams enabled is reported as an error.
+func (m *DataStreamManager) Ensure(ctx context.Context) (*opensearchapi.IndicesDataStream, error) {
+	if err := m.ensureTemplate(ctx); err != nil {
+		return nil, err
+	}
+
+	stream, err := m.dataStream(ctx)
+	if err == nil {
+		return stream, nil
+	}
+	if !errors.Is(err, errDataStreamMissing) {
+		return nil, err
+	}
+	if _, err := m.client.Indices.CreateDataStream(ctx, opensearchapi.IndicesCreateDataStreamReq{Name: m.config.Name}); err != nil {
+		// Another instance may have created it in the meantime.
+		if !errors.Is(err, opensearch.ErrResourceAlreadyExists) {
+			return nil, err
+		}
+	}
+	return m.dataStream(ctx)
+}
+
+func (m *DataStreamManager) ensureTemplate(ctx context.Context) error {
+	name := m.config.TemplateName
+	resp, err := m.client.Indices.GetIndexTemplate(ctx, opensearchapi.IndicesGetIndexTemplateReq{Name: name})
+	if err == nil {
+		for _, item := range resp.IndexTemplates {
+			if item.Name != name {
+				continue
+			}
+			if item.IndexTemplate.DataStream == nil {
+				return fmt.Errorf("opensearchutil: index template %s exists without data_stream enabled", name)
+			}
+			return nil
+		}
+	} else if apiutil.StatusCode(resp) != http.StatusNotFound {
+		return err
+	}
+
+	_, err = m.client.Indices.PutIndexTemplate(ctx, opensearchapi.IndicesPutIndexTemplateReq{
+		Name: name,
+		Body: &opensearchapi.IndicesPutIndexTemplateBody{
+			IndexPatterns: m.config.IndexPatterns,
+			DataStream: &opensearchapi.IndicesIndexTemplateDataStreamConfiguration{
+				TimestampField: &opensearchapi.IndicesDataStreamTimestampField{Name: m.config.TimestampField},
+			},
+			Priority: opensearch.ToPointer(m.config.Priority),
+			Template: m.config.Template,
+		},
+	})
+	return err
+}
+
+var errDataStreamMissing = errors.New("opensearchutil: data stream does not exist")
+
+func (m *DataStreamManager) dataStream(ctx context.Context) (*opensearchapi.IndicesDataStream, error) {
+	resp, err := m.client.Indices.GetDataStream(ctx, &opensearchapi.IndicesGetDataStreamReq{Name: []string{m.config.Name}})
+	if err != nil {
+		if apiutil.StatusCode(resp) == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", errDataStreamMissing, m.config.Name)
+		}
+		return nil, err
+	}
+	for i := range resp.DataStreams {
+		if resp.DataStreams[i].Name == m.config.Name {
+			return &resp.DataStreams[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errDataStreamMissing, m.config.Name)
+}
+
+// BackingIndices lists the backing indices of the data stream, oldest first,
+// with their creation time from the index settings and their size and
+// document count from Indices.Stats. The last one is the write index.
+func (m *DataStreamManager) BackingIndices(ctx context.Context) ([]BackingIndex, error) {
+	stream, err := m.dataStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(stream.Indices) == 0 {
+		return nil, nil
+	}
+	names := make([]string, len(stream.Indices))
+	for i, idx := range stream.Indices {
+		names[i] = idx.IndexName
+	}
+
+	stats, err := m.client.Indices.Stats(ctx, &opensearchapi.IndicesStatsReq{
+		Indices: names,
+		Metric:  []string{"docs", "store"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	settings, err := m.client.Indices.GetSettings(ctx, &opensearchapi.IndicesGetSettingsReq{
+		Indices: names,
+		Name:    []string{"index.creation_date"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	out := make([]BackingIndex, len(names))
+	for i, name := range names {
+		b := BackingIndex{
+			Name:       name,
+			Generation: backingGeneration(name, i),
+			WriteIndex: i == len(names)-1,
+		}
+		if s, ok := stats.Indices[name]; ok {
+			if s.Primaries.Docs != nil {
+				b.Docs = s.Primaries.Docs.Count
+			}
+			if s.Primaries.Store != nil {
+				b.SizeBytes = s.Primaries.Store.SizeInBytes
+			}
+			if s.Total.Store != nil {
+				b.TotalSizeBytes = s.Total.Store.SizeInBytes
+			}
+		}
+		if created, ok := creationDate(settings.Entries[name]); ok {
+			b.CreatedAt = created
+			b.Age = now.Sub(created)
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+// backingGeneration parses the generation suffix of a backing index name
+// such as ".ds-logs-000002", falling back to the index's position.
+func backingGeneration(name string, pos int) int64 {
+	if i := strings.LastIndexByte(name, '-'); i >= 0 {
+		if n, err := strconv.ParseInt(name[i+1:], 10, 64); err == nil {
+			return n
+		}
+	}
+	return int64(pos + 1)
+}
+
+func creationDate(state opensearchapi.IndicesIndexState) (time.Time, bool) {
+	s := state.Settings
+	if s == nil {
+		return time.Time{}, false
+	}
+	if s.Index != nil {
+		s = s.Index
+	}
+	if s.CreationDate == nil {
+		return time.Time{}, false
+	}
+	ms, err := s.CreationDate.Int64()
+	if err != nil {
+		str, serr := s.CreationDate.String()
+		if serr != nil {
+			return time.Time{}, false
+		}
+		if ms, err = strconv.ParseInt(str, 10, 64); err != nil {
+			return time.Time{}, false
+		}
+	}
+	return time.UnixMilli(ms), true
+}
+
+// Rollover evaluates the configured RolloverConditions against the write
+// index and, when at least one is met, sends a rollover request carrying the
+// met conditions so the server re-checks them against its own view. With
+// dryRun the request only reports what the server would do. When no
+// condition is met client-side, no request is sent.
+func (m *DataStreamManager) Rollover(ctx context.Context, dryRun bool) (*RolloverResult, error) {
+	indices, err := m.BackingIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("opensearchutil: data stream %s has no write index", m.config.Name)
+	}
+	write := indices[len(indices)-1]
+	result := &RolloverResult{WriteIndex: write.Name}
+
+	c := m.config.Rollover
+	conditions := &opensearchapi.IndicesRolloverConditions{}
+	if c.MaxAge > 0 && write.Age >= c.MaxAge {
+		result.Met = append(result.Met, RolloverConditionMaxAge)
+		conditions.MaxAge = opensearch.ToPointer(apiutil.FormatDuration(c.MaxAge))
+	}
+	if c.MaxDocs > 0 && write.Docs >= c.MaxDocs {
+		result.Met = append(result.Met, RolloverConditionMaxDocs)
+		conditions.MaxDocs = opensearch.ToPointer(c.MaxDocs)
+	}
+	if c.MaxSizeBytes > 0 && write.SizeBytes >= c.MaxSizeBytes {
+		result.Met = append(result.Met, RolloverConditionMaxSize)
+		conditions.MaxSize = opensearch.ToPointer(strconv.FormatInt(c.MaxSizeBytes, 10) + "b")
+	}
+	if len(result.Met) == 0 {
+		return result, nil
+	}
+
+	resp, err := m.client.Indices.Rollover(ctx, opensearchapi.IndicesRolloverReq{
+		Alias:  m.config.Name,
+		Body:   &opensearchapi.IndicesRolloverBody{Conditions: conditions},
+		Params: &opensearchapi.IndicesRolloverParams{DryRun: opensearch.ToPointer(dryRun)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Response = resp
+	return result, nil
+}
+
+// Expire deletes the backing indices older than the configured Retention and
+// returns their names, oldest first. The write index is never deleted. It
+// stops at the first failed delete.
+func (m *DataStreamManager) Expire(ctx context.Context) ([]string, error) {
+	if m.config.Retention <= 0 {
+		return nil, nil
+	}
+	indices, err := m.BackingIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, b := range indices {
+		if b.WriteIndex || b.CreatedAt.IsZero() || b.Age <= m.config.Retention {
+			continue
+		}
+		if _, err := m.client.Indices.Delete(ctx, &opensearchapi.IndicesDeleteReq{Indices: []string{b.Name}}); err != nil {
+			return deleted, fmt.Errorf("opensearchutil: deleting backing index %s: %w", b.Name, err)
+		}
+		deleted = append(deleted, b.Name)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package opensearchutil
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+func newDataStreamTestManager(t *testing.T, h http.HandlerFunc, cfg DataStreamManagerConfig) *DataStreamManager {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{ts.URL}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	cfg.Client = client
+	if cfg.Name == "" {
+		cfg.Name = "logs"
+	}
+	m, err := NewDataStreamManager(cfg)
+	require.NoError(t, err)
+	return m
+}
+
+// dataStreamStub serves a data stream "logs" with two backing indices: one
+// created ten days ago and a write index created age ago holding docs
+// documents.
+func dataStreamStub(log *requestLog, age time.Duration, docs int64) http.HandlerFunc {
+	now := time.Now()
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch log.add(r) {
+		case "GET /_data_stream/logs":
+			_, _ = io.WriteString(w, `{"data_streams":[{"name":"logs","generation":2,"status":"GREEN","template":"logs",`+
+				`"timestamp_field":{"name":"@timestamp"},"indices":[`+
+				`{"index_name":".ds-logs-000001","index_uuid":"a"},{"index_name":".ds-logs-000002","index_uuid":"b"}]}]}`)
+		case "GET /.ds-logs-000001,.ds-logs-000002/_stats/docs,store":
+			fmt.Fprintf(w, `{"_shards":{"total":4,"successful":4,"failed":0},"_all":{},"indices":{`+
+				`".ds-logs-000001":{"uuid":"a","primaries":{"docs":{"count":500},"store":{"size_in_bytes":4000}},"total":{"store":{"size_in_bytes":8000}}},`+
+				`".ds-logs-000002":{"uuid":"b","primaries":{"docs":{"count":%d},"store":{"size_in_bytes":100}},"total":{"store":{"size_in_bytes":200}}}}}`, docs)
+		case "GET /.ds-logs-000001,.ds-logs-000002/_settings/index.creation_date":
+			fmt.Fprintf(w, `{".ds-logs-000001":{"settings":{"index":{"creation_date":"%d"}}},`+
+				`".ds-logs-000002":{"settings":{"index":{"creation_date":"%d"}}}}`,
+				now.Add(-10*24*time.Hour).UnixMilli(), now.Add(-age).UnixMilli())
+		case "POST /logs/_rollover":
+			_, _ = io.WriteString(w, `{"acknowledged":true,"rolled_over":true,"dry_run":true,`+
+				`"old_index":".ds-logs-000002","new_index":".ds-logs-000003","conditions":{"[max_docs: 1000]":true}}`)
+		case "DELETE /.ds-logs-000001":
+			_, _ = io.WriteString(w, `{"acknowledged":true}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}
+}
+
+func TestDataStreamManagerEnsure(t *testing.T) {
+	t.Parallel()
+
+	var (
+		log      requestLog
+		mu       sync.Mutex
+		template string
+		created  bool
+	)
+	m := newDataStreamTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch log.add(r) {
+		case "GET /_index_template/logs":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"type":"resource_not_found_exception","reason":"missing"},"status":404}`)
+		case "PUT /_index_template/logs", "POST /_index_template/logs":
+			b, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			template = string(b)
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{"acknowledged":true}`)
+		case "PUT /_data_stream/logs":
+			mu.Lock()
+			created = true
+			mu.Unlock()
+			_, _ = io.WriteString(w, `{"acknowledged":true}`)
+		case "GET /_data_stream/logs":
+			mu.Lock()
+			exists := created
+			mu.Unlock()
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"error":{"type":"index_not_found_exception","reason":"no such index [logs]"},"status":404}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"data_streams":[{"name":"logs","generation":1,"status":"GREEN","template":"logs",`+
+				`"timestamp_field":{"name":"ts"},"indices":[{"index_name":".ds-logs-000001","index_uuid":"a"}]}]}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}, DataStreamManagerConfig{TimestampField: "ts", Priority: 200})
+
+	stream, err := m.Ensure(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stream.Generation)
+	require.JSONEq(t, `{"index_patterns":["logs"],"data_stream":{"timestamp_field":{"name":"ts"}},"priority":200}`, template)
+	require.Equal(t, 1, log.count("PUT /_data_stream/logs"))
+}
+
+func TestDataStreamManagerEnsureRejectsPlainTemplate(t *testing.T) {
+	t.Parallel()
+
+	m := newDataStreamTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"index_templates":[{"name":"logs","index_template":{"index_patterns":["logs"]}}]}`)
+	}, DataStreamManagerConfig{})
+
+	_, err := m.Ensure(t.Context())
+	require.ErrorContains(t, err, "without data_stream enabled")
+}
+
+func TestDataStreamManagerBackingIndices(t *testing.T) {
+	t.Parallel()
+
+	var log requestLog
+	m := newDataStreamTestManager(t, dataStreamStub(&log, time.Hour, 42), DataStreamManagerConfig{})
+
+	indices, err := m.BackingIndices(t.Context())
+	require.NoError(t, err)
+	require.Len(t, indices, 2)
+
+	old := indices[0]
+	require.Equal(t, ".ds-logs-000001", old.Name)
+	require.Equal(t, int64(1), old.Generation)
+	require.Equal(t, int64(500), old.Docs)
+	require.Equal(t, int64(4000), old.SizeBytes)
+	require.Equal(t, int64(8000), old.TotalSizeBytes)
+	require.InDelta(t, (10 * 24 * time.Hour).Hours(), old.Age.Hours(), 0.1)
+	require.False(t, old.WriteIndex)
+
+	write := indices[1]
+	require.Equal(t, int64(2), write.Generation)
+	require.Equal(t, int64(42), write.Docs)
+	require.True(t, write.WriteIndex)
+}
+
+func TestDataStreamManagerRollover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no condition met sends no request", func(t *testing.T) {
+		t.Parallel()
+		var log requestLog
+		m := newDataStreamTestManager(t, dataStreamStub(&log, time.Hour, 42), DataStreamManagerConfig{
+			Rollover: RolloverConditions{MaxDocs: 1000, MaxAge: 24 * time.Hour},
+		})
+
+		result, err := m.Rollover(t.Context(), true)
+		require.NoError(t, err)
+		require.Equal(t, ".ds-logs-000002", result.WriteIndex)
+		require.Empty(t, result.Met)
+		require.False(t, result.RolledOver())
+		require.Zero(t, log.count("POST /logs/_rollover"))
+	})
+
+	t.Run("met conditions are sent with dry_run", func(t *testing.T) {
+		t.Parallel()
+		var (
+			log  requestLog
+			body string
+			dry  string
+		)
+		stub := dataStreamStub(&log, 2*time.Hour, 1500)
+		m := newDataStreamTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/logs/_rollover" {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+				dry = r.URL.Query().Get("dry_run")
+			}
+			stub(w, r)
+		}, DataStreamManagerConfig{
+			Rollover: RolloverConditions{MaxDocs: 1000, MaxAge: time.Hour, MaxSizeBytes: 1 << 30},
+		})
+
+		result, err := m.Rollover(t.Context(), true)
+		require.NoError(t, err)
+		require.Equal(t, []string{RolloverConditionMaxAge, RolloverConditionMaxDocs}, result.Met)
+		require.True(t, result.RolledOver())
+		require.Equal(t, ".ds-logs-000003", result.Response.NewIndex)
+		require.Equal(t, "true", dry)
+		require.JSONEq(t, `{"conditions":{"max_age":"3600000ms","max_docs":1000}}`, body)
+	})
+}
+
+func TestDataStreamManagerExpire(t *testing.T) {
+	t.Parallel()
+
+	var log requestLog
+	m := newDataStreamTestManager(t, dataStreamStub(&log, 20*24*time.Hour, 42), DataStreamManagerConfig{
+		Retention: 7 * 24 * time.Hour,
+	})
+
+	deleted, err := m.Expire(t.Context())
+	require.NoError(t, err)
+	// The write index is older than the retention too, but is kept.
+	require.Equal(t, []string{".ds-logs-000001"}, deleted)
+	require.Zero(t, log.count("DELETE /.ds-logs-000002"))
+}
@@ -0,0 +1,642 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package opensearchutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/apiutil"
+	"github.com/opensearch-project/opensearch-go/v5/internal/poll"
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/internal/recovery"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Defaults of SnapshotManagerConfig.
+const (
+	defaultSnapshotPollInterval = time.Second
+	defaultRestoreStartTimeout  = time.Minute
+)
+
+var (
+	// ErrSnapshotFailed is returned, wrapped, when a snapshot finishes in a
+	// state other than SUCCESS.
+	ErrSnapshotFailed = errors.New("opensearchutil: snapshot did not succeed")
+
+	// ErrRestoreNotStarted is returned, wrapped, when the indices Restore
+	// waits for do not all appear within RestoreStartTimeout.
+	ErrRestoreNotStarted = errors.New("opensearchutil: restored indices did not appear")
+)
+
+// SnapshotManagerConfig represents configuration of the snapshot manager.
+type SnapshotManagerConfig struct {
+	// Client is used for every request. When nil, a default client is created.
+	Client *opensearchapi.Client
+
+	// Repository is the name of the snapshot repository. Required.
+	Repository string
+
+	// RepositoryType and RepositorySettings describe the repository, e.g.
+	// "fs" with {"location": "/mnt/backups"}. When RepositoryType is set,
+	// EnsureRepository registers a missing repository and rejects an existing
+	// one of a different type. When empty, the repository must already exist.
+	RepositoryType     string
+	RepositorySettings map[string]any
+
+	// Retention is enforced by ApplyRetention.
+	Retention SnapshotRetention
+
+	// PollInterval is the pause between status polls while a snapshot or a
+	// restore is running. Defaults to 1s.
+	PollInterval time.Duration
+
+	// RestoreStartTimeout bounds how long Restore waits for the restored
+	// indices to appear after the restore is accepted. Defaults to 1m.
+	RestoreStartTimeout time.Duration
+
+	// OnProgress, when set, is called after every status poll of a running
+	// snapshot or restore, and once more when it finishes.
+	OnProgress func(SnapshotProgress)
+}
+
+// SnapshotRetention selects the snapshots ApplyRetention deletes. A snapshot
+// is deleted when it is beyond the MaxCount newest or older than MaxAge,
+// unless it is one of the MinCount newest. Zero values disable the
+// corresponding limit. Snapshots still in progress are never deleted.
+type SnapshotRetention struct {
+	// Prefix restricts retention to snapshots whose name starts with it, so
+	// several jobs can share a repository.
+	Prefix string
+
+	MaxCount int
+	MaxAge   time.Duration
+	MinCount int
+}
+
+// SnapshotPhase identifies the operation a SnapshotProgress reports on.
+type SnapshotPhase string
+
+// Snapshot manager phases.
+const (
+	SnapshotPhaseSnapshot SnapshotPhase = "snapshot"
+	SnapshotPhaseRestore  SnapshotPhase = "restore"
+)
+
+// SnapshotProgress is a point-in-time view of a running snapshot or restore.
+//
+// For a snapshot, BytesTotal counts only the files this snapshot has to copy
+// (the incremental size reported by _snapshot/_status); files already present
+// in the repository are not included. For a restore, BytesTotal is the size
+// of the shards being recovered.
+type SnapshotProgress struct {
+	Phase    SnapshotPhase
+	Snapshot string
+	// State is the snapshot state (IN_PROGRESS, SUCCESS, ...). It is empty
+	// during a restore.
+	State string
+
+	Shards      []SnapshotShardProgress
+	DoneShards  int
+	TotalShards int
+
+	BytesDone  int64
+	BytesTotal int64
+
+	Elapsed        time.Duration
+	BytesPerSecond float64
+	// ETA is the estimated time remaining at the current rate; zero when no
+	// bytes have been transferred yet or the operation is finished.
+	ETA  time.Duration
+	Done bool
+}
+
+// SnapshotShardProgress is the progress of a single shard.
+type SnapshotShardProgress struct {
+	Index      string
+	Shard      int
+	Node       string
+	Stage      string
+	BytesDone  int64
+	BytesTotal int64
+}
+
+// SnapshotRestoreOptions selects what Restore restores.
+type SnapshotRestoreOptions struct {
+	// Indices lists index names or wildcard patterns, matched against the
+	// indices in the snapshot as the server matches them: entries may be
+	// comma-separated, "*" matches any characters, and a "-" prefix
+	// excludes. Empty restores every index in the snapshot.
+	Indices []string
+
+	// RenamePattern and RenameReplacement rename restored indices. The
+	// replacement may refer to capture groups as $1, as on the server.
+	RenamePattern     string
+	RenameReplacement string
+
+	IncludeAliases     *bool
+	IncludeGlobalState *bool
+	Partial            *bool
+}
+
+// SnapshotManager runs snapshot and restore workflows against one repository.
+type SnapshotManager struct {
+	client *opensearchapi.Client
+	config SnapshotManagerConfig
+}
+
+// NewSnapshotManager creates a new snapshot manager.
+func NewSnapshotManager(cfg SnapshotManagerConfig) (*SnapshotManager, error) {
+	if cfg.Repository == "" {
+		return nil, errors.New("opensearchutil: snapshot manager requires a repository")
+	}
+	if cfg.Client == nil {
+		var err error
+		cfg.Client, err = opensearchapi.NewDefaultClient()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultSnapshotPollInterval
+	}
+	if cfg.RestoreStartTimeout <= 0 {
+		cfg.RestoreStartTimeout = defaultRestoreStartTimeout
+	}
+	return &SnapshotManager{client: cfg.Client, config: cfg}, nil
+}
+
+// EnsureRepository makes sure the configured repository exists and that
+// every node can access it. A missing repository is registered when
+// RepositoryType is configured.
+func (m *SnapshotManager) EnsureRepository(ctx context.Context) error {
+	repo := m.config.Repository
+	resp, err := m.client.Snapshot.GetRepository(ctx, &opensearchapi.SnapshotGetRepositoryReq{Repository: []string{repo}})
+	switch {
+	case err == nil:
+		existing := resp.Entries[repo]
+		if m.config.RepositoryType != "" && existing.Type != nil && *existing.Type != m.config.RepositoryType {
+			return fmt.Errorf("opensearchutil: repository %s has type %s, want %s", repo, *existing.Type, m.config.RepositoryType)
+		}
+	case apiutil.StatusCode(resp) == http.StatusNotFound:
+		if m.config.RepositoryType == "" {
+			return fmt.Errorf("opensearchutil: repository %s does not exist and no repository type is configured: %w", repo, err)
+		}
+		if err := m.createRepository(ctx); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	_, err = m.client.Snapshot.VerifyRepository(ctx, opensearchapi.SnapshotVerifyRepositoryReq{Repository: repo})
+	return err
+}
+
+func (m *SnapshotManager) createRepository(ctx context.Context) error {
+	body, err := json.Marshal(map[string]any{
+		"type":     m.config.RepositoryType,
+		"settings": m.config.RepositorySettings,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = m.client.Snapshot.CreateRepository(ctx, opensearchapi.SnapshotCreateRepositoryReq{
+		Repository: m.config.Repository,
+		BodyReader: bytes.NewReader(body),
+		Params:     &opensearchapi.SnapshotCreateRepositoryParams{Verify: opensearch.ToPointer(true)},
+	})
+	return err
+}
+
+// Snapshot starts a snapshot named name and waits for it to finish,
+// reporting progress to OnProgress. A nil body snapshots every index. It
+// returns the finished snapshot; when its state is not SUCCESS the error
+// wraps ErrSnapshotFailed.
+func (m *SnapshotManager) Snapshot(ctx context.Context, name string, body *opensearchapi.SnapshotCreateBody) (*opensearchapi.SnapshotInfo, error) {
+	_, err := m.client.Snapshot.Create(ctx, opensearchapi.SnapshotCreateReq{
+		Repository: m.config.Repository,
+		Snapshot:   name,
+		Body:       body,
+		Params:     &opensearchapi.SnapshotCreateParams{WaitForCompletion: opensearch.ToPointer(false)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	for {
+		resp, err := m.client.Snapshot.Status(ctx, opensearchapi.SnapshotStatusReq{
+			Repository: m.config.Repository,
+			Snapshot:   []string{name},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Snapshots) == 0 {
+			return nil, fmt.Errorf("opensearchutil: no status reported for snapshot %s", name)
+		}
+		progress := snapshotProgress(name, &resp.Snapshots[0], time.Since(start))
+		m.report(progress)
+		if progress.Done {
+			break
+		}
+		if err := poll.Sleep(ctx, m.config.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := m.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if state := ptr.Deref(info.State); state != "SUCCESS" {
+		return info, fmt.Errorf("%w: %s finished in state %s%s", ErrSnapshotFailed, name, state, ptr.ReasonSuffix(info.Reason))
+	}
+	return info, nil
+}
+
+func (m *SnapshotManager) get(ctx context.Context, name string) (*opensearchapi.SnapshotInfo, error) {
+	resp, err := m.client.Snapshot.Get(ctx, opensearchapi.SnapshotGetReq{
+		Repository: m.config.Repository,
+		Snapshot:   []string{name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Snapshots {
+		if ptr.Deref(resp.Snapshots[i].Snapshot) == name {
+			return &resp.Snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("opensearchutil: snapshot %s not found in repository %s", name, m.config.Repository)
+}
+
+// snapshotProgress summarizes one _snapshot/_status entry.
+func snapshotProgress(name string, status *opensearchapi.SnapshotStatus, elapsed time.Duration) SnapshotProgress {
+	p := SnapshotProgress{
+		Phase:    SnapshotPhaseSnapshot,
+		Snapshot: name,
+		State:    ptr.Deref(status.State),
+		Elapsed:  elapsed,
+	}
+	switch p.State {
+	case "INIT", "STARTED", "IN_PROGRESS":
+	default:
+		p.Done = true
+	}
+
+	for index, stats := range status.Indices {
+		for id, shard := range stats.Shards {
+			n, _ := strconv.Atoi(id)
+			sp := SnapshotShardProgress{
+				Index:      index,
+				Shard:      n,
+				Node:       ptr.Deref(shard.Node),
+				Stage:      string(shard.Stage),
+				BytesDone:  summarySize(shard.Stats.Processed),
+				BytesTotal: summarySize(shard.Stats.Incremental),
+			}
+			p.Shards = append(p.Shards, sp)
+			if shard.Stage == "DONE" {
+				p.DoneShards++
+			}
+		}
+	}
+	p.TotalShards = len(p.Shards)
+	sortShards(p.Shards)
+
+	if s := status.Stats; s != nil {
+		p.BytesDone = fileCountSize(s.Processed)
+		p.BytesTotal = fileCountSize(s.Incremental)
+	}
+	p.estimate()
+	return p
+}
+
+// ApplyRetention deletes the snapshots selected by the configured
+// SnapshotRetention, oldest first, and returns the names it deleted. It stops
+// at the first failed delete.
+func (m *SnapshotManager) ApplyRetention(ctx context.Context) ([]string, error) {
+	r := m.config.Retention
+	if r.MaxCount <= 0 && r.MaxAge <= 0 {
+		return nil, nil
+	}
+
+	resp, err := m.client.Snapshot.Get(ctx, opensearchapi.SnapshotGetReq{
+		Repository: m.config.Repository,
+		Snapshot:   []string{r.Prefix + "*"},
+	})
+	if err != nil {
+		if apiutil.StatusCode(resp) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	candidates := make([]opensearchapi.SnapshotInfo, 0, len(resp.Snapshots))
+	for _, s := range resp.Snapshots {
+		if ptr.Deref(s.State) == "IN_PROGRESS" || s.StartTimeInMillis == nil {
+			continue
+		}
+		candidates = append(candidates, s)
+	}
+	// Newest first.
+	sort.Slice(candidates, func(i, j int) bool {
+		return *candidates[i].StartTimeInMillis > *candidates[j].StartTimeInMillis
+	})
+
+	now := time.Now()
+	var expired []string
+	for i, s := range candidates {
+		if i < r.MinCount {
+			continue
+		}
+		tooMany := r.MaxCount > 0 && i >= r.MaxCount
+		tooOld := r.MaxAge > 0 && now.Sub(time.UnixMilli(*s.StartTimeInMillis)) > r.MaxAge
+		if tooMany || tooOld {
+			expired = append(expired, ptr.Deref(s.Snapshot))
+		}
+	}
+
+	var deleted []string
+	for i := len(expired) - 1; i >= 0; i-- {
+		if _, err := m.client.Snapshot.Delete(ctx, opensearchapi.SnapshotDeleteReq{
+			Repository: m.config.Repository,
+			Snapshot:   []string{expired[i]},
+		}); err != nil {
+			return deleted, fmt.Errorf("opensearchutil: deleting snapshot %s: %w", expired[i], err)
+		}
+		deleted = append(deleted, expired[i])
+	}
+	return deleted, nil
+}
+
+// Restore restores indices from snapshot and waits until every restored
+// shard has recovered, reporting progress to OnProgress. It returns the
+// names of the restored indices after renaming. The target indices must not
+// exist or must be closed.
+//
+// The restore is finished by the rule wait.SnapshotRestore applies: a target
+// whose recoveries no longer include a snapshot shard, as after its primary
+// relocated or its node restarted, counts as restored.
+func (m *SnapshotManager) Restore(ctx context.Context, snapshot string, opts SnapshotRestoreOptions) ([]string, error) {
+	info, err := m.get(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := restoreTargets(info.Indices, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("opensearchutil: no index in snapshot %s matches %v", snapshot, opts.Indices)
+	}
+
+	body := &opensearchapi.SnapshotRestoreBody{
+		Indices:            opts.Indices,
+		IncludeAliases:     opts.IncludeAliases,
+		IncludeGlobalState: opts.IncludeGlobalState,
+		Partial:            opts.Partial,
+	}
+	if opts.RenamePattern != "" {
+		body.RenamePattern = opensearch.ToPointer(opts.RenamePattern)
+		body.RenameReplacement = opensearch.ToPointer(opts.RenameReplacement)
+	}
+	if _, err := m.client.Snapshot.Restore(ctx, opensearchapi.SnapshotRestoreReq{
+		Repository: m.config.Repository,
+		Snapshot:   snapshot,
+		Body:       body,
+		Params:     &opensearchapi.SnapshotRestoreParams{WaitForCompletion: opensearch.ToPointer(false)},
+	}); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	for {
+		resp, err := m.client.Indices.Recovery(ctx, &opensearchapi.IndicesRecoveryReq{Indices: targets})
+		// Restored indices appear shortly after the restore is accepted;
+		// until they all have, a 404 or a missing index is not an error.
+		started := err == nil
+		if err != nil && apiutil.StatusCode(resp) != http.StatusNotFound {
+			return nil, err
+		}
+		if err == nil {
+			progress, missing := restoreProgress(snapshot, targets, resp, time.Since(start))
+			m.report(progress)
+			if progress.Done {
+				return targets, nil
+			}
+			started = !missing
+		}
+		if !started && time.Since(start) > m.config.RestoreStartTimeout {
+			return nil, fmt.Errorf("%w: %v after %s", ErrRestoreNotStarted, targets, m.config.RestoreStartTimeout)
+		}
+		if err := poll.Sleep(ctx, m.config.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// restoreTargets returns the post-rename names of the snapshot indices
+// selected by opts, as the server selects them.
+func restoreTargets(indices []string, opts SnapshotRestoreOptions) ([]string, error) {
+	var (
+		rename      *regexp.Regexp
+		replacement string
+	)
+	if opts.RenamePattern != "" {
+		var err error
+		if rename, err = regexp.Compile(opts.RenamePattern); err != nil {
+			return nil, fmt.Errorf("opensearchutil: invalid rename pattern: %w", err)
+		}
+		// The server uses Java replacement syntax ($1); Go would read "$1_x"
+		// as the group named "1_x".
+		replacement = javaGroupRef.ReplaceAllString(opts.RenameReplacement, "$${$1}")
+	}
+
+	var out []string
+	for _, index := range filterIndices(indices, opts.Indices) {
+		if rename != nil {
+			index = rename.ReplaceAllString(index, replacement)
+		}
+		out = append(out, index)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+var javaGroupRef = regexp.MustCompile(`\$(\d+)`)
+
+// filterIndices returns the indices in available that expressions select,
+// following the server's rules for snapshot index lists: an entry may be a
+// comma-separated list; "*" matches any run of characters, dots and dashes
+// included; "_all" and an empty list select everything; and a "-" prefix
+// removes the matches of that pattern from the ones selected before it, or
+// from every index when it comes first. An entry naming an index exactly is
+// taken literally, even when it starts with "-".
+func filterIndices(available []string, expressions []string) []string {
+	var exprs []string
+	for _, e := range expressions {
+		for part := range strings.SplitSeq(e, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				exprs = append(exprs, part)
+			}
+		}
+	}
+	if len(exprs) == 0 || (len(exprs) == 1 && exprs[0] == "_all") {
+		return available
+	}
+
+	selected := make(map[string]bool, len(available))
+	for i, expr := range exprs {
+		if slices.Contains(available, expr) {
+			selected[expr] = true
+			continue
+		}
+		include := true
+		switch expr[0] {
+		case '+':
+			expr = expr[1:]
+		case '-':
+			include = false
+			expr = expr[1:]
+			if i == 0 {
+				for _, index := range available {
+					selected[index] = true
+				}
+			}
+		}
+		for _, index := range available {
+			if simpleMatch(expr, index) {
+				selected[index] = include
+			}
+		}
+	}
+
+	var out []string
+	for _, index := range available {
+		if selected[index] {
+			out = append(out, index)
+		}
+	}
+	return out
+}
+
+// simpleMatch reports whether s matches pattern, in which "*" matches any
+// run of characters and every other character matches itself.
+func simpleMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return len(s) >= len(last) && strings.HasSuffix(s, last)
+}
+
+// restoreProgress summarizes the snapshot recoveries of the target indices,
+// and reports whether any target has no recovery yet. It is done by the
+// rule opensearchapi/wait applies, so a target left with no snapshot shard
+// to recover counts as restored.
+func restoreProgress(
+	snapshot string, targets []string, resp *opensearchapi.IndicesRecoveryResp, elapsed time.Duration,
+) (SnapshotProgress, bool) {
+	p := SnapshotProgress{
+		Phase:    SnapshotPhaseRestore,
+		Snapshot: snapshot,
+		Elapsed:  elapsed,
+	}
+	for _, index := range targets {
+		for _, shard := range resp.Entries[index].Shards {
+			if shard.Type != recovery.TypeSnapshot {
+				continue
+			}
+			size := shard.Index.Size
+			p.Shards = append(p.Shards, SnapshotShardProgress{
+				Index:      index,
+				Shard:      shard.ID,
+				Node:       ptr.Deref(shard.Target.Name),
+				Stage:      shard.Stage,
+				BytesDone:  size.RecoveredInBytes,
+				BytesTotal: size.TotalInBytes,
+			})
+			if shard.Stage == recovery.StageDone {
+				p.DoneShards++
+			}
+			p.BytesDone += size.RecoveredInBytes
+			p.BytesTotal += size.TotalInBytes
+		}
+	}
+	restore := recovery.ReadRestore(targets, resp)
+	p.TotalShards = len(p.Shards)
+	p.Done = restore.Done()
+	sortShards(p.Shards)
+	p.estimate()
+	return p, restore.Missing != ""
+}
+
+// estimate fills in the transfer rate and ETA.
+func (p *SnapshotProgress) estimate() {
+	if p.Elapsed <= 0 || p.BytesDone <= 0 {
+		return
+	}
+	p.BytesPerSecond = float64(p.BytesDone) / p.Elapsed.Seconds()
+	if remaining := p.BytesTotal - p.BytesDone; remaining > 0 && !p.Done {
+		p.ETA = time.Duration(float64(remaining) / p.BytesPerSecond * float64(time.Second))
+	}
+}
+
+func (m *SnapshotManager) report(p SnapshotProgress) {
+	if m.config.OnProgress != nil {
+		m.config.OnProgress(p)
+	}
+}
+
+func sortShards(shards []SnapshotShardProgress) {
+	sort.Slice(shards, func(i, j int) bool {
+		if shards[i].Index != shards[j].Index {
+			return shards[i].Index < shards[j].Index
+		}
+		return shards[i].Shard < shards[j].Shard
+	})
+}
+
+func summarySize(item *opensearchapi.SnapshotShardsStatsSummaryItem) int64 {
+	if item == nil || item.SizeInBytes == nil {
+		return 0
+	}
+	return *item.SizeInBytes
+}
+
+func fileCountSize(stats *opensearchapi.SnapshotFileCountStats) int64 {
+	if stats == nil || stats.SizeInBytes == nil {
+		return 0
+	}
+	return *stats.SizeInBytes
+}
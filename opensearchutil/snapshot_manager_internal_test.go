@@ -0,0 +1,378 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package opensearchutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+func newSnapshotTestManager(t *testing.T, h http.HandlerFunc, cfg SnapshotManagerConfig) *SnapshotManager {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{
+		Client: opensearch.Config{Addresses: []string{ts.URL}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	cfg.Client = client
+	if cfg.Repository == "" {
+		cfg.Repository = "backups"
+	}
+	cfg.PollInterval = time.Millisecond
+	m, err := NewSnapshotManager(cfg)
+	require.NoError(t, err)
+	return m
+}
+
+// requestLog records "METHOD /path" for every request a stub receives.
+type requestLog struct {
+	mu   sync.Mutex
+	seen []string
+}
+
+func (l *requestLog) add(r *http.Request) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := r.Method + " " + r.URL.Path
+	l.seen = append(l.seen, key)
+	return key
+}
+
+func (l *requestLog) count(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, k := range l.seen {
+		if k == key {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSnapshotManagerEnsureRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("registers a missing repository", func(t *testing.T) {
+		t.Parallel()
+		var log requestLog
+		var body string
+		m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+			switch log.add(r) {
+			case "GET /_snapshot/backups":
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"error":{"type":"repository_missing_exception","reason":"[backups] missing"},"status":404}`)
+			case "PUT /_snapshot/backups", "POST /_snapshot/backups":
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+				_, _ = io.WriteString(w, `{"acknowledged":true}`)
+			default:
+				_, _ = io.WriteString(w, `{"nodes":{}}`)
+			}
+		}, SnapshotManagerConfig{RepositoryType: "fs", RepositorySettings: map[string]any{"location": "/mnt/b"}})
+
+		require.NoError(t, m.EnsureRepository(t.Context()))
+		require.JSONEq(t, `{"type":"fs","settings":{"location":"/mnt/b"}}`, body)
+		require.Equal(t, 1, log.count("POST /_snapshot/backups/_verify"))
+	})
+
+	t.Run("rejects a repository of another type", func(t *testing.T) {
+		t.Parallel()
+		m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, `{"backups":{"type":"s3","settings":{}}}`)
+		}, SnapshotManagerConfig{RepositoryType: "fs"})
+
+		err := m.EnsureRepository(t.Context())
+		require.ErrorContains(t, err, "has type s3, want fs")
+	})
+
+	t.Run("missing repository without type", func(t *testing.T) {
+		t.Parallel()
+		m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"type":"repository_missing_exception","reason":"missing"},"status":404}`)
+		}, SnapshotManagerConfig{})
+
+		require.ErrorContains(t, m.EnsureRepository(t.Context()), "no repository type is configured")
+	})
+}
+
+func TestSnapshotManagerSnapshot(t *testing.T) {
+	t.Parallel()
+
+	statuses := []string{
+		`{"snapshots":[{"snapshot":"nightly","state":"STARTED",` +
+			`"stats":{"incremental":{"file_count":4,"size_in_bytes":1000},"processed":{"file_count":1,"size_in_bytes":250}},` +
+			`"indices":{"logs":{"shards":{` +
+			`"1":{"stage":"STARTED","node":"n1","stats":{"incremental":{"size_in_bytes":600},"processed":{"size_in_bytes":50}}},` +
+			`"0":{"stage":"DONE","node":"n2","stats":{"incremental":{"size_in_bytes":400},"processed":{"size_in_bytes":200}}}}}}}]}`,
+		`{"snapshots":[{"snapshot":"nightly","state":"SUCCESS",` +
+			`"stats":{"incremental":{"size_in_bytes":1000},"processed":{"size_in_bytes":1000}}}]}`,
+	}
+
+	var (
+		log      requestLog
+		mu       sync.Mutex
+		polls    int
+		progress []SnapshotProgress
+	)
+	m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch log.add(r) {
+		case "POST /_snapshot/backups/nightly":
+			if got := r.URL.Query().Get("wait_for_completion"); got != "false" {
+				t.Errorf("wait_for_completion = %q, want false", got)
+			}
+			_, _ = io.WriteString(w, `{"accepted":true}`)
+		case "GET /_snapshot/backups/nightly/_status":
+			mu.Lock()
+			body := statuses[min(polls, len(statuses)-1)]
+			polls++
+			mu.Unlock()
+			_, _ = io.WriteString(w, body)
+		case "GET /_snapshot/backups/nightly":
+			_, _ = io.WriteString(w, `{"snapshots":[{"snapshot":"nightly","state":"SUCCESS","indices":["logs"]}]}`)
+		}
+	}, SnapshotManagerConfig{OnProgress: func(p SnapshotProgress) { progress = append(progress, p) }})
+
+	info, err := m.Snapshot(t.Context(), "nightly", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"logs"}, info.Indices)
+
+	require.Len(t, progress, 2)
+	first := progress[0]
+	require.Equal(t, SnapshotPhaseSnapshot, first.Phase)
+	require.False(t, first.Done)
+	require.Equal(t, int64(250), first.BytesDone)
+	require.Equal(t, int64(1000), first.BytesTotal)
+	require.Equal(t, 1, first.DoneShards)
+	require.Equal(t, 2, first.TotalShards)
+	require.Equal(t, SnapshotShardProgress{Index: "logs", Shard: 1, Node: "n1", Stage: "STARTED", BytesDone: 50, BytesTotal: 600}, first.Shards[1])
+	require.Positive(t, first.BytesPerSecond)
+	require.Positive(t, first.ETA)
+
+	last := progress[1]
+	require.True(t, last.Done)
+	require.Zero(t, last.ETA)
+}
+
+func TestSnapshotManagerSnapshotFailed(t *testing.T) {
+	t.Parallel()
+
+	m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_status"):
+			_, _ = io.WriteString(w, `{"snapshots":[{"snapshot":"s","state":"FAILED"}]}`)
+		case r.Method == http.MethodGet:
+			_, _ = io.WriteString(w, `{"snapshots":[{"snapshot":"s","state":"FAILED","reason":"disk full"}]}`)
+		default:
+			_, _ = io.WriteString(w, `{"accepted":true}`)
+		}
+	}, SnapshotManagerConfig{})
+
+	info, err := m.Snapshot(t.Context(), "s", nil)
+	require.ErrorIs(t, err, ErrSnapshotFailed)
+	require.ErrorContains(t, err, "disk full")
+	require.NotNil(t, info)
+}
+
+func TestSnapshotManagerApplyRetention(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	day := 24 * time.Hour
+	snap := func(name string, age time.Duration, state string) string {
+		return fmt.Sprintf(`{"snapshot":%q,"state":%q,"start_time_in_millis":%d}`, name, state, now.Add(-age).UnixMilli())
+	}
+	list := `{"snapshots":[` + strings.Join([]string{
+		snap("job-1", 10*day, "SUCCESS"),
+		snap("job-2", 5*day, "SUCCESS"),
+		snap("job-3", 3*day, "PARTIAL"),
+		snap("job-4", 2*day, "SUCCESS"),
+		snap("job-5", time.Hour, "IN_PROGRESS"),
+	}, ",") + `]}`
+
+	var log requestLog
+	m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		log.add(r)
+		if r.Method == http.MethodGet && r.URL.Path == "/_snapshot/backups/job-*" {
+			_, _ = io.WriteString(w, list)
+			return
+		}
+		_, _ = io.WriteString(w, `{"acknowledged":true}`)
+	}, SnapshotManagerConfig{Retention: SnapshotRetention{Prefix: "job-", MaxCount: 3, MaxAge: 4 * day, MinCount: 1}})
+
+	deleted, err := m.ApplyRetention(t.Context())
+	require.NoError(t, err)
+	// job-1 exceeds MaxCount; job-2 exceeds MaxAge. The running job-5 is
+	// never considered.
+	require.Equal(t, []string{"job-1", "job-2"}, deleted)
+	require.Equal(t, 1, log.count("DELETE /_snapshot/backups/job-1"))
+	require.Zero(t, log.count("DELETE /_snapshot/backups/job-5"))
+}
+
+func TestSnapshotManagerRestore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		polls    int
+		restore  string
+		progress []SnapshotProgress
+	)
+	m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_snapshot/backups/nightly":
+			_, _ = io.WriteString(w, `{"snapshots":[{"snapshot":"nightly","state":"SUCCESS","indices":["logs-1","logs-2","users"]}]}`)
+		case r.URL.Path == "/_snapshot/backups/nightly/_restore":
+			b, _ := io.ReadAll(r.Body)
+			restore = string(b)
+			_, _ = io.WriteString(w, `{"accepted":true}`)
+		case r.URL.Path == "/restored-logs-1,restored-logs-2/_recovery":
+			mu.Lock()
+			polls++
+			n := polls
+			mu.Unlock()
+			switch n {
+			case 1:
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`)
+				return
+			case 2:
+				_, _ = io.WriteString(w, `{"restored-logs-1":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"INDEX",`+
+					`"index":{"size":{"total_in_bytes":100,"recovered_in_bytes":40}}}]},`+
+					`"restored-logs-2":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE",`+
+					`"index":{"size":{"total_in_bytes":100,"recovered_in_bytes":100}}}]}}`)
+			default:
+				_, _ = io.WriteString(w, `{"restored-logs-1":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE",`+
+					`"index":{"size":{"total_in_bytes":100,"recovered_in_bytes":100}}}]},`+
+					`"restored-logs-2":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE",`+
+					`"index":{"size":{"total_in_bytes":100,"recovered_in_bytes":100}}}]}}`)
+			}
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}, SnapshotManagerConfig{OnProgress: func(p SnapshotProgress) { progress = append(progress, p) }})
+
+	restored, err := m.Restore(t.Context(), "nightly", SnapshotRestoreOptions{
+		Indices:           []string{"logs-*"},
+		RenamePattern:     "(.+)",
+		RenameReplacement: "restored-$1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"restored-logs-1", "restored-logs-2"}, restored)
+	require.JSONEq(t, `{"indices":["logs-*"],"rename_pattern":"(.+)","rename_replacement":"restored-$1"}`, restore)
+
+	require.Len(t, progress, 2)
+	require.Equal(t, SnapshotPhaseRestore, progress[0].Phase)
+	require.Equal(t, int64(140), progress[0].BytesDone)
+	require.Equal(t, int64(200), progress[0].BytesTotal)
+	require.Equal(t, 1, progress[0].DoneShards)
+	require.True(t, progress[1].Done)
+}
+
+func TestSnapshotManagerRestoreWithoutSnapshotShards(t *testing.T) {
+	t.Parallel()
+
+	// The restored primary relocated before the first poll, so its only
+	// recovery is a peer recovery.
+	m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_snapshot/backups/nightly":
+			_, _ = io.WriteString(w, `{"snapshots":[{"snapshot":"nightly","state":"SUCCESS","indices":["logs-1"]}]}`)
+		case r.URL.Path == "/logs-1/_recovery":
+			_, _ = io.WriteString(w, `{"logs-1":{"shards":[{"id":0,"type":"PEER","stage":"DONE"}]}}`)
+		default:
+			_, _ = io.WriteString(w, `{"accepted":true}`)
+		}
+	}, SnapshotManagerConfig{})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	restored, err := m.Restore(ctx, "nightly", SnapshotRestoreOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"logs-1"}, restored)
+}
+
+func TestSnapshotManagerRestoreNotStarted(t *testing.T) {
+	t.Parallel()
+
+	m := newSnapshotTestManager(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_snapshot/backups/nightly":
+			_, _ = io.WriteString(w, `{"snapshots":[{"snapshot":"nightly","state":"SUCCESS","indices":["logs-1"]}]}`)
+		case strings.HasSuffix(r.URL.Path, "/_recovery"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`)
+		default:
+			_, _ = io.WriteString(w, `{"accepted":true}`)
+		}
+	}, SnapshotManagerConfig{RestoreStartTimeout: 20 * time.Millisecond})
+
+	_, err := m.Restore(t.Context(), "nightly", SnapshotRestoreOptions{})
+	require.ErrorIs(t, err, ErrRestoreNotStarted)
+	require.ErrorContains(t, err, "logs-1")
+}
+
+func TestFilterIndices(t *testing.T) {
+	t.Parallel()
+
+	available := []string{"logs-2024.01", "logs-2024.02", "metrics-a", "users", "-odd"}
+	tests := []struct {
+		name        string
+		expressions []string
+		want        []string
+	}{
+		{"empty selects all", nil, available},
+		{"_all selects all", []string{"_all"}, available},
+		{"star crosses dots", []string{"logs-*"}, []string{"logs-2024.01", "logs-2024.02"}},
+		{"star in the middle", []string{"logs*.02"}, []string{"logs-2024.02"}},
+		{"question mark is literal", []string{"user?"}, nil},
+		{"comma list", []string{"users,metrics-*"}, []string{"metrics-a", "users"}},
+		{"exclusion after inclusion", []string{"logs-*,-*.01"}, []string{"logs-2024.02"}},
+		{"leading exclusion starts from all", []string{"-logs-*", "-metrics-*"}, []string{"users", "-odd"}},
+		{"exact name wins over exclusion", []string{"-odd"}, []string{"-odd"}},
+		{"unknown name selects nothing", []string{"missing"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, filterIndices(available, tt.expressions))
+		})
+	}
+}
+
+func TestRestoreTargets(t *testing.T) {
+	t.Parallel()
+
+	got, err := restoreTargets([]string{"a-1", "b-1"}, SnapshotRestoreOptions{
+		RenamePattern:     `(\w)-(\d)`,
+		RenameReplacement: "$2_$1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1_a", "1_b"}, got)
+
+	_, err = restoreTargets([]string{"a"}, SnapshotRestoreOptions{RenamePattern: "("})
+	require.Error(t, err)
+}
@@ -7,11 +7,13 @@
 package osotel
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
 
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
 )
@@ -51,3 +53,27 @@ func BenchmarkOnRequestResponse(b *testing.B) {
 		reg.OnRequestResponse(ctx, ev)
 	}
 }
+
+// BenchmarkTracingObserver measures the per-request cost of the tracing hooks
+// for a request with one attempt. With a no-op tracer the allocations are the
+// tracer's own context derivations; the observer adds none.
+func BenchmarkTracingObserver(b *testing.B) {
+	obs := NewTracingObserver(WithTracerProvider(noop.NewTracerProvider()))
+	req, err := http.NewRequest(http.MethodGet, "http://node-1:9200/logs/_search", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	start := opensearchtransport.RequestEvent{Method: http.MethodGet, RouteName: "search", Index: "logs"}
+	ev := opensearchtransport.RequestResponseEvent{
+		ResponseEvent: opensearchtransport.ResponseEvent{Request: start, StatusCode: http.StatusOK},
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		ctx := obs.OnRequestStart(context.Background(), start)
+		attemptCtx := obs.OnAttemptStart(ctx, 0)
+		obs.OnAttemptRequest(attemptCtx, req)
+		obs.OnAttemptEnd(attemptCtx, 0, http.StatusOK, nil)
+		obs.OnRequestResponse(ctx, ev)
+	}
+}
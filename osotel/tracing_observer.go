@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package osotel
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// tracerName is the instrumentation scope of the spans TracingObserver records.
+const tracerName = "github.com/opensearch-project/opensearch-go/v5/osotel"
+
+// Span attribute keys, following the OpenTelemetry database and HTTP client
+// semantic conventions, and shared attribute values.
+const (
+	attrDBSystem       = "db.system"
+	attrDBOperation    = "db.operation"
+	attrDBCollection   = "db.collection.name"
+	attrDBPool         = "db.opensearch.pool"
+	attrHTTPMethod     = "http.request.method"
+	attrHTTPStatusCode = "http.response.status_code"
+	attrResendCount    = "http.request.resend_count"
+	attrURLPath        = "url.path"
+	attrServerAddress  = "server.address"
+	attrServerPort     = "server.port"
+
+	dbSystemOpenSearch = "opensearch"
+
+	// attemptSpanName names an attempt span until OnAttemptRequest renames it
+	// to the request method, as the HTTP client conventions do for a method
+	// they cannot name.
+	attemptSpanName = "HTTP"
+)
+
+// TracingObserver records OpenSearch client requests as OpenTelemetry spans: a
+// client span per logical request, named by its route (the db.operation, such
+// as "search" or "bulk"), and a child client span per round-trip attempt, named
+// by the HTTP method. The request span carries db.system, db.operation, the
+// target index as db.collection.name, the node, the pool, the retry count as
+// http.request.resend_count, and the final status; each attempt span carries
+// its node and status. Each attempt's trace context is injected into its
+// outgoing headers (traceparent by default), so server-side traces join the
+// client's.
+//
+// Unlike [RequestObserver], TracingObserver is not a [Registry] sink: spans
+// must start and end on the request goroutine, so it is itself the
+// [opensearchtransport.ConnectionObserver] wired into the client. To record
+// metrics as well, wrap the Registry with [WithNext]. The request span of a
+// streaming request ends at time-to-first-byte, before the caller reads the
+// body.
+//
+// On the request hot path TracingObserver allocates only what the tracer and
+// propagator do: span attributes are set only on recording spans, and the
+// start options are built once.
+type TracingObserver struct {
+	// ConnectionObserver receives every hook after the span work; it is the
+	// observer set with [WithNext].
+	opensearchtransport.ConnectionObserver
+
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	startOpts  []trace.SpanStartOption
+}
+
+// Compile-time checks that TracingObserver implements ConnectionObserver and
+// the optional observer interfaces it forwards.
+var (
+	_ opensearchtransport.ConnectionObserver     = (*TracingObserver)(nil)
+	_ opensearchtransport.AttemptRequestObserver = (*TracingObserver)(nil)
+	_ opensearchtransport.CacheObserver          = (*TracingObserver)(nil)
+)
+
+// TracingOption configures a [TracingObserver].
+type TracingOption func(*tracingOptions)
+
+type tracingOptions struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	next       opensearchtransport.ConnectionObserver
+}
+
+// WithTracerProvider sets the provider the spans are recorded with. Defaults to
+// the global provider, [otel.GetTracerProvider].
+func WithTracerProvider(tp trace.TracerProvider) TracingOption {
+	return func(o *tracingOptions) { o.provider = tp }
+}
+
+// WithPropagator sets the propagator that injects each attempt's trace context
+// into its request headers. Defaults to W3C Trace Context
+// ([propagation.TraceContext]), which sets traceparent and tracestate; pass
+// [otel.GetTextMapPropagator] to use the globally configured one.
+func WithPropagator(p propagation.TextMapPropagator) TracingOption {
+	return func(o *tracingOptions) { o.propagator = p }
+}
+
+// WithNext sets the observer every hook is forwarded to, such as a [Registry],
+// so one client can record both traces and metrics.
+func WithNext(next opensearchtransport.ConnectionObserver) TracingOption {
+	return func(o *tracingOptions) { o.next = next }
+}
+
+// NewTracingObserver returns a TracingObserver with the given options. Wire it
+// as the client's Config.Observer.
+func NewTracingObserver(opts ...TracingOption) *TracingObserver {
+	cfg := tracingOptions{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.provider == nil {
+		cfg.provider = otel.GetTracerProvider()
+	}
+	if cfg.propagator == nil {
+		cfg.propagator = propagation.TraceContext{}
+	}
+	if cfg.next == nil {
+		cfg.next = opensearchtransport.BaseConnectionObserver{}
+	}
+	return &TracingObserver{
+		ConnectionObserver: cfg.next,
+		tracer:             cfg.provider.Tracer(tracerName),
+		propagator:         cfg.propagator,
+		startOpts:          []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindClient)},
+	}
+}
+
+// OnRequestStart implements [opensearchtransport.ConnectionObserver]. It starts
+// the request span and returns the context carrying it.
+func (o *TracingObserver) OnRequestStart(ctx context.Context, event opensearchtransport.RequestEvent) context.Context {
+	ctx, span := o.tracer.Start(ctx, event.RouteName, o.startOpts...)
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.String(attrDBSystem, dbSystemOpenSearch),
+			attribute.String(attrDBOperation, event.RouteName),
+			attribute.String(attrHTTPMethod, event.Method),
+			attribute.String(attrURLPath, event.Path),
+		)
+		if event.Index != "" {
+			span.SetAttributes(attribute.String(attrDBCollection, event.Index))
+		}
+	}
+	return o.ConnectionObserver.OnRequestStart(ctx, event)
+}
+
+// OnAttemptStart implements [opensearchtransport.ConnectionObserver]. It starts
+// an attempt span as a child of the request span.
+func (o *TracingObserver) OnAttemptStart(ctx context.Context, attempt int) context.Context {
+	ctx, span := o.tracer.Start(ctx, attemptSpanName, o.startOpts...)
+	if attempt > 0 && span.IsRecording() {
+		span.SetAttributes(attribute.Int(attrResendCount, attempt))
+	}
+	return o.ConnectionObserver.OnAttemptStart(ctx, attempt)
+}
+
+// OnAttemptRequest implements [opensearchtransport.AttemptRequestObserver]. It
+// names the attempt span by the request method, records the node, and injects
+// the attempt's trace context into the request headers.
+func (o *TracingObserver) OnAttemptRequest(ctx context.Context, req *http.Request) {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetName(req.Method)
+		span.SetAttributes(
+			attribute.String(attrHTTPMethod, req.Method),
+			attribute.String(attrServerAddress, req.URL.Hostname()),
+		)
+		if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+			span.SetAttributes(attribute.Int(attrServerPort, port))
+		}
+	}
+	o.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	if next, ok := o.ConnectionObserver.(opensearchtransport.AttemptRequestObserver); ok {
+		next.OnAttemptRequest(ctx, req)
+	}
+}
+
+// OnAttemptEnd implements [opensearchtransport.ConnectionObserver]. It ends the
+// attempt span with the attempt's status.
+func (o *TracingObserver) OnAttemptEnd(ctx context.Context, attempt int, statusCode int, err error) {
+	endSpan(trace.SpanFromContext(ctx), statusCode, err)
+	o.ConnectionObserver.OnAttemptEnd(ctx, attempt, statusCode, err)
+}
+
+// OnRequestResponse implements [opensearchtransport.ConnectionObserver]. It
+// ends the request span of a buffered request.
+func (o *TracingObserver) OnRequestResponse(ctx context.Context, event opensearchtransport.RequestResponseEvent) {
+	endRequestSpan(trace.SpanFromContext(ctx), &event.ResponseEvent)
+	o.ConnectionObserver.OnRequestResponse(ctx, event)
+}
+
+// OnStreamResponse implements [opensearchtransport.ConnectionObserver]. It ends
+// the request span of a streaming request.
+func (o *TracingObserver) OnStreamResponse(ctx context.Context, event opensearchtransport.StreamResponseEvent) {
+	endRequestSpan(trace.SpanFromContext(ctx), &event.ResponseEvent)
+	o.ConnectionObserver.OnStreamResponse(ctx, event)
+}
+
+// OnCacheHit implements [opensearchtransport.CacheObserver]. A cache hit
+// sends no request, so it records no span; the event is only forwarded.
+func (o *TracingObserver) OnCacheHit(ctx context.Context, event opensearchtransport.CacheHitEvent) {
+	if next, ok := o.ConnectionObserver.(opensearchtransport.CacheObserver); ok {
+		next.OnCacheHit(ctx, event)
+	}
+}
+
+// endRequestSpan records the node, pool, and retry count of the final attempt
+// on the request span, then ends it.
+func endRequestSpan(span trace.Span, e *opensearchtransport.ResponseEvent) {
+	if span.IsRecording() {
+		span.SetAttributes(attribute.Int(attrResendCount, e.Request.Attempt))
+		if e.Request.PoolName != "" {
+			span.SetAttributes(attribute.String(attrDBPool, e.Request.PoolName))
+		}
+		if e.Request.Host != "" {
+			setServer(span, e.Request.Host)
+		}
+	}
+	endSpan(span, e.StatusCode, e.Err)
+}
+
+// endSpan records statusCode and err on span and ends it. A transport error or
+// a 4xx/5xx status marks the span as failed, as for any HTTP client span.
+func endSpan(span trace.Span, statusCode int, err error) {
+	if span.IsRecording() {
+		if statusCode > 0 {
+			span.SetAttributes(attribute.Int(attrHTTPStatusCode, statusCode))
+		}
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case statusCode >= http.StatusBadRequest:
+			span.SetStatus(codes.Error, "")
+		}
+	}
+	span.End()
+}
+
+// setServer records the server.address and server.port of host, a
+// "scheme://host:port" string as reported in RequestEvent.Host.
+func setServer(span trace.Span, host string) {
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	addr, port, err := net.SplitHostPort(host)
+	if err != nil {
+		span.SetAttributes(attribute.String(attrServerAddress, host))
+		return
+	}
+	span.SetAttributes(attribute.String(attrServerAddress, addr))
+	if n, err := strconv.Atoi(port); err == nil {
+		span.SetAttributes(attribute.Int(attrServerPort, n))
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package osotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
+)
+
+// newTestTracer returns a TracerProvider recording every span into the
+// returned recorder.
+func newTestTracer(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return tp, rec
+}
+
+// spanAttrs flattens the attributes of a span for assertions.
+func spanAttrs(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingObserverSpans(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		traceparent []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs/_search" {
+			// Health checks carry no attempt context.
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		mu.Lock()
+		traceparent = append(traceparent, r.Header.Get("Traceparent"))
+		n := len(traceparent)
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ts.Close)
+
+	tp, rec := newTestTracer(t)
+	u := mustURL(t, ts.URL)
+	transport, err := opensearchtransport.New(opensearchtransport.Config{
+		URLs:     []*url.URL{u},
+		Observer: NewTracingObserver(WithTracerProvider(tp)),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = transport.Close() })
+
+	req, err := http.NewRequest(http.MethodGet, "/logs/_search", nil)
+	require.NoError(t, err)
+	res, err := transport.Request(req)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 3, "two attempt spans and the request span")
+	first, retry, request := spans[0], spans[1], spans[2]
+
+	require.Equal(t, "search", request.Name())
+	require.Equal(t, trace.SpanKindClient, request.SpanKind())
+	attrs := spanAttrs(request)
+	require.Equal(t, "opensearch", attrs[attrDBSystem].AsString())
+	require.Equal(t, "search", attrs[attrDBOperation].AsString())
+	require.Equal(t, "logs", attrs[attrDBCollection].AsString())
+	require.Equal(t, u.Hostname(), attrs[attrServerAddress].AsString())
+	require.Equal(t, int64(1), attrs[attrResendCount].AsInt64())
+	require.Equal(t, int64(http.StatusOK), attrs[attrHTTPStatusCode].AsInt64())
+	require.Equal(t, codes.Unset, request.Status().Code)
+
+	for _, attempt := range []sdktrace.ReadOnlySpan{first, retry} {
+		require.Equal(t, http.MethodGet, attempt.Name())
+		require.Equal(t, request.SpanContext().SpanID(), attempt.Parent().SpanID(), "attempts are children of the request span")
+		require.Equal(t, u.Hostname(), spanAttrs(attempt)[attrServerAddress].AsString())
+	}
+	require.Equal(t, codes.Error, first.Status().Code, "the 502 attempt failed")
+	require.Equal(t, int64(http.StatusBadGateway), spanAttrs(first)[attrHTTPStatusCode].AsInt64())
+	require.Equal(t, int64(1), spanAttrs(retry)[attrResendCount].AsInt64())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, traceparent, 2)
+	for i, attempt := range []sdktrace.ReadOnlySpan{first, retry} {
+		sc := attempt.SpanContext()
+		require.Equal(t, "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01", traceparent[i],
+			"each attempt propagates its own span")
+	}
+}
+
+func TestTracingObserverTransportError(t *testing.T) {
+	tp, rec := newTestTracer(t)
+	obs := NewTracingObserver(WithTracerProvider(tp))
+
+	ctx := obs.OnRequestStart(context.Background(), opensearchtransport.RequestEvent{Method: http.MethodPost, RouteName: "bulk"})
+	attemptCtx := obs.OnAttemptStart(ctx, 0)
+	obs.OnAttemptEnd(attemptCtx, 0, 0, errors.New("connection refused"))
+	obs.OnRequestResponse(ctx, opensearchtransport.RequestResponseEvent{
+		ResponseEvent: opensearchtransport.ResponseEvent{
+			Request: opensearchtransport.RequestEvent{Method: http.MethodPost, RouteName: "bulk"},
+			Err:     errors.New("connection refused"),
+		},
+	})
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+	for _, s := range spans {
+		require.Equal(t, codes.Error, s.Status().Code)
+		require.Equal(t, "connection refused", s.Status().Description)
+		require.NotContains(t, spanAttrs(s), attribute.Key(attrHTTPStatusCode), "no status without a response")
+	}
+}
+
+// TestTracingObserverForwards proves every hook reaches the WithNext observer
+// with the span-carrying context.
+func TestTracingObserverForwards(t *testing.T) {
+	tp, _ := newTestTracer(t)
+	next := &forwardRecorder{}
+	obs := NewTracingObserver(WithTracerProvider(tp), WithNext(next))
+
+	ctx := obs.OnRequestStart(context.Background(), opensearchtransport.RequestEvent{RouteName: "search"})
+	attemptCtx := obs.OnAttemptStart(ctx, 0)
+	req := httptest.NewRequest(http.MethodGet, "http://node-1:9200/_search", nil)
+	obs.OnAttemptRequest(attemptCtx, req)
+	obs.OnAttemptEnd(attemptCtx, 0, http.StatusOK, nil)
+	obs.OnRequestResponse(ctx, opensearchtransport.RequestResponseEvent{})
+	obs.OnDemote(opensearchtransport.ConnectionEvent{})
+	obs.OnCacheHit(ctx, opensearchtransport.CacheHitEvent{})
+
+	require.Equal(t, []string{"start", "attempt_start", "attempt_request", "attempt_end", "response", "demote", "cache_hit"}, next.calls)
+	require.True(t, next.spanInResponse, "the request span reaches the next observer")
+	require.NotEmpty(t, req.Header.Get("Traceparent"))
+}
+
+type forwardRecorder struct {
+	opensearchtransport.BaseConnectionObserver
+
+	calls          []string
+	spanInResponse bool
+}
+
+func (r *forwardRecorder) OnRequestStart(ctx context.Context, _ opensearchtransport.RequestEvent) context.Context {
+	r.calls = append(r.calls, "start")
+	return ctx
+}
+
+func (r *forwardRecorder) OnAttemptStart(ctx context.Context, _ int) context.Context {
+	r.calls = append(r.calls, "attempt_start")
+	return ctx
+}
+
+func (r *forwardRecorder) OnAttemptRequest(context.Context, *http.Request) {
+	r.calls = append(r.calls, "attempt_request")
+}
+
+func (r *forwardRecorder) OnAttemptEnd(context.Context, int, int, error) {
+	r.calls = append(r.calls, "attempt_end")
+}
+
+func (r *forwardRecorder) OnRequestResponse(ctx context.Context, _ opensearchtransport.RequestResponseEvent) {
+	r.calls = append(r.calls, "response")
+	r.spanInResponse = trace.SpanFromContext(ctx).SpanContext().IsValid()
+}
+
+func (r *forwardRecorder) OnDemote(opensearchtransport.ConnectionEvent) {
+	r.calls = append(r.calls, "demote")
+}
+
+func (r *forwardRecorder) OnCacheHit(context.Context, opensearchtransport.CacheHitEvent) {
+	r.calls = append(r.calls, "cache_hit")
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package alerting_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/alerting"
+)
+
+// recorded is a request received by a test server.
+type recorded struct {
+	method string
+	path   string
+	query  string
+	body   string
+}
+
+// recorder keeps the last plugin request of a test server.
+type recorder struct {
+	mu  sync.Mutex
+	req recorded
+}
+
+func (r *recorder) last() recorded {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.req
+}
+
+func newTestClient(t *testing.T, reply string) (*alerting.Client, *recorder) {
+	t.Helper()
+
+	rec := &recorder{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/_plugins/") {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.req = recorded{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, body: string(body)}
+		rec.mu.Unlock()
+		_, _ = io.WriteString(w, reply)
+	}))
+	t.Cleanup(ts.Close)
+
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+
+	return alerting.NewClient(osClient), rec
+}
+
+func TestCreateMonitor(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"_id":"m1","_version":1,"_seq_no":0,"_primary_term":1,
+		"monitor":{"type":"monitor","monitor_type":"query_level_monitor","name":"errors","enabled":true,
+		"schedule":{"period":{"interval":5,"unit":"MINUTES"}},"inputs":[],"triggers":[]}}`)
+
+	monitor := alerting.Monitor{
+		MonitorType: alerting.MonitorTypeQueryLevel,
+		Name:        "errors",
+		Enabled:     true,
+		Schedule:    alerting.Schedule{Period: &alerting.Period{Interval: 5, Unit: alerting.UnitMinutes}},
+		Inputs: []alerting.Input{{Search: &alerting.SearchInput{
+			Indices: []string{"logs-*"},
+			Query:   json.RawMessage(`{"size":0,"query":{"match":{"level":"error"}}}`),
+		}}},
+		Triggers: []alerting.Trigger{{QueryLevel: &alerting.QueryLevelTrigger{
+			Name:      "many errors",
+			Severity:  "1",
+			Condition: alerting.ScriptCondition{Script: alerting.Script{Source: "ctx.results[0].hits.total.value > 10"}},
+			Actions: []alerting.Action{{
+				Name:            "page",
+				DestinationID:   "chan-1",
+				MessageTemplate: alerting.Script{Source: "{{ctx.monitor.name}} fired"},
+			}},
+		}}},
+	}
+	resp, err := client.CreateMonitor(t.Context(), alerting.CreateMonitorReq{
+		Body:   &monitor,
+		Params: &alerting.MonitorWriteParams{Refresh: "wait_for"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "m1", resp.ID)
+	require.Equal(t, "errors", resp.Monitor.Name)
+	require.NotNil(t, resp.Inspect().Response)
+
+	require.Equal(t, http.MethodPost, rec.last().method)
+	require.Equal(t, "/_plugins/_alerting/monitors", rec.last().path)
+	require.Equal(t, "refresh=wait_for", rec.last().query)
+
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal([]byte(rec.last().body), &sent))
+	require.Equal(t, "monitor", sent["type"])
+	triggers := sent["triggers"].([]any)
+	require.Contains(t, triggers[0], "query_level_trigger")
+	require.Empty(t, monitor.Type, "the caller's monitor is not modified")
+}
+
+func TestMonitorRequiredID(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestClient(t, `{}`)
+	_, err := client.GetMonitor(t.Context(), alerting.GetMonitorReq{})
+	require.ErrorContains(t, err, "GetMonitorReq.MonitorID")
+}
+
+func TestExecuteMonitor(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"monitor_name":"errors","input_results":{"results":[{}]},
+		"trigger_results":{"t1":{"name":"many errors","triggered":true,"action_results":{}}}}`)
+
+	dryRun := true
+	resp, err := client.ExecuteMonitor(t.Context(), alerting.ExecuteMonitorReq{
+		MonitorID: "m1",
+		Params:    &alerting.ExecuteMonitorParams{DryRun: &dryRun},
+	})
+	require.NoError(t, err)
+	require.True(t, resp.TriggerResults["t1"].Triggered)
+	require.Equal(t, "/_plugins/_alerting/monitors/m1/_execute", rec.last().path)
+	require.Equal(t, "dryrun=true", rec.last().query)
+}
+
+func TestGetAlertsAndAcknowledge(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"alerts":[{"id":"a1","monitor_id":"m1","state":"ACTIVE"}],"totalAlerts":1}`)
+
+	size := 10
+	alerts, err := client.GetAlerts(t.Context(), &alerting.GetAlertsReq{Params: &alerting.GetAlertsParams{
+		MonitorID:  "m1",
+		AlertState: alerting.AlertStateActive,
+		Size:       &size,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, 1, alerts.TotalAlerts)
+	require.Equal(t, alerting.AlertStateActive, alerts.Alerts[0].State)
+	require.Equal(t, "/_plugins/_alerting/monitors/alerts", rec.last().path)
+	require.Equal(t, "alertState=ACTIVE&monitorId=m1&size=10", rec.last().query)
+
+	client, rec = newTestClient(t, `{"success":["a1"],"failed":[]}`)
+	ack, err := client.AcknowledgeAlerts(t.Context(), alerting.AcknowledgeAlertsReq{MonitorID: "m1", AlertIDs: []string{"a1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1"}, ack.Success)
+	require.Equal(t, "/_plugins/_alerting/monitors/m1/_acknowledge/alerts", rec.last().path)
+	require.JSONEq(t, `{"alerts":["a1"]}`, rec.last().body)
+}
+
+func TestDestinations(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"totalDestinations":1,"destinations":[{"id":"d1","type":"slack","name":"ops","slack":{"url":"https://hooks.example"}}]}`)
+
+	resp, err := client.GetDestinations(t.Context(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.TotalDestinations)
+	require.Equal(t, "https://hooks.example", resp.Destinations[0].Slack.URL)
+	require.Equal(t, "/_plugins/_alerting/destinations", rec.last().path)
+
+	_, err = client.GetDestinations(t.Context(), &alerting.GetDestinationsReq{DestinationID: "d1"})
+	require.NoError(t, err)
+	require.Equal(t, "/_plugins/_alerting/destinations/d1", rec.last().path)
+}
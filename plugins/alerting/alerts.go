@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	osparams "github.com/opensearch-project/opensearch-go/v5/internal/params"
+	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Alert states.
+const (
+	AlertStateActive       = "ACTIVE"
+	AlertStateAcknowledged = "ACKNOWLEDGED"
+	AlertStateCompleted    = "COMPLETED"
+	AlertStateError        = "ERROR"
+	AlertStateDeleted      = "DELETED"
+)
+
+// Alert is raised by a trigger of a monitor.
+type Alert struct {
+	ID             string   `json:"id"`
+	Version        int64    `json:"version"`
+	MonitorID      string   `json:"monitor_id"`
+	MonitorName    string   `json:"monitor_name"`
+	MonitorVersion int64    `json:"monitor_version"`
+	TriggerID      string   `json:"trigger_id"`
+	TriggerName    string   `json:"trigger_name"`
+	State          string   `json:"state"`
+	Severity       string   `json:"severity"`
+	ErrorMessage   *string  `json:"error_message"`
+	FindingIDs     []string `json:"finding_ids,omitempty"`
+	RelatedDocIDs  []string `json:"related_doc_ids,omitempty"`
+
+	AlertHistory           []AlertHistory    `json:"alert_history,omitempty"`
+	ActionExecutionResults []json.RawMessage `json:"action_execution_results,omitempty"`
+
+	// Times are in epoch milliseconds.
+	StartTime            *int64 `json:"start_time"`
+	LastNotificationTime *int64 `json:"last_notification_time"`
+	EndTime              *int64 `json:"end_time"`
+	AcknowledgedTime     *int64 `json:"acknowledged_time"`
+}
+
+// AlertHistory is an earlier error of an alert.
+type AlertHistory struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// GetAlertsReq represents the request for the alerting.get_alerts operation.
+//
+// GET /_plugins/_alerting/monitors/alerts
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#get-alerts
+type GetAlertsReq struct {
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *GetAlertsParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r GetAlertsReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"), ospath.Lit("alerts"))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest[struct{}](method, path, nil, nil, params, r.Header)
+}
+
+// GetAlertsParams represents query parameters for the GetAlertsReq.
+type GetAlertsParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	MonitorID string
+	// AlertState is one of the AlertState constants, or ALL.
+	AlertState string
+	// SeverityLevel is "1" to "5", or ALL.
+	SeverityLevel string
+	SearchString  string
+
+	Size       *int
+	StartIndex *int
+	// SortString is the field to sort by, and SortOrder asc or desc.
+	SortString string
+	SortOrder  string
+}
+
+func (r GetAlertsParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.MonitorID != "" {
+		set("monitorId", r.MonitorID)
+	}
+
+	if r.AlertState != "" {
+		set("alertState", r.AlertState)
+	}
+
+	if r.SeverityLevel != "" {
+		set("severityLevel", r.SeverityLevel)
+	}
+
+	if r.SearchString != "" {
+		set("searchString", r.SearchString)
+	}
+
+	if r.Size != nil {
+		set("size", strconv.Itoa(*r.Size))
+	}
+
+	if r.StartIndex != nil {
+		set("startIndex", strconv.Itoa(*r.StartIndex))
+	}
+
+	if r.SortString != "" {
+		set("sortString", r.SortString)
+	}
+
+	if r.SortOrder != "" {
+		set("sortOrder", r.SortOrder)
+	}
+
+	return params
+}
+
+// GetAlertsResp represents the response for the alerting.get_alerts operation.
+type GetAlertsResp struct {
+	Alerts      []Alert `json:"alerts"`
+	TotalAlerts int     `json:"totalAlerts"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r GetAlertsResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r GetAlertsResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// AcknowledgeAlertsReq represents the request for the alerting.acknowledge_alerts operation.
+//
+// POST /_plugins/_alerting/monitors/{monitor_id}/_acknowledge/alerts
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#acknowledge-alert
+type AcknowledgeAlertsReq struct {
+	MonitorID string
+
+	// AlertIDs are the alerts of the monitor to acknowledge.
+	AlertIDs []string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r AcknowledgeAlertsReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"),
+		ospath.Required("AcknowledgeAlertsReq.MonitorID", r.MonitorID), ospath.Lit("_acknowledge"), ospath.Lit("alerts"))
+	if err != nil {
+		return nil, err
+	}
+	body := struct {
+		Alerts []string `json:"alerts"`
+	}{Alerts: r.AlertIDs}
+	return newRequest(method, path, &body, nil, nil, r.Header)
+}
+
+// AcknowledgeAlertsResp represents the response for the alerting.acknowledge_alerts operation.
+type AcknowledgeAlertsResp struct {
+	// Success and Failed list the alert IDs that were and were not
+	// acknowledged. Alerts that are not ACTIVE cannot be acknowledged.
+	Success []string `json:"success"`
+	Failed  []string `json:"failed"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r AcknowledgeAlertsResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r AcknowledgeAlertsResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// GetAlerts lists alerts.
+//
+// GET /_plugins/_alerting/monitors/alerts
+func (c *Client) GetAlerts(ctx context.Context, req *GetAlertsReq) (*GetAlertsResp, error) {
+	if req == nil {
+		req = &GetAlertsReq{}
+	}
+	var resp GetAlertsResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodGet, *req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// AcknowledgeAlerts acknowledges active alerts of a monitor, which stops
+// their notifications until they complete.
+//
+// POST /_plugins/_alerting/monitors/{monitor_id}/_acknowledge/alerts
+func (c *Client) AcknowledgeAlerts(ctx context.Context, req AcknowledgeAlertsReq) (*AcknowledgeAlertsResp, error) {
+	var resp AcknowledgeAlertsResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
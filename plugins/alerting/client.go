@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+// Package alerting is a client for the OpenSearch Alerting plugin: monitors
+// and their triggers, destinations, and alerts.
+//
+// The bundled API specification has no operations for the plugin, so this
+// package is written by hand, following the conventions of the generated
+// plugin packages.
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/apiutil"
+	"github.com/opensearch-project/opensearch-go/v5/internal/build"
+)
+
+// Inspect represents the struct returned by Inspect(), its main use is to return the opensearch.Response to the user.
+type Inspect = apiutil.Inspect
+
+// Client provides methods for this plugin API.
+type Client struct {
+	Client *opensearch.Client
+}
+
+// NewClient creates a new plugin client wrapping the given opensearch.Client.
+func NewClient(client *opensearch.Client) *Client {
+	return &Client{Client: client}
+}
+
+// request calls [opensearch.Execute] and checks the response for errors.
+func request[T any](ctx context.Context, c *Client, method string, req opensearch.Request, dataPointer *T) (*opensearch.Response, error) {
+	resp, err := opensearch.Execute(ctx, c.Client, method, req, dataPointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		if dataPointer != nil {
+			return resp, opensearch.ParseError(resp)
+		}
+		return resp, fmt.Errorf("status: %s", resp.Status())
+	}
+
+	return resp, nil
+}
+
+// newRequest builds an HTTP request with body marshaled to JSON when it is
+// non-nil, or with bodyReader otherwise.
+func newRequest[B any](method, path string, body *B, bodyReader io.Reader, params map[string]string, header http.Header) (*http.Request, error) {
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+	return build.Request(method, path, bodyReader, params, header)
+}
+
+// rawBody returns a fresh reader over the bytes of resp.
+func rawBody(resp *opensearch.Response) io.Reader {
+	if resp == nil || len(resp.RawBody()) == 0 {
+		return nil
+	}
+	return bytes.NewReader(resp.RawBody())
+}
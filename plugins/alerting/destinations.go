@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package alerting
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
+)
+
+// Destination types.
+const (
+	DestinationTypeSlack         = "slack"
+	DestinationTypeChime         = "chime"
+	DestinationTypeCustomWebhook = "custom_webhook"
+	DestinationTypeEmail         = "email"
+)
+
+// Destination is where monitor actions send their messages. Destinations
+// are deprecated since OpenSearch 2.0 in favor of the channels of the
+// notifications plugin, which actions reference by the same DestinationID.
+type Destination struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	Slack         *URLDestination   `json:"slack,omitempty"`
+	Chime         *URLDestination   `json:"chime,omitempty"`
+	CustomWebhook *CustomWebhook    `json:"custom_webhook,omitempty"`
+	Email         *EmailDestination `json:"email,omitempty"`
+
+	// Set by the server.
+	ID             string `json:"id,omitempty"`
+	SchemaVersion  *int   `json:"schema_version,omitempty"`
+	SeqNo          *int64 `json:"seq_no,omitempty"`
+	PrimaryTerm    *int64 `json:"primary_term,omitempty"`
+	LastUpdateTime *int64 `json:"last_update_time,omitempty"`
+}
+
+// URLDestination is a Slack or Chime webhook.
+type URLDestination struct {
+	URL string `json:"url"`
+}
+
+// CustomWebhook is a custom webhook, given either as URL or by its parts.
+type CustomWebhook struct {
+	URL          string            `json:"url,omitempty"`
+	Scheme       string            `json:"scheme,omitempty"`
+	Host         string            `json:"host,omitempty"`
+	Port         int               `json:"port,omitempty"`
+	Path         string            `json:"path,omitempty"`
+	QueryParams  map[string]string `json:"query_params,omitempty"`
+	HeaderParams map[string]string `json:"header_params,omitempty"`
+	Username     string            `json:"username,omitempty"`
+	Password     string            `json:"password,omitempty"`
+}
+
+// EmailDestination sends email through an email account.
+type EmailDestination struct {
+	EmailAccountID string           `json:"email_account_id"`
+	Recipients     []EmailRecipient `json:"recipients"`
+}
+
+// EmailRecipient is an address (Type "email") or an email group (Type
+// "email_group").
+type EmailRecipient struct {
+	Type         string `json:"type"`
+	Email        string `json:"email,omitempty"`
+	EmailGroupID string `json:"email_group_id,omitempty"`
+}
+
+// CreateDestinationReq represents the request for the alerting.create_destination operation.
+//
+// POST /_plugins/_alerting/destinations
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#create-destination
+type CreateDestinationReq struct {
+	// Body specifies the typed request body. When non-nil, it is
+	// marshaled to JSON for the request payload.
+	Body *Destination
+
+	// BodyReader provides an escape hatch for sending a raw request
+	// body. It is used only when Body is nil.
+	BodyReader io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r CreateDestinationReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("destinations"))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(method, path, r.Body, r.BodyReader, nil, r.Header)
+}
+
+// UpdateDestinationReq represents the request for the alerting.update_destination operation.
+//
+// PUT /_plugins/_alerting/destinations/{destination_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#update-destination
+type UpdateDestinationReq struct {
+	DestinationID string
+
+	// Body specifies the typed request body. When non-nil, it is
+	// marshaled to JSON for the request payload.
+	Body *Destination
+
+	// BodyReader provides an escape hatch for sending a raw request
+	// body. It is used only when Body is nil.
+	BodyReader io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r UpdateDestinationReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("destinations"),
+		ospath.Required("UpdateDestinationReq.DestinationID", r.DestinationID))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(method, path, r.Body, r.BodyReader, nil, r.Header)
+}
+
+// DestinationResp represents the response for the alerting.create_destination
+// and alerting.update_destination operations.
+type DestinationResp struct {
+	ID          string      `json:"_id"`
+	Version     int64       `json:"_version"`
+	SeqNo       int64       `json:"_seq_no"`
+	PrimaryTerm int64       `json:"_primary_term"`
+	Destination Destination `json:"destination"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r DestinationResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r DestinationResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// GetDestinationsReq represents the request for the alerting.get_destinations operation.
+//
+// GET /_plugins/_alerting/destinations
+//
+// GET /_plugins/_alerting/destinations/{destination_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#get-destinations
+type GetDestinationsReq struct {
+	// DestinationID selects a single destination.
+	DestinationID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r GetDestinationsReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("destinations"),
+		ospath.Optional(r.DestinationID))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, nil, nil, r.Header)
+}
+
+// GetDestinationsResp represents the response for the alerting.get_destinations operation.
+type GetDestinationsResp struct {
+	TotalDestinations int           `json:"totalDestinations"`
+	Destinations      []Destination `json:"destinations"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r GetDestinationsResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r GetDestinationsResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// DeleteDestinationReq represents the request for the alerting.delete_destination operation.
+//
+// DELETE /_plugins/_alerting/destinations/{destination_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#delete-destination
+type DeleteDestinationReq struct {
+	DestinationID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r DeleteDestinationReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("destinations"),
+		ospath.Required("DeleteDestinationReq.DestinationID", r.DestinationID))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, nil, nil, r.Header)
+}
+
+// CreateDestination creates a destination.
+//
+// POST /_plugins/_alerting/destinations
+func (c *Client) CreateDestination(ctx context.Context, req CreateDestinationReq) (*DestinationResp, error) {
+	var resp DestinationResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// UpdateDestination replaces a destination.
+//
+// PUT /_plugins/_alerting/destinations/{destination_id}
+func (c *Client) UpdateDestination(ctx context.Context, req UpdateDestinationReq) (*DestinationResp, error) {
+	var resp DestinationResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPut, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// GetDestinations retrieves one or all destinations.
+//
+// GET /_plugins/_alerting/destinations
+func (c *Client) GetDestinations(ctx context.Context, req *GetDestinationsReq) (*GetDestinationsResp, error) {
+	if req == nil {
+		req = &GetDestinationsReq{}
+	}
+	var resp GetDestinationsResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodGet, *req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// DeleteDestination deletes a destination.
+//
+// DELETE /_plugins/_alerting/destinations/{destination_id}
+func (c *Client) DeleteDestination(ctx context.Context, req DeleteDestinationReq) (*DeleteResp, error) {
+	var resp DeleteResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodDelete, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package alerting
+
+import (
+	"encoding/json"
+)
+
+// Monitor types.
+const (
+	MonitorTypeQueryLevel     = "query_level_monitor"
+	MonitorTypeBucketLevel    = "bucket_level_monitor"
+	MonitorTypeDocLevel       = "doc_level_monitor"
+	MonitorTypeClusterMetrics = "cluster_metrics_monitor"
+)
+
+// Schedule period units.
+const (
+	UnitMinutes = "MINUTES"
+	UnitHours   = "HOURS"
+	UnitDays    = "DAYS"
+)
+
+// Monitor is the body of a monitor: what it queries, on which schedule, and
+// the triggers evaluated against the results.
+type Monitor struct {
+	// Type is always "monitor"; it is set when the monitor is created or
+	// updated with an empty Type.
+	Type        string `json:"type"`
+	MonitorType string `json:"monitor_type,omitempty"`
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+
+	Schedule Schedule  `json:"schedule"`
+	Inputs   []Input   `json:"inputs"`
+	Triggers []Trigger `json:"triggers"`
+
+	// Set by the server.
+	SchemaVersion  *int   `json:"schema_version,omitempty"`
+	EnabledTime    *int64 `json:"enabled_time,omitempty"`
+	LastUpdateTime *int64 `json:"last_update_time,omitempty"`
+}
+
+// Schedule is how often a monitor runs: every Period, or on a Cron
+// expression.
+type Schedule struct {
+	Period *Period `json:"period,omitempty"`
+	Cron   *Cron   `json:"cron,omitempty"`
+}
+
+// Period is a fixed interval, such as 5 MINUTES.
+type Period struct {
+	Interval int    `json:"interval"`
+	Unit     string `json:"unit"`
+}
+
+// Cron is a cron expression evaluated in Timezone.
+type Cron struct {
+	Expression string `json:"expression"`
+	Timezone   string `json:"timezone,omitempty"`
+}
+
+// Input is the data source of a monitor. Exactly one field is set.
+type Input struct {
+	// Search runs a query, for query and bucket level monitors.
+	Search *SearchInput `json:"search,omitempty"`
+	// URI calls a cluster API, for cluster metrics monitors.
+	URI *URIInput `json:"uri,omitempty"`
+	// DocLevel matches new documents against queries, for document level
+	// monitors.
+	DocLevel *DocLevelInput `json:"doc_level_input,omitempty"`
+}
+
+// SearchInput is a search request body run against Indices. It may use the
+// {{period_end}} and {{period_start}} mustache variables.
+type SearchInput struct {
+	Indices []string        `json:"indices"`
+	Query   json.RawMessage `json:"query"`
+}
+
+// URIInput is a cluster API call, such as _cluster/health.
+type URIInput struct {
+	APIType    string   `json:"api_type,omitempty"`
+	Path       string   `json:"path,omitempty"`
+	PathParams string   `json:"path_params,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	Clusters   []string `json:"clusters,omitempty"`
+}
+
+// DocLevelInput lists the queries new documents of Indices are matched
+// against.
+type DocLevelInput struct {
+	Description string          `json:"description,omitempty"`
+	Indices     []string        `json:"indices"`
+	Queries     []DocLevelQuery `json:"queries"`
+}
+
+// DocLevelQuery is a query string query of a document level monitor.
+type DocLevelQuery struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Query string   `json:"query"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Trigger is a condition of a monitor and the actions run when it holds.
+// Exactly one field is set, matching the monitor type.
+type Trigger struct {
+	QueryLevel    *QueryLevelTrigger    `json:"query_level_trigger,omitempty"`
+	BucketLevel   *BucketLevelTrigger   `json:"bucket_level_trigger,omitempty"`
+	DocumentLevel *DocumentLevelTrigger `json:"document_level_trigger,omitempty"`
+}
+
+// QueryLevelTrigger evaluates a script against the whole query result,
+// available as ctx.results.
+type QueryLevelTrigger struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// Severity is "1" (highest) to "5".
+	Severity  string          `json:"severity"`
+	Condition ScriptCondition `json:"condition"`
+	Actions   []Action        `json:"actions"`
+}
+
+// DocumentLevelTrigger evaluates a script against the queries each
+// document matched, such as query[tag=sev1].
+type DocumentLevelTrigger struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	Severity  string          `json:"severity"`
+	Condition ScriptCondition `json:"condition"`
+	Actions   []Action        `json:"actions"`
+}
+
+// ScriptCondition is a trigger condition.
+type ScriptCondition struct {
+	Script Script `json:"script"`
+}
+
+// BucketLevelTrigger evaluates a script against each bucket of an
+// aggregation.
+type BucketLevelTrigger struct {
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name"`
+	Severity  string          `json:"severity"`
+	Condition BucketCondition `json:"condition"`
+	Actions   []Action        `json:"actions"`
+}
+
+// BucketCondition is a bucket selector: Script is evaluated with the
+// BucketsPath variables of each bucket under ParentBucketPath.
+type BucketCondition struct {
+	BucketsPath      map[string]string `json:"buckets_path"`
+	ParentBucketPath string            `json:"parent_bucket_path"`
+	Script           Script            `json:"script"`
+}
+
+// Script is a script or, in action templates, a mustache template.
+type Script struct {
+	Source string         `json:"source"`
+	Lang   string         `json:"lang,omitempty"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// Action sends a message to a destination or notification channel when a
+// trigger fires.
+type Action struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	// DestinationID is the ID of a notification channel or, on clusters
+	// older than 2.0, of a destination.
+	DestinationID   string  `json:"destination_id"`
+	MessageTemplate Script  `json:"message_template"`
+	SubjectTemplate *Script `json:"subject_template,omitempty"`
+
+	ThrottleEnabled bool      `json:"throttle_enabled"`
+	Throttle        *Throttle `json:"throttle,omitempty"`
+
+	// ActionExecutionPolicy selects which alerts of a bucket level trigger
+	// run the action.
+	ActionExecutionPolicy json.RawMessage `json:"action_execution_policy,omitempty"`
+}
+
+// Throttle is the minimum time between two runs of an action.
+type Throttle struct {
+	Value int    `json:"value"`
+	Unit  string `json:"unit"`
+}
+
+// withType returns the monitor with Type defaulted to "monitor".
+func (m *Monitor) withType() *Monitor {
+	if m == nil || m.Type != "" {
+		return m
+	}
+	c := *m
+	c.Type = "monitor"
+	return &c
+}
@@ -0,0 +1,458 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	osparams "github.com/opensearch-project/opensearch-go/v5/internal/params"
+	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// CreateMonitorReq represents the request for the alerting.create_monitor operation.
+//
+// POST /_plugins/_alerting/monitors
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#create-a-query-level-monitor
+type CreateMonitorReq struct {
+	// Body specifies the typed request body. When non-nil, it is
+	// marshaled to JSON for the request payload.
+	Body *Monitor
+
+	// BodyReader provides an escape hatch for sending a raw request
+	// body. It is used only when Body is nil.
+	BodyReader io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *MonitorWriteParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r CreateMonitorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest(method, path, r.Body.withType(), r.BodyReader, params, r.Header)
+}
+
+// UpdateMonitorReq represents the request for the alerting.update_monitor operation.
+//
+// PUT /_plugins/_alerting/monitors/{monitor_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#update-monitor
+type UpdateMonitorReq struct {
+	MonitorID string
+
+	// Body specifies the typed request body. When non-nil, it is
+	// marshaled to JSON for the request payload.
+	Body *Monitor
+
+	// BodyReader provides an escape hatch for sending a raw request
+	// body. It is used only when Body is nil.
+	BodyReader io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *MonitorWriteParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r UpdateMonitorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"),
+		ospath.Required("UpdateMonitorReq.MonitorID", r.MonitorID))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest(method, path, r.Body.withType(), r.BodyReader, params, r.Header)
+}
+
+// MonitorWriteParams represents query parameters for the CreateMonitorReq and UpdateMonitorReq.
+type MonitorWriteParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// Refresh controls when the change is visible to search: `true`,
+	// `false`, or `wait_for`.
+	Refresh string
+
+	// IfSeqNo and IfPrimaryTerm make an update fail when the monitor was
+	// changed since it was read.
+	IfSeqNo       *int64
+	IfPrimaryTerm *int64
+}
+
+func (r MonitorWriteParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.Refresh != "" {
+		set("refresh", r.Refresh)
+	}
+
+	if r.IfSeqNo != nil {
+		set("if_seq_no", strconv.FormatInt(*r.IfSeqNo, 10))
+	}
+
+	if r.IfPrimaryTerm != nil {
+		set("if_primary_term", strconv.FormatInt(*r.IfPrimaryTerm, 10))
+	}
+
+	return params
+}
+
+// MonitorResp represents the response for the alerting.create_monitor,
+// alerting.update_monitor, and alerting.get_monitor operations.
+type MonitorResp struct {
+	ID          string  `json:"_id"`
+	Version     int64   `json:"_version"`
+	SeqNo       int64   `json:"_seq_no"`
+	PrimaryTerm int64   `json:"_primary_term"`
+	Monitor     Monitor `json:"monitor"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r MonitorResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r MonitorResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// GetMonitorReq represents the request for the alerting.get_monitor operation.
+//
+// GET /_plugins/_alerting/monitors/{monitor_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#get-monitor
+type GetMonitorReq struct {
+	MonitorID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r GetMonitorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"),
+		ospath.Required("GetMonitorReq.MonitorID", r.MonitorID))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, nil, nil, r.Header)
+}
+
+// DeleteMonitorReq represents the request for the alerting.delete_monitor operation.
+//
+// DELETE /_plugins/_alerting/monitors/{monitor_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#delete-monitor
+type DeleteMonitorReq struct {
+	MonitorID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r DeleteMonitorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"),
+		ospath.Required("DeleteMonitorReq.MonitorID", r.MonitorID))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, nil, nil, r.Header)
+}
+
+// DeleteResp represents the response for the delete operations of this
+// plugin.
+type DeleteResp struct {
+	ID      string `json:"_id"`
+	Version int64  `json:"_version"`
+	Result  string `json:"result"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r DeleteResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r DeleteResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// SearchMonitorsReq represents the request for the alerting.search_monitors operation.
+//
+// POST /_plugins/_alerting/monitors/_search
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#search-monitors
+type SearchMonitorsReq struct {
+	// Body is a search request body, typically JSON-encoded.
+	Body io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r SearchMonitorsReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"), ospath.Lit("_search"))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, r.Body, nil, r.Header)
+}
+
+// SearchMonitorsResp represents the response for the alerting.search_monitors operation.
+type SearchMonitorsResp struct {
+	Took     int  `json:"took"`
+	TimedOut bool `json:"timed_out"`
+	Hits     struct {
+		Total *opensearchapi.SearchTotalHits `json:"total"`
+		Hits  []MonitorHit                   `json:"hits"`
+	} `json:"hits"`
+
+	response *opensearch.Response
+}
+
+// MonitorHit is a monitor found by SearchMonitors.
+type MonitorHit struct {
+	ID          string   `json:"_id"`
+	SeqNo       *int64   `json:"_seq_no"`
+	PrimaryTerm *int64   `json:"_primary_term"`
+	Score       *float64 `json:"_score"`
+	Source      Monitor  `json:"_source"`
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r SearchMonitorsResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r SearchMonitorsResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// ExecuteMonitorReq represents the request for the alerting.execute_monitor operation.
+//
+// POST /_plugins/_alerting/monitors/{monitor_id}/_execute
+//
+// POST /_plugins/_alerting/monitors/_execute
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/alerting/api/#run-monitor
+type ExecuteMonitorReq struct {
+	// MonitorID runs a saved monitor. When empty, Body is run instead.
+	MonitorID string
+
+	// Body is an unsaved monitor to run.
+	Body *Monitor
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *ExecuteMonitorParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r ExecuteMonitorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_alerting"), ospath.Lit("monitors"),
+		ospath.Optional(r.MonitorID), ospath.Lit("_execute"))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest(method, path, r.Body.withType(), nil, params, r.Header)
+}
+
+// ExecuteMonitorParams represents query parameters for the ExecuteMonitorReq.
+type ExecuteMonitorParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// DryRun evaluates the triggers without running their actions.
+	DryRun *bool
+}
+
+func (r ExecuteMonitorParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.DryRun != nil {
+		set("dryrun", strconv.FormatBool(*r.DryRun))
+	}
+
+	return params
+}
+
+// ExecuteMonitorResp represents the response for the alerting.execute_monitor operation.
+type ExecuteMonitorResp struct {
+	MonitorName string  `json:"monitor_name"`
+	PeriodStart *string `json:"period_start"`
+	PeriodEnd   *string `json:"period_end"`
+	Error       *string `json:"error"`
+
+	InputResults struct {
+		Results []json.RawMessage `json:"results"`
+		Error   *string           `json:"error"`
+	} `json:"input_results"`
+
+	// TriggerResults maps trigger IDs to their results.
+	TriggerResults map[string]TriggerRunResult `json:"trigger_results"`
+
+	response *opensearch.Response
+}
+
+// TriggerRunResult is the result of a trigger in a monitor run.
+type TriggerRunResult struct {
+	Name      string  `json:"name"`
+	Triggered bool    `json:"triggered"`
+	Error     *string `json:"error"`
+	// ActionResults maps action IDs to their results.
+	ActionResults map[string]ActionRunResult `json:"action_results"`
+}
+
+// ActionRunResult is the result of an action in a monitor run.
+type ActionRunResult struct {
+	Name      string            `json:"name"`
+	Output    map[string]string `json:"output"`
+	Throttled bool              `json:"throttled"`
+	Error     *string           `json:"error"`
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r ExecuteMonitorResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r ExecuteMonitorResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// CreateMonitor creates a monitor.
+//
+// POST /_plugins/_alerting/monitors
+func (c *Client) CreateMonitor(ctx context.Context, req CreateMonitorReq) (*MonitorResp, error) {
+	var resp MonitorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// UpdateMonitor replaces a monitor.
+//
+// PUT /_plugins/_alerting/monitors/{monitor_id}
+func (c *Client) UpdateMonitor(ctx context.Context, req UpdateMonitorReq) (*MonitorResp, error) {
+	var resp MonitorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPut, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// GetMonitor retrieves a monitor.
+//
+// GET /_plugins/_alerting/monitors/{monitor_id}
+func (c *Client) GetMonitor(ctx context.Context, req GetMonitorReq) (*MonitorResp, error) {
+	var resp MonitorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodGet, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// DeleteMonitor deletes a monitor.
+//
+// DELETE /_plugins/_alerting/monitors/{monitor_id}
+func (c *Client) DeleteMonitor(ctx context.Context, req DeleteMonitorReq) (*DeleteResp, error) {
+	var resp DeleteResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodDelete, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// SearchMonitors searches the monitors.
+//
+// POST /_plugins/_alerting/monitors/_search
+func (c *Client) SearchMonitors(ctx context.Context, req *SearchMonitorsReq) (*SearchMonitorsResp, error) {
+	if req == nil {
+		req = &SearchMonitorsReq{}
+	}
+	var resp SearchMonitorsResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, *req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// ExecuteMonitor runs a saved or an unsaved monitor and returns the results
+// of its input and triggers.
+//
+// POST /_plugins/_alerting/monitors/{monitor_id}/_execute
+func (c *Client) ExecuteMonitor(ctx context.Context, req ExecuteMonitorReq) (*ExecuteMonitorResp, error) {
+	var resp ExecuteMonitorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
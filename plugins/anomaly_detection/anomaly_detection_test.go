@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package anomaly_detection_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	ad "github.com/opensearch-project/opensearch-go/v5/plugins/anomaly_detection"
+)
+
+// recorded is a request received by a test server.
+type recorded struct {
+	method string
+	path   string
+	query  string
+	body   string
+}
+
+// recorder keeps the last plugin request of a test server.
+type recorder struct {
+	mu  sync.Mutex
+	req recorded
+}
+
+func (r *recorder) last() recorded {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.req
+}
+
+func newTestClient(t *testing.T, reply string) (*ad.Client, *recorder) {
+	t.Helper()
+
+	rec := &recorder{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/_plugins/") {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.req = recorded{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, body: string(body)}
+		rec.mu.Unlock()
+		_, _ = io.WriteString(w, reply)
+	}))
+	t.Cleanup(ts.Close)
+
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+
+	return ad.NewClient(osClient), rec
+}
+
+func TestCreateDetector(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"_id":"d1","_version":1,"_seq_no":0,"_primary_term":1,
+		"anomaly_detector":{"name":"bytes","time_field":"@timestamp","indices":["logs-*"],"feature_attributes":[],
+		"detection_interval":{"period":{"interval":10,"unit":"Minutes"}}}}`)
+
+	shingle := 8
+	delay := ad.Minutes(1)
+	resp, err := client.CreateDetector(t.Context(), ad.CreateDetectorReq{Body: &ad.Detector{
+		Name:      "bytes",
+		TimeField: "@timestamp",
+		Indices:   []string{"logs-*"},
+		FeatureAttributes: []ad.Feature{{
+			FeatureName:      "total_bytes",
+			FeatureEnabled:   true,
+			AggregationQuery: json.RawMessage(`{"total_bytes":{"sum":{"field":"bytes"}}}`),
+		}},
+		DetectionInterval: ad.Minutes(10),
+		WindowDelay:       &delay,
+		CategoryField:     []string{"host"},
+		ShingleSize:       &shingle,
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "d1", resp.ID)
+	require.Equal(t, ad.UnitMinutes, resp.Detector.DetectionInterval.Period.Unit)
+
+	last := rec.last()
+	require.Equal(t, http.MethodPost, last.method)
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors", last.path)
+	require.JSONEq(t, `{"name":"bytes","time_field":"@timestamp","indices":["logs-*"],
+		"feature_attributes":[{"feature_name":"total_bytes","feature_enabled":true,
+			"aggregation_query":{"total_bytes":{"sum":{"field":"bytes"}}}}],
+		"detection_interval":{"period":{"interval":10,"unit":"Minutes"}},
+		"window_delay":{"period":{"interval":1,"unit":"Minutes"}},
+		"category_field":["host"],"shingle_size":8}`, last.body)
+}
+
+func TestGetDetectorWithJob(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"_id":"d1","anomaly_detector":{"name":"bytes"},
+		"anomaly_detector_job":{"name":"d1","enabled":true},
+		"realtime_detection_task":{"task_id":"t1","task_type":"REALTIME_HC_DETECTOR","state":"RUNNING","init_progress":1.0}}`)
+
+	job := true
+	resp, err := client.GetDetector(t.Context(), ad.GetDetectorReq{DetectorID: "d1", Params: &ad.GetDetectorParams{Job: &job, Task: &job}})
+	require.NoError(t, err)
+	require.True(t, resp.Job.Enabled)
+	require.Equal(t, ad.StateRunning, resp.RealtimeDetectionTask.State)
+	require.Nil(t, resp.HistoricalAnalysisTask)
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors/d1", rec.last().path)
+	require.Equal(t, "job=true&task=true", rec.last().query)
+
+	_, err = client.GetDetector(t.Context(), ad.GetDetectorReq{})
+	require.ErrorContains(t, err, "GetDetectorReq.DetectorID")
+}
+
+func TestStartStopDetector(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"_id":"d1","_version":2,"_seq_no":3,"_primary_term":1}`)
+
+	_, err := client.StartDetector(t.Context(), ad.StartDetectorReq{DetectorID: "d1"})
+	require.NoError(t, err)
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors/d1/_start", rec.last().path)
+	require.Empty(t, rec.last().body)
+
+	_, err = client.StartDetector(t.Context(), ad.StartDetectorReq{
+		DetectorID: "d1",
+		Body:       &ad.HistoricalRange{StartTime: 1000, EndTime: 2000},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"start_time":1000,"end_time":2000}`, rec.last().body)
+
+	historical := true
+	_, err = client.StopDetector(t.Context(), ad.StopDetectorReq{DetectorID: "d1", Params: &ad.StopDetectorParams{Historical: &historical}})
+	require.NoError(t, err)
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors/d1/_stop", rec.last().path)
+	require.Equal(t, "historical=true", rec.last().query)
+}
+
+func TestSearchResults(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"took":3,"timed_out":false,"hits":{"total":{"value":2,"relation":"eq"},"hits":[
+		{"_index":".opendistro-anomaly-results","_id":"r1","_source":{"detector_id":"d1","anomaly_grade":0,"confidence":0.9,
+			"data_start_time":1,"data_end_time":2,"execution_start_time":3,"execution_end_time":4}},
+		{"_index":".opendistro-anomaly-results","_id":"r2","_source":{"detector_id":"d1","anomaly_grade":0.7,"confidence":0.95,
+			"entity":[{"name":"host","value":"a"}],"feature_data":[{"feature_id":"f1","feature_name":"total_bytes","data":12345}],
+			"data_start_time":5,"data_end_time":6,"execution_start_time":7,"execution_end_time":8}}]}}`)
+
+	only := true
+	resp, err := client.SearchResults(t.Context(), &ad.SearchResultsReq{
+		ResultIndex: "opensearch-ad-plugin-result-bytes",
+		Body:        strings.NewReader(`{"query":{"term":{"detector_id":"d1"}}}`),
+		Params:      &ad.SearchResultsParams{OnlyQueryCustomResultIndex: &only},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Hits.Hits, 2)
+
+	anomalies := resp.Anomalies()
+	require.Len(t, anomalies, 1)
+	require.Equal(t, "a", anomalies[0].Entity[0].Value)
+	require.InDelta(t, 12345, anomalies[0].FeatureData[0].Data, 0)
+
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors/results/_search/opensearch-ad-plugin-result-bytes", rec.last().path)
+	require.Equal(t, "only_query_custom_result_index=true", rec.last().query)
+}
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	client, rec := newTestClient(t, `{"state":"INIT","init_progress":{"percentage":"70%","estimated_minutes_left":77,"needed_shingles":77},
+		"models":[{"model_id":"d1_model_rcf_0","model_size_in_bytes":4096,"node_id":"n1"}]}`)
+
+	resp, err := client.Profile(t.Context(), ad.ProfileReq{DetectorID: "d1", Types: []string{"state", "init_progress", "models"}})
+	require.NoError(t, err)
+	require.Equal(t, ad.StateInit, resp.State)
+	require.Equal(t, "70%", resp.InitProgress.Percentage)
+	require.Equal(t, int64(4096), resp.Models[0].ModelSizeInBytes)
+	require.Equal(t, "/_plugins/_anomaly_detection/detectors/d1/_profile/state,init_progress,models", rec.last().path)
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package anomaly_detection
+
+import (
+	"encoding/json"
+)
+
+// Detector interval units.
+const (
+	UnitMinutes = "Minutes"
+)
+
+// Detector states reported by the profile API.
+const (
+	StateDisabled = "DISABLED"
+	StateInit     = "INIT"
+	StateRunning  = "RUNNING"
+)
+
+// Detector is the body of an anomaly detector: the indices and features it
+// models, and how often it runs.
+type Detector struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	TimeField   string   `json:"time_field"`
+	Indices     []string `json:"indices"`
+
+	FeatureAttributes []Feature `json:"feature_attributes"`
+	// FilterQuery restricts the documents the detector models. It is a
+	// query DSL object; the server defaults it to match_all.
+	FilterQuery json.RawMessage `json:"filter_query,omitempty"`
+
+	DetectionInterval Interval  `json:"detection_interval"`
+	WindowDelay       *Interval `json:"window_delay,omitempty"`
+
+	// CategoryField makes the detector high cardinality: one model per
+	// value, or pair of values, of up to two fields.
+	CategoryField []string `json:"category_field,omitempty"`
+	ShingleSize   *int     `json:"shingle_size,omitempty"`
+	ResultIndex   string   `json:"result_index,omitempty"`
+
+	// Set by the server.
+	DetectorType   string `json:"detector_type,omitempty"`
+	SchemaVersion  *int   `json:"schema_version,omitempty"`
+	LastUpdateTime *int64 `json:"last_update_time,omitempty"`
+}
+
+// Interval is a period, such as 10 Minutes.
+type Interval struct {
+	Period Period `json:"period"`
+}
+
+// Period is an amount of Unit.
+type Period struct {
+	Interval int    `json:"interval"`
+	Unit     string `json:"unit"`
+}
+
+// Minutes returns an interval of n minutes.
+func Minutes(n int) Interval {
+	return Interval{Period: Period{Interval: n, Unit: UnitMinutes}}
+}
+
+// Feature is an aggregation the detector models. AggregationQuery holds a
+// single named metric aggregation, such as
+// {"total_bytes":{"sum":{"field":"bytes"}}}.
+type Feature struct {
+	FeatureID        string          `json:"feature_id,omitempty"`
+	FeatureName      string          `json:"feature_name"`
+	FeatureEnabled   bool            `json:"feature_enabled"`
+	AggregationQuery json.RawMessage `json:"aggregation_query"`
+}
+
+// DetectorJob is the scheduled job of a real-time detector.
+type DetectorJob struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Times are in epoch milliseconds.
+	EnabledTime    *int64 `json:"enabled_time,omitempty"`
+	DisabledTime   *int64 `json:"disabled_time,omitempty"`
+	LastUpdateTime *int64 `json:"last_update_time,omitempty"`
+}
+
+// DetectorTask is a real-time or historical analysis task of a detector.
+type DetectorTask struct {
+	TaskID   string `json:"task_id"`
+	TaskType string `json:"task_type"`
+	State    string `json:"state"`
+	Error    string `json:"error,omitempty"`
+	// TaskProgress and InitProgress are fractions between 0 and 1.
+	TaskProgress *float64 `json:"task_progress,omitempty"`
+	InitProgress *float64 `json:"init_progress,omitempty"`
+	// Times are in epoch milliseconds.
+	ExecutionStartTime *int64 `json:"execution_start_time,omitempty"`
+	ExecutionEndTime   *int64 `json:"execution_end_time,omitempty"`
+	LastUpdateTime     *int64 `json:"last_update_time,omitempty"`
+}
+
+// AnomalyResult is a result a detector wrote for one interval and, for
+// high cardinality detectors, one entity.
+type AnomalyResult struct {
+	DetectorID string `json:"detector_id"`
+	TaskID     string `json:"task_id,omitempty"`
+	ModelID    string `json:"model_id,omitempty"`
+
+	// AnomalyGrade is between 0 and 1; 0 means the interval is not
+	// anomalous. It is nil while the model initializes.
+	AnomalyGrade *float64 `json:"anomaly_grade,omitempty"`
+	Confidence   *float64 `json:"confidence,omitempty"`
+
+	FeatureData []FeatureData `json:"feature_data,omitempty"`
+	Entity      []EntityValue `json:"entity,omitempty"`
+	Error       string        `json:"error,omitempty"`
+
+	// Times are in epoch milliseconds.
+	DataStartTime      int64 `json:"data_start_time"`
+	DataEndTime        int64 `json:"data_end_time"`
+	ExecutionStartTime int64 `json:"execution_start_time"`
+	ExecutionEndTime   int64 `json:"execution_end_time"`
+}
+
+// Anomalous reports whether the result has a positive anomaly grade.
+func (r AnomalyResult) Anomalous() bool {
+	return r.AnomalyGrade != nil && *r.AnomalyGrade > 0
+}
+
+// FeatureData is the value of a feature in an interval.
+type FeatureData struct {
+	FeatureID   string  `json:"feature_id"`
+	FeatureName string  `json:"feature_name"`
+	Data        float64 `json:"data"`
+}
+
+// EntityValue is the value of a category field.
+type EntityValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,513 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package anomaly_detection
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	osparams "github.com/opensearch-project/opensearch-go/v5/internal/params"
+	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// CreateDetectorReq represents the request for the ad.create_detector operation.
+//
+// POST /_plugins/_anomaly_detection/detectors
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#create-anomaly-detector
+type CreateDetectorReq struct {
+	// Body specifies the typed request body. When non-nil, it is
+	// marshaled to JSON for the request payload.
+	Body *Detector
+
+	// BodyReader provides an escape hatch for sending a raw request
+	// body. It is used only when Body is nil.
+	BodyReader io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r CreateDetectorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(method, path, r.Body, r.BodyReader, nil, r.Header)
+}
+
+// UpdateDetectorReq represents the request for the ad.update_detector operation.
+// A detector can be updated only while its real-time and historical jobs
+// are stopped.
+//
+// PUT /_plugins/_anomaly_detection/detectors/{detector_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#update-detector
+type UpdateDetectorReq struct {
+	DetectorID string
+
+	// Body specifies the typed request body. When non-nil, it is
+	// marshaled to JSON for the request payload.
+	Body *Detector
+
+	// BodyReader provides an escape hatch for sending a raw request
+	// body. It is used only when Body is nil.
+	BodyReader io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *UpdateDetectorParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r UpdateDetectorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Required("UpdateDetectorReq.DetectorID", r.DetectorID))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest(method, path, r.Body, r.BodyReader, params, r.Header)
+}
+
+// UpdateDetectorParams represents query parameters for the UpdateDetectorReq.
+type UpdateDetectorParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// Refresh controls when the change is visible to search: `true`,
+	// `false`, or `wait_for`.
+	Refresh string
+
+	// IfSeqNo and IfPrimaryTerm make the update fail when the detector was
+	// changed since it was read.
+	IfSeqNo       *int64
+	IfPrimaryTerm *int64
+}
+
+func (r UpdateDetectorParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.Refresh != "" {
+		set("refresh", r.Refresh)
+	}
+
+	if r.IfSeqNo != nil {
+		set("if_seq_no", strconv.FormatInt(*r.IfSeqNo, 10))
+	}
+
+	if r.IfPrimaryTerm != nil {
+		set("if_primary_term", strconv.FormatInt(*r.IfPrimaryTerm, 10))
+	}
+
+	return params
+}
+
+// GetDetectorReq represents the request for the ad.get_detector operation.
+//
+// GET /_plugins/_anomaly_detection/detectors/{detector_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#get-detector
+type GetDetectorReq struct {
+	DetectorID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *GetDetectorParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r GetDetectorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Required("GetDetectorReq.DetectorID", r.DetectorID))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest[struct{}](method, path, nil, nil, params, r.Header)
+}
+
+// GetDetectorParams represents query parameters for the GetDetectorReq.
+type GetDetectorParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// Job includes the real-time job of the detector.
+	Job *bool
+	// Task includes the latest real-time and historical tasks.
+	Task *bool
+}
+
+func (r GetDetectorParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.Job != nil {
+		set("job", strconv.FormatBool(*r.Job))
+	}
+
+	if r.Task != nil {
+		set("task", strconv.FormatBool(*r.Task))
+	}
+
+	return params
+}
+
+// DetectorResp represents the response for the ad.create_detector,
+// ad.update_detector, and ad.get_detector operations.
+type DetectorResp struct {
+	ID          string   `json:"_id"`
+	Version     int64    `json:"_version"`
+	SeqNo       int64    `json:"_seq_no"`
+	PrimaryTerm int64    `json:"_primary_term"`
+	Detector    Detector `json:"anomaly_detector"`
+
+	// Set by GetDetector with the Job and Task params.
+	Job                    *DetectorJob  `json:"anomaly_detector_job,omitempty"`
+	RealtimeDetectionTask  *DetectorTask `json:"realtime_detection_task,omitempty"`
+	HistoricalAnalysisTask *DetectorTask `json:"historical_analysis_task,omitempty"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r DetectorResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r DetectorResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// DeleteDetectorReq represents the request for the ad.delete_detector operation.
+//
+// DELETE /_plugins/_anomaly_detection/detectors/{detector_id}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#delete-detector
+type DeleteDetectorReq struct {
+	DetectorID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r DeleteDetectorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Required("DeleteDetectorReq.DetectorID", r.DetectorID))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, nil, nil, r.Header)
+}
+
+// DeleteDetectorResp represents the response for the ad.delete_detector operation.
+type DeleteDetectorResp struct {
+	ID      string `json:"_id"`
+	Version int64  `json:"_version"`
+	Result  string `json:"result"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r DeleteDetectorResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r DeleteDetectorResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// SearchDetectorsReq represents the request for the ad.search_detectors operation.
+//
+// POST /_plugins/_anomaly_detection/detectors/_search
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#search-detector
+type SearchDetectorsReq struct {
+	// Body is a search request body, typically JSON-encoded.
+	Body io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r SearchDetectorsReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"), ospath.Lit("_search"))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest[struct{}](method, path, nil, r.Body, nil, r.Header)
+}
+
+// SearchDetectorsResp represents the response for the ad.search_detectors operation.
+type SearchDetectorsResp struct {
+	Took     int  `json:"took"`
+	TimedOut bool `json:"timed_out"`
+	Hits     struct {
+		Total *opensearchapi.SearchTotalHits `json:"total"`
+		Hits  []DetectorHit                  `json:"hits"`
+	} `json:"hits"`
+
+	response *opensearch.Response
+}
+
+// DetectorHit is a detector found by SearchDetectors.
+type DetectorHit struct {
+	ID          string   `json:"_id"`
+	SeqNo       *int64   `json:"_seq_no"`
+	PrimaryTerm *int64   `json:"_primary_term"`
+	Score       *float64 `json:"_score"`
+	Source      Detector `json:"_source"`
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r SearchDetectorsResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r SearchDetectorsResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// StartDetectorReq represents the request for the ad.start_detector operation.
+//
+// POST /_plugins/_anomaly_detection/detectors/{detector_id}/_start
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#start-detector-job
+type StartDetectorReq struct {
+	DetectorID string
+
+	// Body, when set, starts a historical analysis over a time range
+	// instead of the real-time job.
+	Body *HistoricalRange
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+}
+
+// HistoricalRange is the time range of a historical analysis, in epoch
+// milliseconds.
+type HistoricalRange struct {
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r StartDetectorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Required("StartDetectorReq.DetectorID", r.DetectorID), ospath.Lit("_start"))
+	if err != nil {
+		return nil, err
+	}
+	return newRequest(method, path, r.Body, nil, nil, r.Header)
+}
+
+// StopDetectorReq represents the request for the ad.stop_detector operation.
+//
+// POST /_plugins/_anomaly_detection/detectors/{detector_id}/_stop
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#stop-detector-job
+type StopDetectorReq struct {
+	DetectorID string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *StopDetectorParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r StopDetectorReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Required("StopDetectorReq.DetectorID", r.DetectorID), ospath.Lit("_stop"))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest[struct{}](method, path, nil, nil, params, r.Header)
+}
+
+// StopDetectorParams represents query parameters for the StopDetectorReq.
+type StopDetectorParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// Historical stops the historical analysis instead of the real-time job.
+	Historical *bool
+}
+
+func (r StopDetectorParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.Historical != nil {
+		set("historical", strconv.FormatBool(*r.Historical))
+	}
+
+	return params
+}
+
+// DetectorJobResp represents the response for the ad.start_detector and
+// ad.stop_detector operations. For a historical analysis, ID is the ID of
+// the task.
+type DetectorJobResp struct {
+	ID          string `json:"_id"`
+	Version     int64  `json:"_version"`
+	SeqNo       int64  `json:"_seq_no"`
+	PrimaryTerm int64  `json:"_primary_term"`
+
+	response *opensearch.Response
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r DetectorJobResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r DetectorJobResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// CreateDetector creates a detector.
+//
+// POST /_plugins/_anomaly_detection/detectors
+func (c *Client) CreateDetector(ctx context.Context, req CreateDetectorReq) (*DetectorResp, error) {
+	var resp DetectorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// UpdateDetector replaces a detector.
+//
+// PUT /_plugins/_anomaly_detection/detectors/{detector_id}
+func (c *Client) UpdateDetector(ctx context.Context, req UpdateDetectorReq) (*DetectorResp, error) {
+	var resp DetectorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPut, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// GetDetector retrieves a detector and, optionally, its job and tasks.
+//
+// GET /_plugins/_anomaly_detection/detectors/{detector_id}
+func (c *Client) GetDetector(ctx context.Context, req GetDetectorReq) (*DetectorResp, error) {
+	var resp DetectorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodGet, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// DeleteDetector deletes a stopped detector.
+//
+// DELETE /_plugins/_anomaly_detection/detectors/{detector_id}
+func (c *Client) DeleteDetector(ctx context.Context, req DeleteDetectorReq) (*DeleteDetectorResp, error) {
+	var resp DeleteDetectorResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodDelete, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// SearchDetectors searches the detectors.
+//
+// POST /_plugins/_anomaly_detection/detectors/_search
+func (c *Client) SearchDetectors(ctx context.Context, req *SearchDetectorsReq) (*SearchDetectorsResp, error) {
+	if req == nil {
+		req = &SearchDetectorsReq{}
+	}
+	var resp SearchDetectorsResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, *req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// StartDetector starts the real-time job of a detector or, with a Body, a
+// historical analysis.
+//
+// POST /_plugins/_anomaly_detection/detectors/{detector_id}/_start
+func (c *Client) StartDetector(ctx context.Context, req StartDetectorReq) (*DetectorJobResp, error) {
+	var resp DetectorJobResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// StopDetector stops the real-time job or the historical analysis of a
+// detector.
+//
+// POST /_plugins/_anomaly_detection/detectors/{detector_id}/_stop
+func (c *Client) StopDetector(ctx context.Context, req StopDetectorReq) (*DetectorJobResp, error) {
+	var resp DetectorJobResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
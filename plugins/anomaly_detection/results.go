@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package anomaly_detection
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	osparams "github.com/opensearch-project/opensearch-go/v5/internal/params"
+	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// SearchResultsReq represents the request for the ad.search_results operation.
+//
+// POST /_plugins/_anomaly_detection/detectors/results/_search
+//
+// POST /_plugins/_anomaly_detection/detectors/results/_search/{result_index}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#search-detector-result
+type SearchResultsReq struct {
+	// ResultIndex searches the custom result index of a detector as well
+	// as the default result indices.
+	ResultIndex string
+
+	// Body is a search request body, typically JSON-encoded.
+	Body io.Reader
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *SearchResultsParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r SearchResultsReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Lit("results"), ospath.Lit("_search"), ospath.Optional(r.ResultIndex))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest[struct{}](method, path, nil, r.Body, params, r.Header)
+}
+
+// SearchResultsParams represents query parameters for the SearchResultsReq.
+type SearchResultsParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// OnlyQueryCustomResultIndex searches ResultIndex only.
+	OnlyQueryCustomResultIndex *bool
+}
+
+func (r SearchResultsParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.OnlyQueryCustomResultIndex != nil {
+		set("only_query_custom_result_index", strconv.FormatBool(*r.OnlyQueryCustomResultIndex))
+	}
+
+	return params
+}
+
+// SearchResultsResp represents the response for the ad.search_results operation.
+type SearchResultsResp struct {
+	Took     int  `json:"took"`
+	TimedOut bool `json:"timed_out"`
+	Hits     struct {
+		Total *opensearchapi.SearchTotalHits `json:"total"`
+		Hits  []ResultHit                    `json:"hits"`
+	} `json:"hits"`
+	Aggregations map[string]json.RawMessage `json:"aggregations,omitempty"`
+
+	response *opensearch.Response
+}
+
+// ResultHit is an anomaly result found by SearchResults.
+type ResultHit struct {
+	Index  string        `json:"_index"`
+	ID     string        `json:"_id"`
+	Score  *float64      `json:"_score"`
+	Source AnomalyResult `json:"_source"`
+}
+
+// Anomalies returns the anomalous results of the response.
+func (r SearchResultsResp) Anomalies() []AnomalyResult {
+	var anomalies []AnomalyResult
+	for _, hit := range r.Hits.Hits {
+		if hit.Source.Anomalous() {
+			anomalies = append(anomalies, hit.Source)
+		}
+	}
+	return anomalies
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r SearchResultsResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r SearchResultsResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// ProfileReq represents the request for the ad.profile operation.
+//
+// GET /_plugins/_anomaly_detection/detectors/{detector_id}/_profile
+//
+// GET /_plugins/_anomaly_detection/detectors/{detector_id}/_profile/{types}
+//
+// See: https://docs.opensearch.org/latest/observing-your-data/ad/api/#profile-detector
+type ProfileReq struct {
+	DetectorID string
+
+	// Types selects the profile entries, such as state, error,
+	// init_progress, models, total_entities, and ad_task.
+	Types []string
+
+	// Header provides additional HTTP headers for the request.
+	Header http.Header
+
+	// Params holds optional query parameters for the request.
+	Params *ProfileParams
+}
+
+// GetRequest builds the HTTP request from the structured fields.
+func (r ProfileReq) GetRequest(method string) (*http.Request, error) {
+	path, err := ospath.Join(ospath.Lit("_plugins"), ospath.Lit("_anomaly_detection"), ospath.Lit("detectors"),
+		ospath.Required("ProfileReq.DetectorID", r.DetectorID), ospath.Lit("_profile"), ospath.List(r.Types))
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]string
+	if r.Params != nil {
+		params = r.Params.get()
+	}
+	return newRequest[struct{}](method, path, nil, nil, params, r.Header)
+}
+
+// ProfileParams represents query parameters for the ProfileReq.
+type ProfileParams struct {
+	opensearchapi.TimeoutParams
+	opensearchapi.DebugParams
+
+	// All returns every profile entry.
+	All *bool
+}
+
+func (r ProfileParams) get() map[string]string {
+	var params map[string]string
+	set := func(k, v string) {
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[k] = v
+	}
+	osparams.EncodeTimeout(r.TimeoutParams, set)
+	osparams.EncodeDebug(r.DebugParams, set)
+
+	if r.All != nil {
+		set("_all", strconv.FormatBool(*r.All))
+	}
+
+	return params
+}
+
+// ProfileResp represents the response for the ad.profile operation.
+type ProfileResp struct {
+	// State is one of the State constants.
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+
+	InitProgress *InitProgress  `json:"init_progress,omitempty"`
+	Models       []ModelProfile `json:"models,omitempty"`
+
+	ShingleSize      *int   `json:"shingle_size,omitempty"`
+	CoordinatingNode string `json:"coordinating_node,omitempty"`
+	TotalSizeInBytes *int64 `json:"total_size_in_bytes,omitempty"`
+	TotalEntities    *int64 `json:"total_entities,omitempty"`
+	ActiveEntities   *int64 `json:"active_entities,omitempty"`
+
+	ADTask json.RawMessage `json:"ad_task,omitempty"`
+
+	response *opensearch.Response
+}
+
+// InitProgress reports how far a detector is from producing results.
+type InitProgress struct {
+	// Percentage is formatted, as in "70%".
+	Percentage           string `json:"percentage"`
+	EstimatedMinutesLeft int    `json:"estimated_minutes_left"`
+	NeededShingles       int    `json:"needed_shingles"`
+}
+
+// ModelProfile is a model of a detector, per entity for high cardinality
+// detectors.
+type ModelProfile struct {
+	ModelID          string        `json:"model_id"`
+	ModelSizeInBytes int64         `json:"model_size_in_bytes"`
+	NodeID           string        `json:"node_id"`
+	Entity           []EntityValue `json:"entity,omitempty"`
+}
+
+// Inspect returns the raw OpenSearch response for debugging or advanced use.
+func (r ProfileResp) Inspect() Inspect {
+	return Inspect{Response: r.response}
+}
+
+// RawBody returns a fresh reader over the original response bytes,
+// useful when the typed response struct is incomplete for your use case.
+func (r ProfileResp) RawBody() io.Reader {
+	return rawBody(r.response)
+}
+
+// SearchResults searches the anomaly results.
+//
+// POST /_plugins/_anomaly_detection/detectors/results/_search
+func (c *Client) SearchResults(ctx context.Context, req *SearchResultsReq) (*SearchResultsResp, error) {
+	if req == nil {
+		req = &SearchResultsReq{}
+	}
+	var resp SearchResultsResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodPost, *req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
+
+// Profile reports the state, initialization progress, and models of a
+// detector.
+//
+// GET /_plugins/_anomaly_detection/detectors/{detector_id}/_profile
+func (c *Client) Profile(ctx context.Context, req ProfileReq) (*ProfileResp, error) {
+	var resp ProfileResp
+	var err error
+	if resp.response, err = request(ctx, c, http.MethodGet, req, &resp); err != nil {
+		return &resp, err
+	}
+	return &resp, nil
+}
@@ -14,7 +14,9 @@ It contains no exported symbols of its own. Each subpackage wraps a shared
 opensearch.Client to provide strongly-typed access to a single OpenSearch plugin's
 REST API, and is generated from the same OpenSearch API specification as the core
 opensearchapi package. Subpackages include knn, ml, ism, security, security_analytics,
-notifications, observability, and others.
+notifications, observability, and others. The alerting and anomaly_detection
+packages cover plugins the specification has no operations for and are written by
+hand to the same conventions.
 
 All plugin clients follow the same constructor pattern: create a shared
 opensearch.Client, then pass it to the plugin's NewClient. See the README.md in this
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package ism
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ManagedIndex is the ISM status of one index, as reported by ExplainPolicy.
+type ManagedIndex struct {
+	Index    string `json:"index"`
+	PolicyID string `json:"policy_id"`
+	// Enabled is false when ISM stopped managing the index, for example
+	// after an action exhausted its retries.
+	Enabled *bool `json:"enabled"`
+
+	State      *ExplainStep `json:"state"`
+	Action     *ExplainStep `json:"action"`
+	Step       *ExplainStep `json:"step"`
+	RetryInfo  *RetryInfo   `json:"retry_info"`
+	RolledOver *bool        `json:"rolled_over"`
+
+	// Info holds the plugin's latest message about the index.
+	Info map[string]any `json:"info"`
+}
+
+// ExplainStep is the current state, action, or step of a managed index.
+type ExplainStep struct {
+	Name            string `json:"name"`
+	StartTime       int64  `json:"start_time"`
+	Failed          bool   `json:"failed"`
+	ConsumedRetries int    `json:"consumed_retries"`
+	StepStatus      string `json:"step_status"`
+}
+
+// RetryInfo reports the retries of the current action.
+type RetryInfo struct {
+	Failed          bool `json:"failed"`
+	ConsumedRetries int  `json:"consumed_retries"`
+}
+
+// Message returns the plugin's latest message about the index.
+func (m ManagedIndex) Message() string {
+	msg, _ := m.Info["message"].(string)
+	return msg
+}
+
+// Stuck reports whether the index cannot progress without intervention: its
+// current action or step failed, or ISM disabled its management.
+func (m ManagedIndex) Stuck() bool {
+	return m.RetryInfo != nil && m.RetryInfo.Failed ||
+		m.Action != nil && m.Action.Failed ||
+		m.Step != nil && m.Step.StepStatus == "failed" ||
+		m.Enabled != nil && !*m.Enabled
+}
+
+// reason returns the failure reason of a stuck index, with index names
+// removed so that indices stuck for the same reason share it.
+func (m ManagedIndex) reason() string {
+	msg := m.Message()
+	if msg == "" {
+		switch {
+		case m.Enabled != nil && !*m.Enabled:
+			msg = "policy disabled"
+		case m.Action != nil:
+			msg = fmt.Sprintf("action %s failed", m.Action.Name)
+		default:
+			msg = "unknown failure"
+		}
+	}
+	msg = indexRef.ReplaceAllString(msg, "")
+	if cause, _ := m.Info["cause"].(string); cause != "" {
+		msg += ": " + cause
+	}
+	return strings.TrimSpace(msg)
+}
+
+// indexRef matches the "[index=name]" suffix of ISM messages.
+var indexRef = regexp.MustCompile(`\s*\[index=[^\]]*\]`)
+
+// StuckGroup lists the indices stuck for the same reason.
+type StuckGroup struct {
+	// Reason is the failure message, without index names.
+	Reason string
+	// Actions are the names of the failed actions, such as rollover.
+	Actions []string
+	Indices []string
+}
+
+// ExplainSummary summarizes the ISM status of a set of indices.
+type ExplainSummary struct {
+	// TotalManaged is the number of indices ISM manages.
+	TotalManaged int
+	// Indices are the managed indices, sorted by name.
+	Indices []ManagedIndex
+	// Unmanaged are the indices without a policy.
+	Unmanaged []string
+	// Stuck groups the stuck indices by failure reason, largest group
+	// first.
+	Stuck []StuckGroup
+}
+
+// String formats the stuck groups, one per line.
+func (s *ExplainSummary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d managed indices, %d stuck\n", s.TotalManaged, s.stuckCount())
+	for _, g := range s.Stuck {
+		fmt.Fprintf(&b, "%s (%d, actions: %s): %s\n", g.Reason, len(g.Indices), strings.Join(g.Actions, ","), strings.Join(g.Indices, ", "))
+	}
+	return b.String()
+}
+
+func (s *ExplainSummary) stuckCount() int {
+	n := 0
+	for _, g := range s.Stuck {
+		n += len(g.Indices)
+	}
+	return n
+}
+
+// ExplainSummary explains the ISM status of the indices, or of every index
+// when none is given, and groups the stuck ones by why they are stuck.
+func (c *Client) ExplainSummary(ctx context.Context, indices ...string) (*ExplainSummary, error) {
+	resp, err := c.ExplainPolicy(ctx, &ExplainPolicyReq{Indices: indices})
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]json.RawMessage
+	if body := resp.RawBody(); body != nil {
+		if err := json.NewDecoder(body).Decode(&entries); err != nil {
+			return nil, fmt.Errorf("ism: decode explain response: %w", err)
+		}
+	}
+	return summarize(entries)
+}
+
+func summarize(entries map[string]json.RawMessage) (*ExplainSummary, error) {
+	summary := &ExplainSummary{}
+	groups := make(map[string]*StuckGroup)
+	for name, raw := range entries {
+		if name == "total_managed_indices" {
+			if err := json.Unmarshal(raw, &summary.TotalManaged); err != nil {
+				return nil, fmt.Errorf("ism: decode total_managed_indices: %w", err)
+			}
+			continue
+		}
+		var index ManagedIndex
+		if err := json.Unmarshal(raw, &index); err != nil {
+			return nil, fmt.Errorf("ism: decode explain of %s: %w", name, err)
+		}
+		if index.Index == "" {
+			index.Index = name
+		}
+		if index.PolicyID == "" {
+			summary.Unmanaged = append(summary.Unmanaged, name)
+			continue
+		}
+		summary.Indices = append(summary.Indices, index)
+
+		if !index.Stuck() {
+			continue
+		}
+		reason := index.reason()
+		g := groups[reason]
+		if g == nil {
+			g = &StuckGroup{Reason: reason}
+			groups[reason] = g
+		}
+		g.Indices = append(g.Indices, index.Index)
+		if index.Action != nil && index.Action.Name != "" && !slices.Contains(g.Actions, index.Action.Name) {
+			g.Actions = append(g.Actions, index.Action.Name)
+		}
+	}
+
+	sort.Slice(summary.Indices, func(i, j int) bool { return summary.Indices[i].Index < summary.Indices[j].Index })
+	sort.Strings(summary.Unmanaged)
+	for _, g := range groups {
+		sort.Strings(g.Indices)
+		sort.Strings(g.Actions)
+		summary.Stuck = append(summary.Stuck, *g)
+	}
+	sort.Slice(summary.Stuck, func(i, j int) bool {
+		a, b := summary.Stuck[i], summary.Stuck[j]
+		if len(a.Indices) != len(b.Indices) {
+			return len(a.Indices) > len(b.Indices)
+		}
+		return a.Reason < b.Reason
+	})
+	return summary, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package ism_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/ism"
+)
+
+const explainResponse = `{
+	"logs-000001": {
+		"index.plugins.index_state_management.policy_id": "logs",
+		"index": "logs-000001", "policy_id": "logs", "enabled": true,
+		"state": {"name": "hot", "start_time": 1},
+		"action": {"name": "rollover", "start_time": 2, "index": 0, "failed": true, "consumed_retries": 3},
+		"step": {"name": "attempt_rollover", "start_time": 3, "step_status": "failed"},
+		"retry_info": {"failed": true, "consumed_retries": 3},
+		"info": {"message": "Missing rollover_alias index setting [index=logs-000001]"}
+	},
+	"logs-000002": {
+		"index": "logs-000002", "policy_id": "logs", "enabled": true,
+		"action": {"name": "rollover", "failed": true},
+		"retry_info": {"failed": true},
+		"info": {"message": "Missing rollover_alias index setting [index=logs-000002]"}
+	},
+	"metrics-1": {
+		"index": "metrics-1", "policy_id": "metrics", "enabled": false,
+		"action": {"name": "snapshot", "failed": false},
+		"info": {}
+	},
+	"metrics-2": {
+		"index": "metrics-2", "policy_id": "metrics", "enabled": true,
+		"action": {"name": "snapshot", "failed": true},
+		"step": {"name": "attempt_snapshot", "step_status": "failed"},
+		"info": {"message": "Failed to create snapshot [index=metrics-2]", "cause": "[backups] missing"}
+	},
+	"logs-000003": {
+		"index": "logs-000003", "policy_id": "logs", "enabled": true,
+		"action": {"name": "rollover", "failed": false},
+		"step": {"name": "attempt_rollover", "step_status": "condition_not_met"},
+		"info": {"message": "Pending rollover of index [index=logs-000003]"}
+	},
+	"plain": {"index.plugins.index_state_management.policy_id": null},
+	"total_managed_indices": 5
+}`
+
+func TestExplainSummary(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/_plugins/_ism/explain") {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		_, _ = io.WriteString(w, explainResponse)
+	}))
+	t.Cleanup(ts.Close)
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+
+	summary, err := ism.NewClient(osClient).ExplainSummary(t.Context(), "logs-*", "metrics-*", "plain")
+	require.NoError(t, err)
+
+	require.Equal(t, 5, summary.TotalManaged)
+	require.Len(t, summary.Indices, 5)
+	require.Equal(t, "logs-000001", summary.Indices[0].Index)
+	require.Equal(t, "hot", summary.Indices[0].State.Name)
+	require.Equal(t, []string{"plain"}, summary.Unmanaged)
+	require.Equal(t, []ism.StuckGroup{
+		{Reason: "Missing rollover_alias index setting", Actions: []string{"rollover"}, Indices: []string{"logs-000001", "logs-000002"}},
+		{Reason: "Failed to create snapshot: [backups] missing", Actions: []string{"snapshot"}, Indices: []string{"metrics-2"}},
+		{Reason: "policy disabled", Actions: []string{"snapshot"}, Indices: []string{"metrics-1"}},
+	}, summary.Stuck)
+	require.Equal(t, `5 managed indices, 4 stuck
+Missing rollover_alias index setting (2, actions: rollover): logs-000001, logs-000002
+Failed to create snapshot: [backups] missing (1, actions: snapshot): metrics-2
+policy disabled (1, actions: snapshot): metrics-1
+`, summary.String())
+}
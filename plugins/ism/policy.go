@@ -0,0 +1,488 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package ism
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Policy is an ISM policy: a state machine each managed index moves through,
+// starting in DefaultState.
+type Policy struct {
+	Description  string  `json:"description,omitempty"`
+	DefaultState string  `json:"default_state"`
+	States       []State `json:"states"`
+	// ISMTemplates apply the policy to newly created indices matching their
+	// patterns.
+	ISMTemplates      Templates                           `json:"ism_template,omitempty"`
+	ErrorNotification *opensearchapi.ISMErrorNotification `json:"error_notification,omitempty"`
+}
+
+// State is a policy state. Its actions run in order; once they complete,
+// the first transition whose conditions hold moves the index on.
+type State struct {
+	Name        string       `json:"name"`
+	Actions     []Action     `json:"actions"`
+	Transitions []Transition `json:"transitions"`
+
+	// Terminal marks a final state that keeps its indices for good, so
+	// [Policy.Validate] accepts it without transitions or a delete action.
+	// It is not sent to the server.
+	Terminal bool `json:"-"`
+}
+
+// Transition moves an index to StateName once its condition holds. A
+// transition without conditions is taken as soon as the actions complete.
+type Transition struct {
+	StateName  string      `json:"state_name"`
+	Conditions *Conditions `json:"conditions,omitempty"`
+}
+
+// Conditions of a transition. The plugin accepts a single condition per
+// transition.
+type Conditions struct {
+	MinIndexAge    string `json:"min_index_age,omitempty"`
+	MinRolloverAge string `json:"min_rollover_age,omitempty"`
+	MinDocCount    *int64 `json:"min_doc_count,omitempty"`
+	MinSize        string `json:"min_size,omitempty"`
+	Cron           *Cron  `json:"cron,omitempty"`
+}
+
+// Cron is a cron schedule condition.
+type Cron struct {
+	Expression string `json:"expression"`
+	Timezone   string `json:"timezone"`
+}
+
+// Template applies the policy to new indices matching IndexPatterns. Among
+// several matching templates, the one with the highest Priority wins.
+type Template struct {
+	IndexPatterns   []string `json:"index_patterns"`
+	Priority        int      `json:"priority"`
+	LastUpdatedTime int64    `json:"last_updated_time,omitempty"`
+}
+
+// Templates is the ism_template of a policy. It decodes from both the
+// single-object and the array form the plugin accepts.
+type Templates []Template
+
+// UnmarshalJSON decodes a template object or an array of them.
+func (t *Templates) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*t = nil
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var one Template
+		if err := json.Unmarshal(data, &one); err != nil {
+			return err
+		}
+		*t = Templates{one}
+		return nil
+	}
+	var many []Template
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// Action is one step of a state. Exactly one of the action fields is set;
+// Timeout and Retry apply to it.
+type Action struct {
+	Timeout string       `json:"timeout,omitempty"`
+	Retry   *ActionRetry `json:"retry,omitempty"`
+
+	Rollover      *RolloverAction      `json:"rollover,omitempty"`
+	ForceMerge    *ForceMergeAction    `json:"force_merge,omitempty"`
+	ReplicaCount  *ReplicaCountAction  `json:"replica_count,omitempty"`
+	Snapshot      *SnapshotAction      `json:"snapshot,omitempty"`
+	Delete        *EmptyAction         `json:"delete,omitempty"`
+	ReadOnly      *EmptyAction         `json:"read_only,omitempty"`
+	ReadWrite     *EmptyAction         `json:"read_write,omitempty"`
+	Open          *EmptyAction         `json:"open,omitempty"`
+	Close         *EmptyAction         `json:"close,omitempty"`
+	IndexPriority *IndexPriorityAction `json:"index_priority,omitempty"`
+	Allocation    *AllocationAction    `json:"allocation,omitempty"`
+
+	// Actions without a typed field, such as shrink, rollup, transform,
+	// alias, and notification, are set here by name. Names the plugin does
+	// not know are reported by Validate.
+	Other map[string]json.RawMessage `json:"-"`
+}
+
+// ActionRetry configures the retries of a failed action.
+type ActionRetry struct {
+	Count int `json:"count"`
+	// Backoff is "exponential", the default, "constant", or "linear".
+	Backoff string `json:"backoff,omitempty"`
+	Delay   string `json:"delay,omitempty"`
+}
+
+// RolloverAction rolls the index's alias or data stream over once one of
+// the conditions holds, or at once when none is set.
+type RolloverAction struct {
+	MinSize             string `json:"min_size,omitempty"`
+	MinPrimaryShardSize string `json:"min_primary_shard_size,omitempty"`
+	MinDocCount         *int64 `json:"min_doc_count,omitempty"`
+	MinIndexAge         string `json:"min_index_age,omitempty"`
+	CopyAlias           *bool  `json:"copy_alias,omitempty"`
+}
+
+// ForceMergeAction merges the index down to MaxNumSegments segments.
+type ForceMergeAction struct {
+	MaxNumSegments int `json:"max_num_segments"`
+}
+
+// ReplicaCountAction sets the number of replicas.
+type ReplicaCountAction struct {
+	NumberOfReplicas int `json:"number_of_replicas"`
+}
+
+// SnapshotAction snapshots the index into Repository.
+type SnapshotAction struct {
+	Repository string `json:"repository"`
+	Snapshot   string `json:"snapshot"`
+}
+
+// IndexPriorityAction sets the recovery priority of the index.
+type IndexPriorityAction struct {
+	Priority int `json:"priority"`
+}
+
+// AllocationAction sets shard allocation filters on the index.
+type AllocationAction struct {
+	Require map[string]string `json:"require,omitempty"`
+	Include map[string]string `json:"include,omitempty"`
+	Exclude map[string]string `json:"exclude,omitempty"`
+	WaitFor *bool             `json:"wait_for,omitempty"`
+}
+
+// EmptyAction is an action without parameters, such as delete.
+type EmptyAction struct{}
+
+// typedActions are the names of the actions with a field in Action.
+var typedActions = []string{
+	"rollover", "force_merge", "replica_count", "snapshot", "delete", "read_only", "read_write",
+	"open", "close", "index_priority", "allocation",
+}
+
+// knownActions are the action names of the ISM plugin.
+var knownActions = []string{
+	"rollover", "force_merge", "replica_count", "snapshot", "delete", "read_only", "read_write",
+	"open", "close", "index_priority", "allocation", "shrink", "rollup", "transform", "alias",
+	"notification", "convert_index_to_remote", "stop_replication",
+}
+
+// actionAlias has the fields of Action without its methods.
+type actionAlias Action
+
+// MarshalJSON encodes the action together with the actions in Other.
+func (a Action) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(actionAlias(a))
+	if err != nil || len(a.Other) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, v := range a.Other {
+		if _, ok := fields[name]; ok {
+			return nil, fmt.Errorf("ism: action %s is set twice", name)
+		}
+		fields[name] = v
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes the action, keeping the actions without a typed
+// field in Other.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var typed actionAlias
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name, v := range fields {
+		if name == "timeout" || name == "retry" || slices.Contains(typedActions, name) {
+			continue
+		}
+		if typed.Other == nil {
+			typed.Other = make(map[string]json.RawMessage)
+		}
+		typed.Other[name] = v
+	}
+	*a = Action(typed)
+	return nil
+}
+
+// names returns the names of the actions set, typed ones first.
+func (a actionAlias) names() []string {
+	var names []string
+	for name, set := range map[string]bool{
+		"rollover":       a.Rollover != nil,
+		"force_merge":    a.ForceMerge != nil,
+		"replica_count":  a.ReplicaCount != nil,
+		"snapshot":       a.Snapshot != nil,
+		"delete":         a.Delete != nil,
+		"read_only":      a.ReadOnly != nil,
+		"read_write":     a.ReadWrite != nil,
+		"open":           a.Open != nil,
+		"close":          a.Close != nil,
+		"index_priority": a.IndexPriority != nil,
+		"allocation":     a.Allocation != nil,
+	} {
+		if set {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	other := make([]string, 0, len(a.Other))
+	for name := range a.Other {
+		other = append(other, name)
+	}
+	sort.Strings(other)
+	return append(names, other...)
+}
+
+// Name returns the name of the action, or "" when none or several are set.
+func (a Action) Name() string {
+	if names := actionAlias(a).names(); len(names) == 1 {
+		return names[0]
+	}
+	return ""
+}
+
+// ProblemKind classifies a [Problem] found by [Policy.Validate].
+type ProblemKind string
+
+// Problem kinds.
+const (
+	ProblemNoDefaultState     ProblemKind = "no_default_state"
+	ProblemInvalidState       ProblemKind = "invalid_state"
+	ProblemDuplicateState     ProblemKind = "duplicate_state"
+	ProblemUnknownState       ProblemKind = "unknown_state"
+	ProblemUnreachableState   ProblemKind = "unreachable_state"
+	ProblemMissingTransitions ProblemKind = "missing_transitions"
+	ProblemUnknownAction      ProblemKind = "unknown_action"
+	ProblemInvalidAction      ProblemKind = "invalid_action"
+	ProblemInvalidTransition  ProblemKind = "invalid_transition"
+	ProblemInvalidTemplate    ProblemKind = "invalid_template"
+)
+
+// Problem is a defect of a policy.
+type Problem struct {
+	Kind ProblemKind
+	// State is the state the problem is in, if any.
+	State   string
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.State == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("state %q: %s", p.State, p.Message)
+}
+
+// ValidationError lists the problems of a policy.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return "ism: invalid policy: " + strings.Join(msgs, "; ")
+}
+
+// Has reports whether the error includes a problem of the kind.
+func (e *ValidationError) Has(kind ProblemKind) bool {
+	return slices.ContainsFunc(e.Problems, func(p Problem) bool { return p.Kind == kind })
+}
+
+// Validate checks the policy's state machine before it is stored, so that
+// mistakes surface here instead of as indices stuck in ExplainPolicy. It
+// reports transitions to unknown states, states the default state cannot
+// reach, states an index can never leave because they neither transition
+// nor delete the index, unless marked [State.Terminal], unknown or
+// malformed actions, and malformed transitions and templates. It returns a
+// [*ValidationError] listing every problem, or nil.
+func (p *Policy) Validate() error {
+	var problems []Problem
+	add := func(kind ProblemKind, state, format string, args ...any) {
+		problems = append(problems, Problem{Kind: kind, State: state, Message: fmt.Sprintf(format, args...)})
+	}
+
+	states := make(map[string]*State, len(p.States))
+	for i := range p.States {
+		s := &p.States[i]
+		if s.Name == "" {
+			add(ProblemInvalidState, "", "state %d has no name", i)
+			continue
+		}
+		if _, ok := states[s.Name]; ok {
+			add(ProblemDuplicateState, s.Name, "defined more than once")
+			continue
+		}
+		states[s.Name] = s
+	}
+	if p.DefaultState == "" {
+		add(ProblemNoDefaultState, "", "no default state")
+	} else if _, ok := states[p.DefaultState]; !ok {
+		add(ProblemNoDefaultState, "", "default state %q is not defined", p.DefaultState)
+	}
+
+	for i := range p.States {
+		s := &p.States[i]
+		if s.Name == "" || states[s.Name] != s {
+			continue
+		}
+		deletes := validateActions(s, add)
+		for j, t := range s.Transitions {
+			if _, ok := states[t.StateName]; !ok {
+				add(ProblemUnknownState, s.Name, "transition %d targets undefined state %q", j, t.StateName)
+			}
+			if n := t.Conditions.count(); n > 1 {
+				add(ProblemInvalidTransition, s.Name, "transition %d has %d conditions; only one is allowed", j, n)
+			}
+			if j < len(s.Transitions)-1 && t.Conditions.count() == 0 {
+				add(ProblemInvalidTransition, s.Name, "transition %d has no conditions, so the transitions after it are never taken", j)
+			}
+		}
+		switch {
+		case deletes && len(s.Transitions) > 0:
+			add(ProblemInvalidTransition, s.Name, "transitions after a delete action are never taken")
+		case !deletes && len(s.Transitions) == 0 && !s.Terminal:
+			add(ProblemMissingTransitions, s.Name, "no transitions and no delete action; indices stay in this state forever")
+		}
+	}
+
+	if _, ok := states[p.DefaultState]; ok {
+		reached := map[string]bool{p.DefaultState: true}
+		queue := []string{p.DefaultState}
+		for len(queue) > 0 {
+			s := states[queue[0]]
+			queue = queue[1:]
+			for _, t := range s.Transitions {
+				if _, ok := states[t.StateName]; ok && !reached[t.StateName] {
+					reached[t.StateName] = true
+					queue = append(queue, t.StateName)
+				}
+			}
+		}
+		for _, s := range p.States {
+			if s.Name != "" && !reached[s.Name] {
+				add(ProblemUnreachableState, s.Name, "not reachable from default state %q", p.DefaultState)
+			}
+		}
+	}
+
+	for i, t := range p.ISMTemplates {
+		if len(t.IndexPatterns) == 0 {
+			add(ProblemInvalidTemplate, "", "ism_template %d has no index patterns", i)
+		}
+		if t.Priority < 0 {
+			add(ProblemInvalidTemplate, "", "ism_template %d has a negative priority", i)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateActions checks the actions of a state and reports whether the
+// state deletes the index.
+func validateActions(s *State, add func(kind ProblemKind, state, format string, args ...any)) bool {
+	deletes := false
+	for i, a := range s.Actions {
+		if deletes {
+			add(ProblemInvalidAction, s.Name, "action %d follows a delete action", i)
+		}
+		names := actionAlias(a).names()
+		for _, name := range names {
+			if !slices.Contains(knownActions, name) {
+				add(ProblemUnknownAction, s.Name, "action %d: unknown action %q", i, name)
+			}
+		}
+		switch len(names) {
+		case 0:
+			add(ProblemInvalidAction, s.Name, "action %d sets no action", i)
+			continue
+		case 1:
+		default:
+			add(ProblemInvalidAction, s.Name, "action %d sets several actions: %s", i, strings.Join(names, ", "))
+			continue
+		}
+
+		switch {
+		case a.Delete != nil:
+			deletes = true
+		case a.Snapshot != nil && (a.Snapshot.Repository == "" || a.Snapshot.Snapshot == ""):
+			add(ProblemInvalidAction, s.Name, "action %d: snapshot requires a repository and a snapshot name", i)
+		case a.ForceMerge != nil && a.ForceMerge.MaxNumSegments <= 0:
+			add(ProblemInvalidAction, s.Name, "action %d: force_merge requires a positive max_num_segments", i)
+		case a.ReplicaCount != nil && a.ReplicaCount.NumberOfReplicas < 0:
+			add(ProblemInvalidAction, s.Name, "action %d: replica_count must not be negative", i)
+		}
+		if a.Retry != nil && a.Retry.Count < 0 {
+			add(ProblemInvalidAction, s.Name, "action %d: retry count must not be negative", i)
+		}
+	}
+	return deletes
+}
+
+func (c *Conditions) count() int {
+	if c == nil {
+		return 0
+	}
+	n := 0
+	for _, set := range []bool{c.MinIndexAge != "", c.MinRolloverAge != "", c.MinDocCount != nil, c.MinSize != "", c.Cron != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// PutTypedPolicy validates the policy and creates or replaces it under
+// policyID. Params may set IfSeqNo and IfPrimaryTerm to update an existing
+// policy.
+func (c *Client) PutTypedPolicy(ctx context.Context, policyID string, policy *Policy, params *PutPolicyParams) (*PutPolicyResp, error) {
+	if policy == nil {
+		return nil, errors.New("ism: policy is nil")
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(struct {
+		Policy *Policy `json:"policy"`
+	}{policy})
+	if err != nil {
+		return nil, err
+	}
+	return c.Policy.PutPolicy(ctx, PutPolicyReq{PolicyID: policyID, Body: bytes.NewReader(body), Params: params})
+}
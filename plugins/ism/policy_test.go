@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package ism_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/ism"
+)
+
+func hotWarmDelete() *ism.Policy {
+	return &ism.Policy{
+		Description:  "hot-warm-delete",
+		DefaultState: "hot",
+		States: []ism.State{
+			{
+				Name:        "hot",
+				Actions:     []ism.Action{{Retry: &ism.ActionRetry{Count: 3, Delay: "1m"}, Rollover: &ism.RolloverAction{MinSize: "50gb"}}},
+				Transitions: []ism.Transition{{StateName: "warm", Conditions: &ism.Conditions{MinIndexAge: "7d"}}},
+			},
+			{
+				Name: "warm",
+				Actions: []ism.Action{
+					{ReplicaCount: &ism.ReplicaCountAction{NumberOfReplicas: 1}},
+					{ForceMerge: &ism.ForceMergeAction{MaxNumSegments: 1}},
+					{Snapshot: &ism.SnapshotAction{Repository: "backups", Snapshot: "logs"}},
+				},
+				Transitions: []ism.Transition{{StateName: "delete", Conditions: &ism.Conditions{MinIndexAge: "30d"}}},
+			},
+			{
+				Name:    "delete",
+				Actions: []ism.Action{{Delete: &ism.EmptyAction{}}},
+			},
+		},
+		ISMTemplates: ism.Templates{{IndexPatterns: []string{"logs-*"}, Priority: 100}},
+	}
+}
+
+func TestPolicyJSON(t *testing.T) {
+	t.Parallel()
+
+	policy := hotWarmDelete()
+	policy.States[1].Actions = append(policy.States[1].Actions, ism.Action{
+		Timeout: "1h",
+		Other:   map[string]json.RawMessage{"shrink": json.RawMessage(`{"num_new_shards":1}`)},
+	})
+	require.NoError(t, policy.Validate())
+
+	data, err := json.Marshal(policy)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"description":"hot-warm-delete","default_state":"hot",
+		"states":[
+			{"name":"hot","actions":[{"retry":{"count":3,"delay":"1m"},"rollover":{"min_size":"50gb"}}],
+			 "transitions":[{"state_name":"warm","conditions":{"min_index_age":"7d"}}]},
+			{"name":"warm","actions":[
+				{"replica_count":{"number_of_replicas":1}},
+				{"force_merge":{"max_num_segments":1}},
+				{"snapshot":{"repository":"backups","snapshot":"logs"}},
+				{"timeout":"1h","shrink":{"num_new_shards":1}}],
+			 "transitions":[{"state_name":"delete","conditions":{"min_index_age":"30d"}}]},
+			{"name":"delete","actions":[{"delete":{}}],"transitions":null}],
+		"ism_template":[{"index_patterns":["logs-*"],"priority":100}]}`, string(data))
+
+	var decoded ism.Policy
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, "shrink", decoded.States[1].Actions[3].Name())
+	require.Equal(t, "rollover", decoded.States[0].Actions[0].Name())
+
+	// ism_template may also be a single object.
+	require.NoError(t, json.Unmarshal([]byte(`{"ism_template":{"index_patterns":["a-*"],"priority":1}}`), &decoded))
+	require.Equal(t, ism.Templates{{IndexPatterns: []string{"a-*"}, Priority: 1}}, decoded.ISMTemplates)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	var policy ism.Policy
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"default_state":"hot",
+		"states":[
+			{"name":"hot","actions":[{"rollover":{"min_size":"1gb"}}],"transitions":[
+				{"state_name":"wram"},
+				{"state_name":"delete","conditions":{"min_index_age":"1d","min_doc_count":5}}]},
+			{"name":"warm","actions":[{"force_merg":{"max_num_segments":1}},{"snapshot":{"repository":"r"}}],"transitions":[]},
+			{"name":"delete","actions":[{"delete":{}},{"read_only":{}}],"transitions":[{"state_name":"hot"}]},
+			{"name":"delete","actions":[]}
+		],
+		"ism_template":[{"index_patterns":[]}]
+	}`), &policy))
+
+	err := policy.Validate()
+	var verr *ism.ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	kinds := map[ism.ProblemKind][]string{}
+	for _, p := range verr.Problems {
+		kinds[p.Kind] = append(kinds[p.Kind], p.String())
+	}
+	require.Equal(t, map[ism.ProblemKind][]string{
+		ism.ProblemDuplicateState: {`state "delete": defined more than once`},
+		ism.ProblemUnknownState:   {`state "hot": transition 0 targets undefined state "wram"`},
+		ism.ProblemInvalidTransition: {
+			`state "hot": transition 0 has no conditions, so the transitions after it are never taken`,
+			`state "hot": transition 1 has 2 conditions; only one is allowed`,
+			`state "delete": transitions after a delete action are never taken`,
+		},
+		ism.ProblemUnknownAction: {`state "warm": action 0: unknown action "force_merg"`},
+		ism.ProblemInvalidAction: {
+			`state "warm": action 1: snapshot requires a repository and a snapshot name`,
+			`state "delete": action 1 follows a delete action`,
+		},
+		ism.ProblemMissingTransitions: {`state "warm": no transitions and no delete action; indices stay in this state forever`},
+		ism.ProblemUnreachableState:   {`state "warm": not reachable from default state "hot"`},
+		ism.ProblemInvalidTemplate:    {`ism_template 0 has no index patterns`},
+	}, kinds)
+	require.True(t, verr.Has(ism.ProblemUnknownAction))
+	require.ErrorContains(t, err, "ism: invalid policy: ")
+
+	require.ErrorContains(t, (&ism.Policy{States: []ism.State{{Name: "a", Actions: []ism.Action{{}}}}}).Validate(), "no default state")
+	require.ErrorContains(t, (&ism.Policy{DefaultState: "a", States: []ism.State{{
+		Name:    "a",
+		Actions: []ism.Action{{Delete: &ism.EmptyAction{}, Close: &ism.EmptyAction{}}},
+	}}}).Validate(), "sets several actions: close, delete")
+
+	// A final state that neither transitions nor deletes must be marked
+	// terminal, and the marker is not sent.
+	hotWarm := hotWarmDelete()
+	hotWarm.States = hotWarm.States[:2]
+	hotWarm.States[1].Transitions = nil
+	err = hotWarm.Validate()
+	require.ErrorAs(t, err, &verr)
+	require.True(t, verr.Has(ism.ProblemMissingTransitions))
+	hotWarm.States[1].Terminal = true
+	require.NoError(t, hotWarm.Validate())
+	body, err := json.Marshal(hotWarm.States[1])
+	require.NoError(t, err)
+	require.NotContains(t, string(body), "erminal")
+}
+
+func TestPutTypedPolicy(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_plugins/_ism/policies/logs" {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, `{"_id":"logs","_version":1,"_seq_no":0,"_primary_term":1}`)
+	}))
+	t.Cleanup(ts.Close)
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	client := ism.NewClient(osClient)
+
+	resp, err := client.PutTypedPolicy(t.Context(), "logs", hotWarmDelete(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "logs", *resp.ID)
+
+	var sent struct {
+		Policy ism.Policy `json:"policy"`
+	}
+	require.NoError(t, json.Unmarshal(body, &sent))
+	require.Equal(t, "hot", sent.Policy.DefaultState)
+	require.Len(t, sent.Policy.States, 3)
+
+	invalid := hotWarmDelete()
+	invalid.DefaultState = "cold"
+	_, err = client.PutTypedPolicy(t.Context(), "logs", invalid, nil)
+	require.ErrorContains(t, err, `default state "cold" is not defined`)
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package knn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// VectorDoc is a document indexed by [Client.IndexVectors].
+type VectorDoc struct {
+	// ID is the document ID. Empty lets OpenSearch generate one.
+	ID string
+	// Vector is written to the request's vector field.
+	Vector []float32
+	// Source holds the other fields of the document as a JSON object. It
+	// must not contain the vector field.
+	Source json.RawMessage
+}
+
+// IndexVectorsReq represents the request for [Client.IndexVectors].
+type IndexVectorsReq struct {
+	// Index is the target index.
+	Index string
+	// Field is the knn_vector field the vectors are written to.
+	Field string
+	Docs  []VectorDoc
+
+	Header http.Header
+	Params *opensearchapi.BulkParams
+}
+
+// IndexVectors indexes the documents with a single bulk request. The vectors
+// are encoded directly into the request body in their shortest float32 form,
+// without reflection or float64 rounding noise.
+//
+// When some documents fail, the response is returned with an
+// [*opensearchapi.PartialBulkError] listing them.
+func (c *Client) IndexVectors(ctx context.Context, req IndexVectorsReq) (*opensearchapi.BulkResp, error) {
+	if req.Index == "" || req.Field == "" {
+		return nil, errors.New("knn: index vectors requires an index and a field")
+	}
+
+	field, err := json.Marshal(req.Field)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	for i, doc := range req.Docs {
+		if body, err = appendVectorDoc(body, field, doc); err != nil {
+			return nil, fmt.Errorf("knn: document %d: %w", i, err)
+		}
+	}
+
+	var resp opensearchapi.BulkResp
+	bulk := opensearchapi.BulkReq{Index: req.Index, Body: bytes.NewReader(body), Header: req.Header, Params: req.Params}
+	if _, err := request(ctx, c, http.MethodPost, bulk, &resp); err != nil {
+		return &resp, err
+	}
+	if failures := resp.BulkItemFailures(); failures != nil {
+		return &resp, failures
+	}
+	return &resp, nil
+}
+
+// appendVectorDoc appends the action and source lines of doc.
+func appendVectorDoc(dst, field []byte, doc VectorDoc) ([]byte, error) {
+	dst = append(dst, `{"index":{`...)
+	if doc.ID != "" {
+		id, err := json.Marshal(doc.ID)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, `"_id":`...)
+		dst = append(dst, id...)
+	}
+	dst = append(dst, "}}\n{"...)
+	dst = append(dst, field...)
+	dst = append(dst, ':')
+
+	var err error
+	if dst, err = AppendVector(dst, doc.Vector); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Source) > 0 {
+		var src bytes.Buffer
+		if err := json.Compact(&src, doc.Source); err != nil {
+			return nil, err
+		}
+		obj := src.Bytes()
+		if obj[0] != '{' {
+			return nil, errors.New("source is not a JSON object")
+		}
+		// Splice the other fields in after the vector.
+		if fields := obj[1 : len(obj)-1]; len(fields) > 0 {
+			dst = append(dst, ',')
+			dst = append(dst, fields...)
+		}
+	}
+	return append(dst, "}\n"...), nil
+}
+
+// AppendVector appends v to dst as a JSON array, writing each component in
+// the shortest form that parses back to the same float32. NaN and infinite
+// components are an error, since JSON cannot represent them.
+func AppendVector(dst []byte, v []float32) ([]byte, error) {
+	dst = append(dst, '[')
+	for i, f := range v {
+		if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+			return nil, fmt.Errorf("knn: vector component %d is %v", i, f)
+		}
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = strconv.AppendFloat(dst, float64(f), 'g', -1, 32)
+	}
+	return append(dst, ']'), nil
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package knn_test
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/knn"
+)
+
+func TestAppendVector(t *testing.T) {
+	t.Parallel()
+
+	v := []float32{0.1, -2, 1e-7, 3.4028235e38, 0}
+	data, err := knn.AppendVector([]byte("x"), v)
+	require.NoError(t, err)
+	require.Equal(t, `x[0.1,-2,1e-07,3.4028235e+38,0]`, string(data))
+
+	var decoded []float32
+	require.NoError(t, json.Unmarshal(data[1:], &decoded))
+	require.Equal(t, v, decoded)
+
+	_, err = knn.AppendVector(nil, []float32{1, float32(math.NaN())})
+	require.ErrorContains(t, err, "component 1")
+	_, err = knn.AppendVector(nil, []float32{float32(math.Inf(-1))})
+	require.Error(t, err)
+}
+
+func TestIndexVectors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		path string
+		body string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/_bulk") {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		path, body = r.URL.Path, string(data)
+		mu.Unlock()
+		_, _ = io.WriteString(w, `{"took":3,"errors":true,"items":[
+			{"index":{"_index":"vectors","_id":"a","status":201,"result":"created"}},
+			{"index":{"_index":"vectors","_id":"x","status":400,"error":{"type":"mapper_parsing_exception","reason":"wrong dimension"}}}]}`)
+	}))
+	t.Cleanup(ts.Close)
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	client := knn.NewClient(osClient)
+
+	resp, err := client.IndexVectors(t.Context(), knn.IndexVectorsReq{
+		Index: "vectors",
+		Field: "embedding",
+		Docs: []knn.VectorDoc{
+			{ID: "a", Vector: []float32{1, 0.5}, Source: json.RawMessage(`{ "title": "first",
+				"tags": ["x"] }`)},
+			{Vector: []float32{0.25}, Source: json.RawMessage(`{}`)},
+		},
+	})
+	var partial *opensearchapi.PartialBulkError
+	require.ErrorAs(t, err, &partial)
+	require.Len(t, partial.FailedItems, 1)
+	require.Len(t, resp.Items, 2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, "/vectors/_bulk", path)
+	require.Equal(t, `{"index":{"_id":"a"}}
+{"embedding":[1,0.5],"title":"first","tags":["x"]}
+{"index":{}}
+{"embedding":[0.25]}
+`, body)
+
+	_, err = client.IndexVectors(t.Context(), knn.IndexVectorsReq{
+		Index: "vectors",
+		Field: "embedding",
+		Docs:  []knn.VectorDoc{{Vector: []float32{1}, Source: json.RawMessage(`[1]`)}},
+	})
+	require.ErrorContains(t, err, "document 0: source is not a JSON object")
+
+	_, err = client.IndexVectors(t.Context(), knn.IndexVectorsReq{Index: "vectors"})
+	require.ErrorContains(t, err, "requires an index and a field")
+}
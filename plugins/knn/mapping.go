@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package knn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Engine is the library that builds and searches the vector index.
+type Engine string
+
+// Engines supported by the k-NN plugin.
+const (
+	EngineFaiss  Engine = "faiss"
+	EngineLucene Engine = "lucene"
+	// EngineNMSLIB is deprecated by the plugin in favor of Faiss.
+	EngineNMSLIB Engine = "nmslib"
+)
+
+// SpaceType is the distance function used to compare vectors.
+type SpaceType string
+
+// Space types supported by the k-NN plugin.
+const (
+	SpaceL2           SpaceType = "l2"
+	SpaceL1           SpaceType = "l1"
+	SpaceLInf         SpaceType = "linf"
+	SpaceCosine       SpaceType = "cosinesimil"
+	SpaceInnerProduct SpaceType = "innerproduct"
+	SpaceHamming      SpaceType = "hamming"
+)
+
+// Method names supported by the k-NN plugin.
+const (
+	MethodHNSW = "hnsw"
+	MethodIVF  = "ivf"
+)
+
+// Vector data types of a knn_vector field.
+const (
+	DataTypeFloat  = "float"
+	DataTypeByte   = "byte"
+	DataTypeBinary = "binary"
+)
+
+// Method is the approximate nearest-neighbor method of a knn_vector field or
+// a trained model.
+type Method struct {
+	// Name is the method, MethodHNSW or MethodIVF.
+	Name      string
+	Engine    Engine
+	SpaceType SpaceType
+
+	// M is the number of bidirectional links per HNSW node.
+	M int
+	// EfConstruction is the size of the HNSW candidate list during
+	// indexing.
+	EfConstruction int
+	// NList is the number of IVF buckets.
+	NList int
+	// NProbes is the number of IVF buckets searched by default.
+	NProbes int
+
+	// Parameters holds further method parameters, such as an encoder. They
+	// are sent as-is and must not repeat the parameters above.
+	Parameters map[string]any
+}
+
+func (m Method) parameters() (map[string]json.RawMessage, error) {
+	if m.Name == "" {
+		return nil, errors.New("knn: method requires a name")
+	}
+	params := make(map[string]json.RawMessage, len(m.Parameters)+2)
+	for k, v := range m.Parameters {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("knn: method parameter %s: %w", k, err)
+		}
+		params[k] = data
+	}
+	for k, v := range map[string]int{"m": m.M, "ef_construction": m.EfConstruction, "nlist": m.NList, "nprobes": m.NProbes} {
+		if v == 0 {
+			continue
+		}
+		if _, ok := params[k]; ok {
+			return nil, fmt.Errorf("knn: method parameter %s is set twice", k)
+		}
+		params[k], _ = json.Marshal(v)
+	}
+	if len(params) == 0 {
+		return nil, nil
+	}
+	return params, nil
+}
+
+// TrainingMethod returns the method in the form the train model API takes.
+func (m Method) TrainingMethod() (*opensearchapi.KNNMethod, error) {
+	params, err := m.parameters()
+	if err != nil {
+		return nil, err
+	}
+	return &opensearchapi.KNNMethod{
+		Name:       m.Name,
+		Engine:     ptr.NonZero(string(m.Engine)),
+		SpaceType:  ptr.NonZero(string(m.SpaceType)),
+		Parameters: params,
+	}, nil
+}
+
+// VectorField describes a knn_vector field mapping. The field uses either a
+// Method, a trained model through ModelID, or neither, in which case the
+// plugin picks its default method. The index needs the index.knn setting
+// for approximate search.
+type VectorField struct {
+	Dimension int
+	// DataType is one of DataTypeFloat, the default, DataTypeByte, and
+	// DataTypeBinary.
+	DataType string
+	// SpaceType sets the field's distance function when Method does not.
+	SpaceType SpaceType
+	Method    *Method
+	ModelID   string
+
+	// Mode is "in_memory" or "on_disk"; CompressionLevel, such as "32x",
+	// sets the quantization of on-disk vectors.
+	Mode             string
+	CompressionLevel string
+}
+
+// Property returns the field as a mapping property, for use in the
+// properties of an index mapping.
+func (f VectorField) Property() (opensearchapi.CommonMappingProperty, error) {
+	if f.ModelID != "" && f.Method != nil {
+		return opensearchapi.CommonMappingProperty{}, errors.New("knn: vector field sets both Method and ModelID")
+	}
+	if f.ModelID == "" && f.Dimension <= 0 {
+		return opensearchapi.CommonMappingProperty{}, errors.New("knn: vector field requires a positive dimension")
+	}
+
+	prop := opensearchapi.CommonMappingKNNVectorProperty{
+		Dimension:        f.Dimension,
+		DataType:         ptr.NonZero(f.DataType),
+		SpaceType:        ptr.NonZero(string(f.SpaceType)),
+		ModelID:          ptr.NonZero(f.ModelID),
+		Mode:             ptr.NonZero(f.Mode),
+		CompressionLevel: ptr.NonZero(f.CompressionLevel),
+	}
+	if f.Method != nil {
+		params, err := f.Method.parameters()
+		if err != nil {
+			return opensearchapi.CommonMappingProperty{}, err
+		}
+		prop.Method = &opensearchapi.CommonMappingKNNVectorMethod{
+			Name:       f.Method.Name,
+			Engine:     ptr.NonZero(string(f.Method.Engine)),
+			SpaceType:  ptr.NonZero(string(f.Method.SpaceType)),
+			Parameters: params,
+		}
+	}
+	return opensearchapi.NewCommonMappingPropertyFromKNNVectorProperty(prop), nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package knn
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Query describes a k-NN query against one knn_vector field. Exactly one of
+// K, MinScore, and MaxDistance selects the neighbors: the K nearest, or every
+// neighbor within the score or distance threshold (radial search).
+type Query struct {
+	// Field is the knn_vector field to search.
+	Field string
+	// Vector is the query vector. Its length must match the field's
+	// dimension.
+	Vector []float32
+
+	K           int
+	MinScore    *float32
+	MaxDistance *float32
+
+	// Filter restricts the neighbors to the documents it matches. The
+	// engine applies it during the search rather than to the top K
+	// afterwards.
+	Filter *opensearchapi.CommonQueryDSLQueryContainer
+
+	// EfSearch sets the ef_search method parameter of HNSW indexes. Zero
+	// uses the index setting.
+	EfSearch int
+	// NProbes sets the nprobes method parameter of IVF indexes. Zero uses
+	// the method's default.
+	NProbes int
+
+	// Rescore configures rescoring of quantized results with full-precision
+	// vectors.
+	Rescore *opensearchapi.CommonQueryDSLKNNQueryRescore
+	// ExpandNestedDocs returns every matching nested document instead of
+	// only the best one per parent.
+	ExpandNestedDocs *bool
+
+	Boost *float32
+	// Name is the query name reported in matched_queries.
+	Name string
+}
+
+// Build returns the query container for the k-NN query, for use as a
+// search body's Query or inside a compound query.
+func (q Query) Build() (*opensearchapi.CommonQueryDSLQueryContainer, error) {
+	if q.Field == "" {
+		return nil, errors.New("knn: query requires a field")
+	}
+	if len(q.Vector) == 0 {
+		return nil, errors.New("knn: query requires a vector")
+	}
+	modes := 0
+	if q.K > 0 {
+		modes++
+	}
+	if q.MinScore != nil {
+		modes++
+	}
+	if q.MaxDistance != nil {
+		modes++
+	}
+	if modes != 1 {
+		return nil, errors.New("knn: query requires exactly one of K, MinScore, and MaxDistance")
+	}
+	if q.K < 0 || q.EfSearch < 0 || q.NProbes < 0 {
+		return nil, errors.New("knn: query K, EfSearch, and NProbes must not be negative")
+	}
+
+	knn := opensearchapi.CommonQueryDSLKNNQuery{
+		Vector:           q.Vector,
+		MinScore:         q.MinScore,
+		MaxDistance:      q.MaxDistance,
+		Filter:           q.Filter,
+		Rescore:          q.Rescore,
+		ExpandNestedDocs: q.ExpandNestedDocs,
+	}
+	knn.Boost = q.Boost
+	if q.K > 0 {
+		knn.K = &q.K
+	}
+	if q.Name != "" {
+		knn.Name = &q.Name
+	}
+	if q.EfSearch > 0 || q.NProbes > 0 {
+		knn.MethodParameters = make(map[string]json.RawMessage, 1)
+		if q.EfSearch > 0 {
+			knn.MethodParameters["ef_search"] = json.RawMessage(strconv.Itoa(q.EfSearch))
+		}
+		if q.NProbes > 0 {
+			knn.MethodParameters["nprobes"] = json.RawMessage(strconv.Itoa(q.NProbes))
+		}
+	}
+	return &opensearchapi.CommonQueryDSLQueryContainer{
+		KNN: map[string]opensearchapi.CommonQueryDSLKNNQuery{q.Field: knn},
+	}, nil
+}
+
+// SearchBody returns a search body running the query. For a top-K query the
+// size is set to K, so the response holds exactly the neighbors.
+func (q Query) SearchBody() (*opensearchapi.SearchBody, error) {
+	query, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	body := &opensearchapi.SearchBody{Query: query}
+	if q.K > 0 {
+		size := q.K
+		body.Size = &size
+	}
+	return body, nil
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package knn_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/knn"
+)
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("top k with filter", func(t *testing.T) {
+		t.Parallel()
+		body, err := knn.Query{
+			Field:    "embedding",
+			Vector:   []float32{0.1, 2, -3.5},
+			K:        5,
+			EfSearch: 100,
+			Filter: &opensearchapi.CommonQueryDSLQueryContainer{
+				Exists: &opensearchapi.CommonQueryDSLExistsQuery{Field: "title"},
+			},
+			Name: "vec",
+		}.SearchBody()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"size":5,"query":{"knn":{"embedding":{
+			"_name":"vec","vector":[0.1,2,-3.5],"k":5,
+			"method_parameters":{"ef_search":100},
+			"filter":{"exists":{"field":"title"}}}}}}`, string(data))
+	})
+
+	t.Run("radial", func(t *testing.T) {
+		t.Parallel()
+		query, err := knn.Query{Field: "embedding", Vector: []float32{1}, MaxDistance: opensearch.ToPointer[float32](2.5), NProbes: 8}.Build()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(query)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"knn":{"embedding":{"vector":[1],"max_distance":2.5,"method_parameters":{"nprobes":8}}}}`, string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for name, q := range map[string]knn.Query{
+			"no field":      {Vector: []float32{1}, K: 1},
+			"no vector":     {Field: "v", K: 1},
+			"no mode":       {Field: "v", Vector: []float32{1}},
+			"two modes":     {Field: "v", Vector: []float32{1}, K: 1, MinScore: opensearch.ToPointer[float32](0.5)},
+			"negative k":    {Field: "v", Vector: []float32{1}, K: -1, MinScore: opensearch.ToPointer[float32](0.5)},
+			"negative prob": {Field: "v", Vector: []float32{1}, K: 1, NProbes: -1},
+		} {
+			_, err := q.Build()
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestVectorField(t *testing.T) {
+	t.Parallel()
+
+	t.Run("method", func(t *testing.T) {
+		t.Parallel()
+		prop, err := knn.VectorField{
+			Dimension: 768,
+			Method: &knn.Method{
+				Name:           knn.MethodHNSW,
+				Engine:         knn.EngineFaiss,
+				SpaceType:      knn.SpaceInnerProduct,
+				M:              16,
+				EfConstruction: 128,
+				Parameters:     map[string]any{"encoder": map[string]any{"name": "sq"}},
+			},
+		}.Property()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(prop)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type":"knn_vector","dimension":768,"method":{
+			"name":"hnsw","engine":"faiss","space_type":"innerproduct",
+			"parameters":{"m":16,"ef_construction":128,"encoder":{"name":"sq"}}}}`, string(data))
+	})
+
+	t.Run("model", func(t *testing.T) {
+		t.Parallel()
+		prop, err := knn.VectorField{ModelID: "ivf-model"}.Property()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(prop)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type":"knn_vector","dimension":0,"model_id":"ivf-model"}`, string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := knn.VectorField{}.Property()
+		require.ErrorContains(t, err, "dimension")
+		_, err = knn.VectorField{ModelID: "m", Method: &knn.Method{Name: knn.MethodIVF}}.Property()
+		require.ErrorContains(t, err, "both")
+		_, err = knn.VectorField{Dimension: 2, Method: &knn.Method{Name: knn.MethodHNSW, M: 4, Parameters: map[string]any{"m": 8}}}.Property()
+		require.ErrorContains(t, err, "set twice")
+		_, err = knn.Method{}.TrainingMethod()
+		require.ErrorContains(t, err, "name")
+	})
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package knn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/poll"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// DefaultTrainPollInterval is the interval between model state checks used
+// by [Client.TrainAndWait] when none is given.
+const DefaultTrainPollInterval = time.Second
+
+// Model states reported by the k-NN plugin.
+const (
+	ModelStateTraining = "training"
+	ModelStateCreated  = "created"
+	ModelStateFailed   = "failed"
+)
+
+// ErrTrainingFailed is wrapped by the error [Client.TrainAndWait] returns
+// when the model ends in the failed state.
+var ErrTrainingFailed = errors.New("knn: model training failed")
+
+// Model is the metadata of a trained model, without the model blob.
+type Model struct {
+	ModelID     string    `json:"model_id"`
+	State       string    `json:"state"`
+	Description string    `json:"description"`
+	Error       string    `json:"error"`
+	Timestamp   string    `json:"timestamp"`
+	Dimension   int       `json:"dimension"`
+	Engine      Engine    `json:"engine"`
+	SpaceType   SpaceType `json:"space_type"`
+	// TrainingNode is the ID of the node that trained the model.
+	TrainingNode string `json:"training_node_assignment"`
+}
+
+// TrainAndWait starts training a model and polls it every pollInterval until
+// it leaves the training state. A zero pollInterval uses
+// DefaultTrainPollInterval. It returns the created model; when training
+// fails, the model is returned with an error wrapping ErrTrainingFailed.
+func (c *Client) TrainAndWait(ctx context.Context, req TrainModelReq, pollInterval time.Duration) (*Model, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultTrainPollInterval
+	}
+	resp, err := c.Model.TrainModel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	modelID := resp.ModelID
+	if modelID == "" {
+		modelID = req.ModelID
+	}
+
+	for {
+		model, err := c.model(ctx, modelID)
+		if err != nil {
+			return nil, err
+		}
+		switch model.State {
+		case ModelStateCreated:
+			return model, nil
+		case ModelStateFailed:
+			return model, fmt.Errorf("%w: model %s: %s", ErrTrainingFailed, modelID, model.Error)
+		}
+
+		if err := poll.Sleep(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// model fetches the model's metadata, leaving out the potentially large
+// model blob.
+func (c *Client) model(ctx context.Context, modelID string) (*Model, error) {
+	resp, err := c.Model.GetModel(ctx, GetModelReq{
+		ModelID: modelID,
+		Params:  &GetModelParams{DebugParams: opensearchapi.DebugParams{FilterPath: []string{"-model_blob"}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var model Model
+	if err := json.Unmarshal(resp.Body, &model); err != nil {
+		return nil, fmt.Errorf("knn: decode model %s: %w", modelID, err)
+	}
+	return &model, nil
+}
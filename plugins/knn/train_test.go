@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package knn_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/knn"
+)
+
+// trainStub trains a model that stays in the training state for a number of
+// polls before reaching its final state.
+type trainStub struct {
+	mu         sync.Mutex
+	polls      int
+	finalState string
+	filterPath string
+}
+
+func (s *trainStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/_plugins/_knn/models/_train" && r.Method == http.MethodPost:
+		_, _ = io.WriteString(w, `{"model_id":"m1"}`)
+	case r.URL.Path == "/_plugins/_knn/models/m1" && r.Method == http.MethodGet:
+		s.filterPath = r.URL.Query().Get("filter_path")
+		state, errMsg := "training", ""
+		if s.polls--; s.polls < 0 {
+			state = s.finalState
+			if state == knn.ModelStateFailed {
+				errMsg = "not enough training vectors"
+			}
+		}
+		_, _ = io.WriteString(w, `{"model_id":"m1","state":"`+state+`","error":"`+errMsg+`",
+			"dimension":4,"engine":"faiss","space_type":"l2","training_node_assignment":"node-1"}`)
+	default:
+		_, _ = io.WriteString(w, `{}`)
+	}
+}
+
+func newTrainClient(t *testing.T, s *trainStub) *knn.Client {
+	t.Helper()
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	return knn.NewClient(osClient)
+}
+
+func trainReq(t *testing.T) knn.TrainModelReq {
+	t.Helper()
+	method, err := knn.Method{Name: knn.MethodIVF, Engine: knn.EngineFaiss, NList: 4}.TrainingMethod()
+	require.NoError(t, err)
+	return knn.TrainModelReq{Body: &opensearchapi.KNNTrainedModel{
+		Dimension:     4,
+		TrainingIndex: "train",
+		TrainingField: "embedding",
+		Method:        method,
+	}}
+}
+
+func TestTrainAndWait(t *testing.T) {
+	t.Parallel()
+
+	t.Run("created", func(t *testing.T) {
+		t.Parallel()
+		stub := &trainStub{polls: 2, finalState: knn.ModelStateCreated}
+		client := newTrainClient(t, stub)
+
+		model, err := client.TrainAndWait(t.Context(), trainReq(t), time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, &knn.Model{
+			ModelID:      "m1",
+			State:        knn.ModelStateCreated,
+			Dimension:    4,
+			Engine:       knn.EngineFaiss,
+			SpaceType:    knn.SpaceL2,
+			TrainingNode: "node-1",
+		}, model)
+		require.Equal(t, "-model_blob", stub.filterPath)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+		client := newTrainClient(t, &trainStub{finalState: knn.ModelStateFailed})
+
+		model, err := client.TrainAndWait(t.Context(), trainReq(t), time.Millisecond)
+		require.ErrorIs(t, err, knn.ErrTrainingFailed)
+		require.ErrorContains(t, err, "not enough training vectors")
+		require.Equal(t, knn.ModelStateFailed, model.State)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		t.Parallel()
+		client := newTrainClient(t, &trainStub{polls: 1 << 30})
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+		_, err := client.TrainAndWait(ctx, trainReq(t), time.Millisecond)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -32,6 +32,7 @@ import (
 	"net/http"
 
 	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
 )
 
 // Client provides methods for this plugin API.
@@ -208,13 +209,8 @@ func (c *Client) DeleteAgenticMemoryQuery(ctx context.Context, req DeleteAgentic
 // POST /_plugins/_ml/agents/{agent_id}/_execute/stream
 //
 // Available: >= 3.3.0.
-func (c *Client) ExecuteAgentStream(ctx context.Context, req ExecuteAgentStreamReq) (*ExecuteAgentStreamResp, error) {
-	var resp ExecuteAgentStreamResp
-	var err error
-	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
-		return &resp, err
-	}
-	return &resp, nil
+func (c *Client) ExecuteAgentStream(ctx context.Context, req ExecuteAgentStreamReq, opts *opensearch.StreamOptions) (*opensearch.EventStream[opensearchapi.MLPredictResponse], error) {
+	return opensearch.ExecuteEventStream[opensearchapi.MLPredictResponse](ctx, c.Client, http.MethodPost, req, opts)
 }
 
 // ExecuteAlgorithm execute an algorithm.
@@ -323,13 +319,8 @@ func (c *Client) Predict(ctx context.Context, req PredictReq) (*PredictResp, err
 // POST /_plugins/_ml/models/{model_id}/_predict/stream
 //
 // Available: >= 3.3.0.
-func (c *Client) PredictModelStream(ctx context.Context, req PredictModelStreamReq) (*PredictModelStreamResp, error) {
-	var resp PredictModelStreamResp
-	var err error
-	if resp.response, err = request(ctx, c, http.MethodPost, req, &resp); err != nil {
-		return &resp, err
-	}
-	return &resp, nil
+func (c *Client) PredictModelStream(ctx context.Context, req PredictModelStreamReq, opts *opensearch.StreamOptions) (*opensearch.EventStream[opensearchapi.MLPredictResponse], error) {
+	return opensearch.ExecuteEventStream[opensearchapi.MLPredictResponse](ctx, c.Client, http.MethodPost, req, opts)
 }
 
 // Train trains a model synchronously.
@@ -14,7 +14,6 @@ import (
 	"io"
 	"net/http"
 
-	opensearch "github.com/opensearch-project/opensearch-go/v5"
 	"github.com/opensearch-project/opensearch-go/v5/internal/build"
 	osparams "github.com/opensearch-project/opensearch-go/v5/internal/params"
 	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
@@ -101,29 +100,6 @@ func (r ExecuteAgentStreamParams) get() map[string]string {
 	return params
 }
 
-// ExecuteAgentStreamResp represents the response for the ml.execute_agent_stream operation.
-//
-// Execute an agent in streaming mode.
-//
-// Available: >= 3.3.0.
-type ExecuteAgentStreamResp struct {
-	response *opensearch.Response
-}
-
-// Inspect returns the raw OpenSearch response for debugging or advanced use.
-func (r ExecuteAgentStreamResp) Inspect() Inspect {
-	return Inspect{Response: r.response}
-}
-
-// RawBody returns a fresh reader over the original response bytes,
-// useful when the typed response struct is incomplete for your use case.
-func (r ExecuteAgentStreamResp) RawBody() io.Reader {
-	if r.response == nil || len(r.response.RawBody()) == 0 {
-		return nil
-	}
-	return bytes.NewReader(r.response.RawBody())
-}
-
 // MLExecuteAgentStreamBody is a typed component of the ml.execute_agent_stream operation.
 type MLExecuteAgentStreamBody struct {
 	Parameters opensearchapi.MLParameters `json:"parameters"`
@@ -68,8 +68,9 @@ func TestExecuteAgentStream_Roundtrip(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
 			w.WriteHeader(http.StatusOK)
-			_, _ = io.WriteString(w, `{}`)
+			_, _ = io.WriteString(w, "data: {}\n\n")
 		}))
 		t.Cleanup(ts.Close)
 
@@ -78,10 +79,14 @@ func TestExecuteAgentStream_Roundtrip(t *testing.T) {
 		t.Cleanup(func() { _ = osClient.Close() })
 		client := ml.NewClient(osClient)
 
-		resp, err := client.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: "test", BodyReader: strings.NewReader("{}")})
+		stream, err := client.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: "test", BodyReader: strings.NewReader("{}")}, nil)
 		require.NoError(t, err)
-		require.NotNil(t, resp)
-		require.NotNil(t, resp.Inspect().Response)
+		t.Cleanup(func() { _ = stream.Close() })
+		require.Equal(t, http.StatusOK, stream.Response().StatusCode)
+		_, err = stream.Next()
+		require.NoError(t, err)
+		_, err = stream.Next()
+		require.ErrorIs(t, err, io.EOF)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -97,8 +102,9 @@ func TestExecuteAgentStream_Roundtrip(t *testing.T) {
 		t.Cleanup(func() { _ = osClient.Close() })
 		errClient := ml.NewClient(osClient)
 
-		resp, err := errClient.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: "test", BodyReader: strings.NewReader("{}")})
+		resp, err := errClient.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: "test", BodyReader: strings.NewReader("{}")}, nil)
 		require.Error(t, err)
 		require.NotNil(t, resp)
+		require.Equal(t, http.StatusBadRequest, resp.Response().StatusCode)
 	})
 }
@@ -31,19 +31,21 @@ func TestExecuteAgentStream(t *testing.T) {
 	name := testutil.MustUniqueString(t, "test-execute-agent-stream")
 
 	t.Run("success", func(t *testing.T) {
-		resp, err := client.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: name, Body: &ml.MLExecuteAgentStreamBody{}})
+		stream, err := client.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: name, Body: &ml.MLExecuteAgentStreamBody{}}, nil)
 		require.NoError(t, err)
-		require.NotNil(t, resp)
-		testutil.CompareRawJSONwithParsedJSON(t, resp, resp.Inspect().Response)
+		t.Cleanup(func() { _ = stream.Close() })
+		for _, err := range stream.All() {
+			require.NoError(t, err)
+		}
 	})
 
 	t.Run("inspect", func(t *testing.T) {
 		failingClient, err := plugintest.CreateFailingClient(t)
 		require.NoError(t, err)
 
-		res, err := failingClient.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: name, Body: &ml.MLExecuteAgentStreamBody{}})
+		res, err := failingClient.ExecuteAgentStream(t.Context(), ml.ExecuteAgentStreamReq{AgentID: name, Body: &ml.MLExecuteAgentStreamBody{}}, nil)
 		require.Error(t, err)
 		require.NotNil(t, res)
-		plugintest.VerifyInspect(t, res.Inspect())
+		plugintest.VerifyInspect(t, ml.Inspect{Response: res.Response()})
 	})
 }
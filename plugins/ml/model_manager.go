@@ -0,0 +1,564 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package ml
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/internal/poll"
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Defaults used when the corresponding ModelManagerConfig field is zero.
+const (
+	defaultModelPollInterval = time.Second
+	defaultModelMaxRetries   = 3
+	defaultModelRetryBackoff = 500 * time.Millisecond
+	defaultModelChunkSize    = 10 << 20
+)
+
+// Model states reported by ML Commons.
+const (
+	modelStateRegistering    = "REGISTERING"
+	modelStateRegisterFailed = "REGISTER_FAILED"
+	modelStateDeploying      = "DEPLOYING"
+	modelStateDeployed       = "DEPLOYED"
+	modelStateDeployFailed   = "DEPLOY_FAILED"
+)
+
+// Task states reported by ML Commons.
+const (
+	taskStateCompleted = "COMPLETED"
+	taskStateFailed    = "FAILED"
+	taskStateCancelled = "CANCELLED"
+	// taskStateCompletedWithError is reported when a deployment succeeded on
+	// some nodes only.
+	taskStateCompletedWithError = "COMPLETED_WITH_ERROR"
+)
+
+var (
+	// ErrTaskFailed is returned, wrapped, when an ML task ends in a state
+	// other than COMPLETED.
+	ErrTaskFailed = errors.New("ml: task failed")
+
+	// ErrModelFailed is returned, wrapped, when a model ends up in the
+	// REGISTER_FAILED or DEPLOY_FAILED state.
+	ErrModelFailed = errors.New("ml: model failed")
+)
+
+// ModelManagerConfig represents configuration of the model manager.
+type ModelManagerConfig struct {
+	// Client is used for every request. Required.
+	Client *Client
+
+	// PollInterval is the pause between polls of a running task or of a
+	// model in a transitional state. Defaults to 1s.
+	PollInterval time.Duration
+
+	// MaxRetries is the number of times a request is retried after a
+	// throttled (429) or unavailable (502, 503, 504) response or a transport
+	// failure. Registrations, which are not idempotent, are retried only
+	// after a 429. Defaults to 3.
+	MaxRetries int
+	// DisableRetry disables the retries above.
+	DisableRetry bool
+	// RetryBackoff is the pause before the first retry; it doubles with each
+	// further attempt. Defaults to 500ms.
+	RetryBackoff time.Duration
+
+	// ChunkSize is the size of the chunks a local model file is uploaded in.
+	// Defaults to 10 MiB.
+	ChunkSize int
+}
+
+// ModelSpec describes a model for [ModelManager.Register] and
+// [ModelManager.Ensure]. The model source is one of File, URL, or
+// ConnectorID; when none is set the model is registered by name, as for the
+// pretrained models OpenSearch provides.
+type ModelSpec struct {
+	// Name and Version identify the model. A model with the same name,
+	// version, and group that is already registered is reused.
+	Name        string
+	Version     string
+	Description string
+
+	// ModelGroupID places the model in an existing group. Otherwise, when
+	// ModelGroupName is set, the group with that name is used and registered
+	// if it does not exist yet.
+	ModelGroupID          string
+	ModelGroupName        string
+	ModelGroupDescription string
+
+	FunctionName string
+	// ModelFormat is TORCH_SCRIPT or ONNX. Required for File.
+	ModelFormat string
+	ModelConfig *opensearchapi.MLModelConfig
+
+	// File is the path of a local model archive. It is uploaded in chunks
+	// together with its SHA-256 checksum, which the server verifies.
+	File string
+
+	// URL is the location the server downloads the model from, and
+	// ContentHash its SHA-256 checksum.
+	URL         string
+	ContentHash string
+
+	// ConnectorID registers a remote model served through a connector.
+	ConnectorID string
+}
+
+// ManagedModel is a model registered or found by the [ModelManager].
+type ManagedModel struct {
+	ID      string
+	GroupID string
+	State   string
+	// Registered is true when the model was registered by this call and
+	// false when an existing model was reused.
+	Registered bool
+}
+
+// ModelManager runs the register, upload, deploy, and predict workflow of ML
+// Commons models, polling the tasks the server starts along the way.
+type ModelManager struct {
+	client *Client
+	config ModelManagerConfig
+}
+
+// NewModelManager creates a new model manager.
+func NewModelManager(cfg ModelManagerConfig) (*ModelManager, error) {
+	if cfg.Client == nil {
+		return nil, errors.New("ml: model manager requires a client")
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultModelPollInterval
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultModelMaxRetries
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultModelRetryBackoff
+	}
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = defaultModelChunkSize
+	}
+	return &ModelManager{client: cfg.Client, config: cfg}, nil
+}
+
+// Ensure registers the model described by spec, unless it already exists,
+// and deploys it. It returns once the model is DEPLOYED.
+func (m *ModelManager) Ensure(ctx context.Context, spec ModelSpec) (*ManagedModel, error) {
+	model, err := m.Register(ctx, spec)
+	if err != nil {
+		return model, err
+	}
+	state, err := m.Deploy(ctx, model.ID)
+	if state != "" {
+		model.State = state
+	}
+	return model, err
+}
+
+// Register registers the model described by spec and waits until the
+// registration finishes. When a model with the same name, version, and
+// group already exists it is returned instead, after waiting for a
+// registration still in progress. Models whose registration failed are
+// ignored.
+func (m *ModelManager) Register(ctx context.Context, spec ModelSpec) (*ManagedModel, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	groupID, err := m.ensureGroup(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.findModel(ctx, spec, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.State == modelStateRegistering {
+			if existing.State, err = m.waitModel(ctx, existing.ID, modelStateRegistering); err != nil {
+				return existing, err
+			}
+		}
+		return existing, nil
+	}
+
+	var id string
+	if spec.File != "" {
+		id, err = m.uploadFile(ctx, spec, groupID)
+	} else {
+		id, err = m.registerModel(ctx, spec, groupID)
+	}
+	model := &ManagedModel{ID: id, GroupID: groupID, Registered: true}
+	if err != nil {
+		return model, err
+	}
+	model.State, err = m.waitModel(ctx, id, modelStateRegistering)
+	return model, err
+}
+
+// Deploy deploys the model and waits until the deployment finishes. A model
+// that is already DEPLOYED is left alone, and a deployment already in
+// progress is awaited rather than started again. It returns the model's
+// final state.
+func (m *ModelManager) Deploy(ctx context.Context, modelID string) (string, error) {
+	state, err := m.modelState(ctx, modelID)
+	if err != nil {
+		return "", err
+	}
+	if state == modelStateDeploying {
+		if state, err = m.waitModel(ctx, modelID, modelStateDeploying); err != nil {
+			return state, err
+		}
+	}
+	if state == modelStateDeployed {
+		return state, nil
+	}
+
+	resp, err := retry(ctx, m, true, func() (*DeployModelResp, error) {
+		return m.client.Model.DeployModel(ctx, DeployModelReq{ModelID: modelID})
+	})
+	if err != nil {
+		return state, err
+	}
+	if resp.TaskID != "" {
+		if _, err := m.WaitForTask(ctx, resp.TaskID); err != nil {
+			return state, err
+		}
+	}
+	return m.waitModel(ctx, modelID, modelStateDeploying)
+}
+
+// Predict runs inference with a deployed model, retrying transient failures.
+func (m *ModelManager) Predict(ctx context.Context, modelID string, body *MLPredictModelBody) (*PredictModelResp, error) {
+	return retry(ctx, m, true, func() (*PredictModelResp, error) {
+		return m.client.Model.PredictModel(ctx, PredictModelReq{ModelID: modelID, Body: body})
+	})
+}
+
+// WaitForTask polls the task until it finishes. When it ends in a state
+// other than COMPLETED, the error wraps ErrTaskFailed and the task is
+// returned as well.
+func (m *ModelManager) WaitForTask(ctx context.Context, taskID string) (*GetTaskResp, error) {
+	for {
+		task, err := retry(ctx, m, true, func() (*GetTaskResp, error) {
+			return m.client.Task.GetTask(ctx, GetTaskReq{TaskID: taskID})
+		})
+		if err != nil {
+			return nil, err
+		}
+		switch task.State {
+		case taskStateCompleted:
+			return task, nil
+		case taskStateFailed, taskStateCancelled, taskStateCompletedWithError:
+			return task, fmt.Errorf("%w: task %s finished in state %s%s", ErrTaskFailed, taskID, task.State, ptr.ReasonSuffix(task.Error))
+		}
+		if err := poll.Sleep(ctx, m.config.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s ModelSpec) validate() error {
+	if s.Name == "" {
+		return errors.New("ml: model spec requires a name")
+	}
+	sources := 0
+	for _, src := range []string{s.File, s.URL, s.ConnectorID} {
+		if src != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.New("ml: model spec sets more than one of File, URL, and ConnectorID")
+	}
+	if s.File != "" && s.ModelFormat == "" {
+		return errors.New("ml: model spec requires a model format to upload a file")
+	}
+	return nil
+}
+
+// ensureGroup returns the ID of the spec's model group, registering a group
+// with ModelGroupName when none exists.
+func (m *ModelManager) ensureGroup(ctx context.Context, spec ModelSpec) (string, error) {
+	if spec.ModelGroupID != "" || spec.ModelGroupName == "" {
+		return spec.ModelGroupID, nil
+	}
+
+	hits, err := m.search(ctx, true, map[string]any{
+		"size":  100,
+		"query": map[string]any{"match_phrase": map[string]any{"name": spec.ModelGroupName}},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, hit := range hits {
+		if hit.Source != nil && ptr.Deref(hit.Source.Name) == spec.ModelGroupName {
+			return ptr.Deref(hit.ID), nil
+		}
+	}
+
+	body := &MLRegisterModelGroupBody{Name: spec.ModelGroupName}
+	if spec.ModelGroupDescription != "" {
+		body.Description = opensearch.ToPointer(spec.ModelGroupDescription)
+	}
+	resp, err := retry(ctx, m, false, func() (*RegisterModelGroupResp, error) {
+		return m.client.ModelGroup.RegisterModelGroup(ctx, &RegisterModelGroupReq{Body: body})
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ModelGroupID, nil
+}
+
+// findModel returns the registered model matching the spec's name, version,
+// and group, preferring a deployed one, or nil when there is none.
+func (m *ModelManager) findModel(ctx context.Context, spec ModelSpec, groupID string) (*ManagedModel, error) {
+	hits, err := m.search(ctx, false, map[string]any{
+		"size": 100,
+		"query": map[string]any{"bool": map[string]any{
+			"must": []any{map[string]any{"match_phrase": map[string]any{"name": spec.Name}}},
+			// Chunks of uploaded models are stored as separate documents.
+			"must_not": []any{map[string]any{"exists": map[string]any{"field": "chunk_number"}}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *ManagedModel
+	for _, hit := range hits {
+		src := hit.Source
+		if src == nil || ptr.Deref(src.Name) != spec.Name || ptr.Deref(src.ModelState) == modelStateRegisterFailed {
+			continue
+		}
+		if spec.Version != "" && ptr.Deref(src.ModelVersion) != spec.Version {
+			continue
+		}
+		if groupID != "" && ptr.Deref(src.ModelGroupID) != groupID {
+			continue
+		}
+		model := &ManagedModel{ID: ptr.Deref(hit.ID), GroupID: ptr.Deref(src.ModelGroupID), State: ptr.Deref(src.ModelState)}
+		if found == nil || model.State == modelStateDeployed {
+			found = model
+		}
+	}
+	return found, nil
+}
+
+// search runs a model or model group search. A missing ML index yields no
+// hits.
+func (m *ModelManager) search(ctx context.Context, groups bool, query map[string]any) ([]opensearchapi.MLSearchHitsHit, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits opensearchapi.MLSearchHits
+	if groups {
+		var resp *SearchModelGroupResp
+		resp, err = retry(ctx, m, true, func() (*SearchModelGroupResp, error) {
+			return m.client.ModelGroup.SearchModelGroup(ctx, &SearchModelGroupReq{BodyReader: bytes.NewReader(body)})
+		})
+		hits = resp.Hits
+	} else {
+		var resp *SearchModelsResp
+		resp, err = retry(ctx, m, true, func() (*SearchModelsResp, error) {
+			return m.client.Model.SearchModels(ctx, &SearchModelsReq{BodyReader: bytes.NewReader(body)})
+		})
+		hits = resp.Hits
+	}
+	if err != nil {
+		if errors.Is(err, opensearch.ErrIndexNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return hits.Hits, nil
+}
+
+// registerModel registers a model from a URL, a connector, or by name and
+// returns its ID once the registration task completes.
+func (m *ModelManager) registerModel(ctx context.Context, spec ModelSpec, groupID string) (string, error) {
+	body := &MLRegisterModelBody{
+		Name:                  spec.Name,
+		Version:               ptr.NonZero(spec.Version),
+		Description:           ptr.NonZero(spec.Description),
+		ModelGroupID:          ptr.NonZero(groupID),
+		FunctionName:          ptr.NonZero(spec.FunctionName),
+		ModelFormat:           ptr.NonZero(spec.ModelFormat),
+		ModelConfig:           spec.ModelConfig,
+		URL:                   ptr.NonZero(spec.URL),
+		ModelContentHashValue: ptr.NonZero(spec.ContentHash),
+		ConnectorID:           ptr.NonZero(spec.ConnectorID),
+	}
+	resp, err := retry(ctx, m, false, func() (*RegisterModelResp, error) {
+		return m.client.Model.RegisterModel(ctx, &RegisterModelReq{Body: body})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	id := ptr.Deref(resp.ModelID)
+	if resp.TaskID != "" {
+		task, err := m.WaitForTask(ctx, resp.TaskID)
+		if task != nil && task.ModelID != nil {
+			id = *task.ModelID
+		}
+		if err != nil {
+			return id, err
+		}
+	}
+	if id == "" {
+		return "", fmt.Errorf("ml: registration of model %s did not report a model ID", spec.Name)
+	}
+	return id, nil
+}
+
+// uploadFile registers the model's metadata with the file's checksum and
+// uploads the file in chunks. It returns the new model's ID.
+func (m *ModelManager) uploadFile(ctx context.Context, spec ModelSpec, groupID string) (string, error) {
+	f, err := os.Open(spec.File)
+	if err != nil {
+		return "", fmt.Errorf("ml: open model file: %w", err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return "", fmt.Errorf("ml: read model file: %w", err)
+	}
+	if size == 0 {
+		return "", fmt.Errorf("ml: model file %s is empty", spec.File)
+	}
+	chunkSize := int64(m.config.ChunkSize)
+	totalChunks := (size + chunkSize - 1) / chunkSize
+
+	body := &MLRegisterModelMetaBody{
+		Name:                  spec.Name,
+		Version:               spec.Version,
+		Description:           ptr.NonZero(spec.Description),
+		ModelGroupID:          ptr.NonZero(groupID),
+		FunctionName:          ptr.NonZero(spec.FunctionName),
+		ModelFormat:           spec.ModelFormat,
+		ModelContentHashValue: hex.EncodeToString(hash.Sum(nil)),
+		TotalChunks:           totalChunks,
+	}
+	if spec.ModelConfig != nil {
+		body.ModelConfig = *spec.ModelConfig
+	}
+	meta, err := retry(ctx, m, false, func() (*RegisterModelMetaResp, error) {
+		return m.client.ModelMeta.RegisterModelMeta(ctx, &RegisterModelMetaReq{Body: body})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	header := http.Header{"Content-Type": []string{"application/octet-stream"}}
+	buf := make([]byte, chunkSize)
+	for i := range totalChunks {
+		n, err := io.ReadFull(io.NewSectionReader(f, i*chunkSize, chunkSize), buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return meta.ModelID, fmt.Errorf("ml: read model file chunk %d: %w", i, err)
+		}
+		chunk := buf[:n]
+		_, err = retry(ctx, m, true, func() (*UploadChunkResp, error) {
+			return m.client.UploadChunk(ctx, UploadChunkReq{
+				ModelID:     meta.ModelID,
+				ChunkNumber: strconv.FormatInt(i, 10),
+				BodyReader:  bytes.NewReader(chunk),
+				Header:      header,
+			})
+		})
+		if err != nil {
+			return meta.ModelID, fmt.Errorf("ml: upload chunk %d of %d: %w", i, totalChunks, err)
+		}
+	}
+	return meta.ModelID, nil
+}
+
+func (m *ModelManager) modelState(ctx context.Context, modelID string) (string, error) {
+	resp, err := retry(ctx, m, true, func() (*GetModelResp, error) {
+		return m.client.Model.GetModel(ctx, GetModelReq{ModelID: modelID})
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ModelState, nil
+}
+
+// waitModel polls the model while it is in the pending state and returns
+// the state it moves to. A failed state yields an error wrapping
+// ErrModelFailed.
+func (m *ModelManager) waitModel(ctx context.Context, modelID, pending string) (string, error) {
+	for {
+		state, err := m.modelState(ctx, modelID)
+		if err != nil {
+			return "", err
+		}
+		switch state {
+		case pending:
+		case modelStateRegisterFailed, modelStateDeployFailed:
+			return state, fmt.Errorf("%w: model %s is in state %s", ErrModelFailed, modelID, state)
+		default:
+			return state, nil
+		}
+		if err := poll.Sleep(ctx, m.config.PollInterval); err != nil {
+			return state, err
+		}
+	}
+}
+
+// retry calls call until it succeeds, fails with an error that is not
+// transient, or the retries are exhausted. Transport failures and
+// unavailable responses are retried only for idempotent calls.
+func retry[T interface{ Inspect() Inspect }](ctx context.Context, m *ModelManager, idempotent bool, call func() (T, error)) (T, error) {
+	backoff := m.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := call()
+		if err == nil || m.config.DisableRetry || attempt >= m.config.MaxRetries || !retryable(ctx, resp, idempotent) {
+			return resp, err
+		}
+		if serr := poll.Sleep(ctx, backoff); serr != nil {
+			return resp, err
+		}
+		backoff *= 2
+	}
+}
+
+func retryable[T interface{ Inspect() Inspect }](ctx context.Context, resp T, idempotent bool) bool {
+	status := 0
+	if r := resp.Inspect().Response; r != nil {
+		status = r.StatusCode
+	}
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case 0:
+		return idempotent && ctx.Err() == nil
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
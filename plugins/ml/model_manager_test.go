@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package ml_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/ml"
+)
+
+// mlStub is a minimal ML Commons API holding a single model group and model.
+type mlStub struct {
+	mu sync.Mutex
+
+	existing   string // _search response for models; empty means no ML index
+	modelState string
+	taskPolls  int
+	taskState  string
+	deployFail int // number of 503 answers before a deploy is accepted
+
+	meta     map[string]any
+	chunks   map[string][]byte
+	requests []string
+}
+
+func (s *mlStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, "/_plugins/_ml/") {
+		_, _ = io.WriteString(w, `{}`)
+		return
+	}
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	body, _ := io.ReadAll(r.Body)
+
+	switch path := strings.TrimPrefix(r.URL.Path, "/_plugins/_ml"); {
+	case path == "/model_groups/_search":
+		_, _ = io.WriteString(w, `{"hits":{"total":{"value":1,"relation":"eq"},"hits":[{"_id":"other","_source":{"name":"embeddings-v2"}}]}}`)
+	case path == "/model_groups/_register":
+		_, _ = io.WriteString(w, `{"model_group_id":"g1","status":"CREATED"}`)
+	case path == "/models/_search":
+		if s.existing == "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"status":404,"error":{"type":"index_not_found_exception","reason":"no such index [.plugins-ml-model]"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, s.existing)
+	case path == "/models/_register_meta":
+		if err := json.Unmarshal(body, &s.meta); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.modelState = "REGISTERING"
+		_, _ = io.WriteString(w, `{"model_id":"m1","status":"CREATED"}`)
+	case strings.HasPrefix(path, "/models/m1/upload_chunk/"):
+		s.chunks[strings.TrimPrefix(path, "/models/m1/upload_chunk/")] = body
+		if total, ok := s.meta["total_chunks"].(float64); ok && len(s.chunks) == int(total) {
+			s.modelState = "REGISTERED"
+		}
+		_, _ = io.WriteString(w, `{"status":"Uploaded"}`)
+	case path == "/models/_register":
+		s.taskState = "FAILED"
+		_, _ = io.WriteString(w, `{"task_id":"t1","status":"CREATED"}`)
+	case path == "/models/m1/_deploy":
+		if s.deployFail > 0 {
+			s.deployFail--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, `{"status":503,"error":{"type":"unavailable","reason":"busy"}}`)
+			return
+		}
+		s.modelState = "DEPLOYING"
+		s.taskState = "COMPLETED"
+		_, _ = io.WriteString(w, `{"task_id":"t1","status":"CREATED"}`)
+	case path == "/tasks/t1":
+		s.taskPolls++
+		if s.taskPolls == 1 {
+			_, _ = io.WriteString(w, `{"state":"RUNNING"}`)
+			return
+		}
+		if s.taskState == "COMPLETED" {
+			s.modelState = "DEPLOYED"
+		}
+		fmt.Fprintf(w, `{"state":%q,"error":"download failed"}`, s.taskState)
+	case path == "/models/m1":
+		fmt.Fprintf(w, `{"model_state":%q}`, s.modelState)
+	case path == "/models/m1/_predict":
+		_, _ = io.WriteString(w, `{"inference_results":[{"output":[{"name":"sentence_embedding","data":[0.1,0.2]}]}]}`)
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusBadRequest)
+	}
+}
+
+func newModelManager(t *testing.T, stub *mlStub) *ml.ModelManager {
+	t.Helper()
+	ts := httptest.NewServer(stub)
+	t.Cleanup(ts.Close)
+
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}, DisableRetry: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+
+	m, err := ml.NewModelManager(ml.ModelManagerConfig{
+		Client:       ml.NewClient(osClient),
+		PollInterval: time.Millisecond,
+		RetryBackoff: time.Millisecond,
+		ChunkSize:    4,
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func TestModelManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uploads, deploys and predicts", func(t *testing.T) {
+		t.Parallel()
+		stub := &mlStub{chunks: map[string][]byte{}, deployFail: 1}
+		m := newModelManager(t, stub)
+
+		content := []byte("0123456789")
+		file := filepath.Join(t.TempDir(), "model.zip")
+		require.NoError(t, os.WriteFile(file, content, 0o600))
+
+		model, err := m.Ensure(t.Context(), ml.ModelSpec{
+			Name:           "embeddings",
+			Version:        "1.0.0",
+			ModelGroupName: "embeddings",
+			ModelFormat:    "TORCH_SCRIPT",
+			File:           file,
+		})
+		require.NoError(t, err)
+		require.Equal(t, &ml.ManagedModel{ID: "m1", GroupID: "g1", State: "DEPLOYED", Registered: true}, model)
+
+		sum := sha256.Sum256(content)
+		require.Equal(t, hex.EncodeToString(sum[:]), stub.meta["model_content_hash_value"])
+		require.Equal(t, "g1", stub.meta["model_group_id"])
+		require.InDelta(t, 3, stub.meta["total_chunks"], 0)
+		require.Equal(t, map[string][]byte{"0": []byte("0123"), "1": []byte("4567"), "2": []byte("89")}, stub.chunks)
+		require.Equal(t, 2, stub.taskPolls)
+
+		resp, err := m.Predict(t.Context(), model.ID, &ml.MLPredictModelBody{TextDocs: []string{"hello"}})
+		require.NoError(t, err)
+		require.Len(t, resp.InferenceResults, 1)
+	})
+
+	t.Run("reuses an existing model", func(t *testing.T) {
+		t.Parallel()
+		stub := &mlStub{
+			modelState: "DEPLOYED",
+			existing: `{"hits":{"total":{"value":2,"relation":"eq"},"hits":[
+				{"_id":"old","_source":{"name":"embeddings","model_version":"0.9.0","model_state":"DEPLOYED"}},
+				{"_id":"m1","_source":{"name":"embeddings","model_version":"1.0.0","model_state":"DEPLOYED","model_group_id":"g0"}}]}}`,
+		}
+		m := newModelManager(t, stub)
+
+		model, err := m.Ensure(t.Context(), ml.ModelSpec{
+			Name:    "embeddings",
+			Version: "1.0.0",
+			URL:     "https://example.com/model.zip",
+		})
+		require.NoError(t, err)
+		require.Equal(t, &ml.ManagedModel{ID: "m1", GroupID: "g0", State: "DEPLOYED"}, model)
+		require.Equal(t, []string{
+			"GET /_plugins/_ml/models/_search",
+			"GET /_plugins/_ml/models/m1",
+		}, stub.requests)
+	})
+
+	t.Run("failed registration task", func(t *testing.T) {
+		t.Parallel()
+		m := newModelManager(t, &mlStub{})
+
+		_, err := m.Register(t.Context(), ml.ModelSpec{
+			Name:         "embeddings",
+			Version:      "1.0.0",
+			ModelGroupID: "g1",
+			ModelFormat:  "TORCH_SCRIPT",
+			URL:          "https://example.com/model.zip",
+		})
+		require.ErrorIs(t, err, ml.ErrTaskFailed)
+		require.ErrorContains(t, err, "download failed")
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		t.Parallel()
+		m := newModelManager(t, &mlStub{})
+
+		_, err := m.Register(t.Context(), ml.ModelSpec{Version: "1.0.0"})
+		require.ErrorContains(t, err, "requires a name")
+		_, err = m.Register(t.Context(), ml.ModelSpec{Name: "a", URL: "u", ConnectorID: "c"})
+		require.ErrorContains(t, err, "more than one")
+		_, err = m.Register(t.Context(), ml.ModelSpec{Name: "a", File: "model.zip"})
+		require.ErrorContains(t, err, "model format")
+	})
+}
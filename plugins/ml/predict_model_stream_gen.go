@@ -14,7 +14,6 @@ import (
 	"io"
 	"net/http"
 
-	opensearch "github.com/opensearch-project/opensearch-go/v5"
 	"github.com/opensearch-project/opensearch-go/v5/internal/build"
 	osparams "github.com/opensearch-project/opensearch-go/v5/internal/params"
 	ospath "github.com/opensearch-project/opensearch-go/v5/internal/path"
@@ -101,29 +100,6 @@ func (r PredictModelStreamParams) get() map[string]string {
 	return params
 }
 
-// PredictModelStreamResp represents the response for the ml.predict_model_stream operation.
-//
-// Predicts a model in streaming mode.
-//
-// Available: >= 3.3.0.
-type PredictModelStreamResp struct {
-	response *opensearch.Response
-}
-
-// Inspect returns the raw OpenSearch response for debugging or advanced use.
-func (r PredictModelStreamResp) Inspect() Inspect {
-	return Inspect{Response: r.response}
-}
-
-// RawBody returns a fresh reader over the original response bytes,
-// useful when the typed response struct is incomplete for your use case.
-func (r PredictModelStreamResp) RawBody() io.Reader {
-	if r.response == nil || len(r.response.RawBody()) == 0 {
-		return nil
-	}
-	return bytes.NewReader(r.response.RawBody())
-}
-
 // MLPredictModelStreamBody is a typed component of the ml.predict_model_stream operation.
 type MLPredictModelStreamBody struct {
 	Parameters opensearchapi.MLParameters `json:"parameters"`
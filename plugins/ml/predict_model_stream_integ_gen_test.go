@@ -31,19 +31,21 @@ func TestPredictModelStream(t *testing.T) {
 	name := testutil.MustUniqueString(t, "test-predict-model-stream")
 
 	t.Run("success", func(t *testing.T) {
-		resp, err := client.PredictModelStream(t.Context(), ml.PredictModelStreamReq{ModelID: name, Body: &ml.MLPredictModelStreamBody{}})
+		stream, err := client.PredictModelStream(t.Context(), ml.PredictModelStreamReq{ModelID: name, Body: &ml.MLPredictModelStreamBody{}}, nil)
 		require.NoError(t, err)
-		require.NotNil(t, resp)
-		testutil.CompareRawJSONwithParsedJSON(t, resp, resp.Inspect().Response)
+		t.Cleanup(func() { _ = stream.Close() })
+		for _, err := range stream.All() {
+			require.NoError(t, err)
+		}
 	})
 
 	t.Run("inspect", func(t *testing.T) {
 		failingClient, err := plugintest.CreateFailingClient(t)
 		require.NoError(t, err)
 
-		res, err := failingClient.PredictModelStream(t.Context(), ml.PredictModelStreamReq{ModelID: name, Body: &ml.MLPredictModelStreamBody{}})
+		res, err := failingClient.PredictModelStream(t.Context(), ml.PredictModelStreamReq{ModelID: name, Body: &ml.MLPredictModelStreamBody{}}, nil)
 		require.Error(t, err)
 		require.NotNil(t, res)
-		plugintest.VerifyInspect(t, res.Inspect())
+		plugintest.VerifyInspect(t, ml.Inspect{Response: res.Response()})
 	})
 }
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package ml
+
+import (
+	"errors"
+	"io"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// StreamAggregator folds the chunk events of PredictModelStream and
+// ExecuteAgentStream into the response the buffered call would have returned.
+// Each chunk carries a slice of the generated text in an output's
+// dataAsMap.content; the aggregator concatenates those per inference result
+// and output name, and keeps the latest value of every other field.
+//
+// Feed it with Add while consuming the stream to render tokens as they arrive
+// and still end up with the full response. The zero value is ready to use.
+type StreamAggregator struct {
+	resp opensearchapi.MLPredictResponse
+}
+
+// Add merges one chunk into the aggregate.
+func (a *StreamAggregator) Add(chunk opensearchapi.MLPredictResponse) {
+	if chunk.Status != nil {
+		a.resp.Status = chunk.Status
+	}
+	if chunk.PredictionResult != nil {
+		a.resp.PredictionResult = chunk.PredictionResult
+	}
+	for i, result := range chunk.InferenceResults {
+		if i == len(a.resp.InferenceResults) {
+			a.resp.InferenceResults = append(a.resp.InferenceResults, opensearchapi.MLInferenceResults{})
+		}
+		agg := &a.resp.InferenceResults[i]
+		for j, out := range result.Output {
+			agg.Output = mergeOutput(agg.Output, j, out)
+		}
+	}
+}
+
+// Response returns the aggregate of every chunk added so far.
+func (a *StreamAggregator) Response() *opensearchapi.MLPredictResponse {
+	resp := a.resp
+	return &resp
+}
+
+// Done reports whether every streamed output seen so far has received its
+// last chunk.
+func (a *StreamAggregator) Done() bool {
+	seen := false
+	for _, result := range a.resp.InferenceResults {
+		for _, out := range result.Output {
+			if out.DataAsMap == nil || out.DataAsMap.IsLast == nil {
+				continue
+			}
+			if !*out.DataAsMap.IsLast {
+				return false
+			}
+			seen = true
+		}
+	}
+	return seen
+}
+
+// CollectStream drains stream into a [StreamAggregator] and returns the
+// aggregated response. The stream is closed on return. A chunk that fails to
+// decode aborts the collection.
+func CollectStream(stream *opensearch.EventStream[opensearchapi.MLPredictResponse]) (*opensearchapi.MLPredictResponse, error) {
+	defer stream.Close()
+
+	var agg StreamAggregator
+	for {
+		chunk, err := stream.Next()
+		if errors.Is(err, io.EOF) {
+			return agg.Response(), nil
+		}
+		if err != nil {
+			return agg.Response(), err
+		}
+		agg.Add(chunk)
+	}
+}
+
+// mergeOutput merges out into outputs. It matches by name and falls back to
+// position for unnamed outputs.
+func mergeOutput(outputs []opensearchapi.MLOutput, pos int, out opensearchapi.MLOutput) []opensearchapi.MLOutput {
+	idx := -1
+	if out.Name != nil {
+		for i := range outputs {
+			if outputs[i].Name != nil && *outputs[i].Name == *out.Name {
+				idx = i
+				break
+			}
+		}
+	} else if pos < len(outputs) && outputs[pos].Name == nil {
+		idx = pos
+	}
+	if idx < 0 {
+		// Copy dataAsMap so merging later chunks does not modify this one.
+		if out.DataAsMap != nil {
+			dm := *out.DataAsMap
+			out.DataAsMap = &dm
+		}
+		return append(outputs, out)
+	}
+
+	agg := &outputs[idx]
+	if out.DataType != nil {
+		agg.DataType = out.DataType
+	}
+	if out.Result != nil {
+		agg.Result = out.Result
+	}
+	if out.Data != nil {
+		agg.Data = out.Data
+	}
+	if out.Shape != nil {
+		agg.Shape = out.Shape
+	}
+	if out.ByteBuffer != nil {
+		agg.ByteBuffer = out.ByteBuffer
+	}
+	if out.DataAsMap == nil {
+		return outputs
+	}
+	if agg.DataAsMap == nil {
+		agg.DataAsMap = &opensearchapi.MLDataAsMap{}
+	}
+	if out.DataAsMap.Content != nil {
+		content := *out.DataAsMap.Content
+		if agg.DataAsMap.Content != nil {
+			content = *agg.DataAsMap.Content + content
+		}
+		agg.DataAsMap.Content = &content
+	}
+	if out.DataAsMap.IsLast != nil {
+		agg.DataAsMap.IsLast = out.DataAsMap.IsLast
+	}
+	return outputs
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package ml_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/ml"
+)
+
+func TestCollectStream(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_plugins/_ml/models/m1/_predict/stream" {
+			_, _ = io.WriteString(w, `{}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"inference_results":[{"output":[{"name":"response","dataAsMap":{"content":"Hello","is_last":false}}]}]}`,
+			`{"inference_results":[{"output":[{"name":"response","dataAsMap":{"content":", world","is_last":false}}]}]}`,
+			`{"inference_results":[{"output":[{"name":"response","dataAsMap":{"content":"!","is_last":true}}]}]}`,
+		} {
+			_, _ = io.WriteString(w, "data: "+chunk+"\n\n")
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	client := ml.NewClient(osClient)
+
+	stream, err := client.PredictModelStream(t.Context(), ml.PredictModelStreamReq{
+		ModelID:    "m1",
+		BodyReader: strings.NewReader(`{"parameters":{"messages":[]}}`),
+	}, nil)
+	require.NoError(t, err)
+
+	resp, err := ml.CollectStream(stream)
+	require.NoError(t, err)
+	require.Len(t, resp.InferenceResults, 1)
+	require.Len(t, resp.InferenceResults[0].Output, 1)
+	out := resp.InferenceResults[0].Output[0]
+	require.Equal(t, "response", *out.Name)
+	require.Equal(t, "Hello, world!", *out.DataAsMap.Content)
+	require.True(t, *out.DataAsMap.IsLast)
+}
+
+func TestStreamAggregator(t *testing.T) {
+	t.Parallel()
+
+	var agg ml.StreamAggregator
+	require.False(t, agg.Done())
+
+	for _, chunk := range []string{
+		`{"inference_results":[{"output":[{"name":"a","dataAsMap":{"content":"x","is_last":false}},{"name":"b","dataAsMap":{"content":"1","is_last":false}}]}]}`,
+		`{"inference_results":[{"output":[{"name":"b","dataAsMap":{"content":"2","is_last":true}}]}],"status":"RUNNING"}`,
+		`{"inference_results":[{"output":[{"name":"a","dataAsMap":{"content":"y","is_last":true}}]}],"status":"COMPLETED"}`,
+	} {
+		require.False(t, agg.Done())
+		var resp opensearchapi.MLPredictResponse
+		require.NoError(t, json.Unmarshal([]byte(chunk), &resp))
+		agg.Add(resp)
+	}
+	require.True(t, agg.Done())
+
+	resp := agg.Response()
+	require.Equal(t, "COMPLETED", *resp.Status)
+	outputs := resp.InferenceResults[0].Output
+	require.Len(t, outputs, 2)
+	require.Equal(t, "xy", *outputs[0].DataAsMap.Content)
+	require.Equal(t, "12", *outputs[1].DataAsMap.Content)
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package neural
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Normalization is the technique a normalization processor uses to bring
+// the scores of the sub-queries of a hybrid query onto a common scale.
+type Normalization string
+
+// Normalization techniques.
+const (
+	NormalizationMinMax Normalization = "min_max"
+	NormalizationL2     Normalization = "l2"
+	NormalizationZScore Normalization = "z_score"
+)
+
+// Combination is the technique used to combine the normalized sub-query
+// scores into a document's score.
+type Combination string
+
+// Combination techniques. CombinationRRF is only valid for a
+// [ScoreRankerProcessor].
+const (
+	CombinationArithmeticMean Combination = "arithmetic_mean"
+	CombinationGeometricMean  Combination = "geometric_mean"
+	CombinationHarmonicMean   Combination = "harmonic_mean"
+	CombinationRRF            Combination = "rrf"
+)
+
+// Processor names of the neural search plugin.
+const (
+	ProcessorNormalization          = "normalization-processor"
+	ProcessorScoreRanker            = "score-ranker-processor"
+	ProcessorHybridScoreExplanation = "hybrid_score_explanation"
+)
+
+// NormalizationProcessor defines a normalization-processor, the phase
+// results processor that normalizes and combines the scores of a hybrid
+// query. Zero fields use the plugin defaults, min_max and arithmetic_mean.
+type NormalizationProcessor struct {
+	Normalization Normalization
+	Combination   Combination
+	// Weights are the weights of the sub-queries, in query order. They must
+	// be non-negative and sum to 1.
+	Weights []float32
+
+	Description   string
+	Tag           string
+	IgnoreFailure bool
+}
+
+// Processor returns the processor definition for a search pipeline's
+// phase_results_processors.
+func (p NormalizationProcessor) Processor() (opensearchapi.SearchPipelinePhaseResultsProcessor, error) {
+	if p.Combination == CombinationRRF {
+		return opensearchapi.SearchPipelinePhaseResultsProcessor{}, errors.New("neural: rrf combination requires a score ranker processor")
+	}
+	if err := validateWeights(p.Weights); err != nil {
+		return opensearchapi.SearchPipelinePhaseResultsProcessor{}, err
+	}
+
+	proc := opensearchapi.SearchPipelineNormalizationPhaseResultsProcessor{
+		Description: ptr.NonZero(p.Description),
+		Tag:         ptr.NonZero(p.Tag),
+	}
+	if p.IgnoreFailure {
+		proc.IgnoreFailure = &p.IgnoreFailure
+	}
+	if p.Normalization != "" {
+		proc.Normalization = &opensearchapi.SearchPipelineScoreNormalization{Technique: ptr.NonZero(string(p.Normalization))}
+	}
+	if p.Combination != "" || len(p.Weights) > 0 {
+		proc.Combination = &opensearchapi.SearchPipelineScoreCombination{Technique: ptr.NonZero(string(p.Combination))}
+		if len(p.Weights) > 0 {
+			proc.Combination.Parameters = &opensearchapi.SearchPipelineScoreCombinationParameters{Weights: p.Weights}
+		}
+	}
+	return opensearchapi.NewSearchPipelinePhaseResultsProcessorFromNormalizationProcessor(
+		opensearchapi.SearchPipelinePhaseResultsProcessorNormalizationProcessor{NormalizationProcessor: proc},
+	), nil
+}
+
+// validateWeights checks the weights the way the plugin does, so that a bad
+// pipeline fails before it is sent.
+func validateWeights(weights []float32) error {
+	if len(weights) == 0 {
+		return nil
+	}
+	var sum float64
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(float64(w)) {
+			return fmt.Errorf("neural: weight %d is %v, weights must not be negative", i, w)
+		}
+		sum += float64(w)
+	}
+	if math.Abs(sum-1) > 1e-3 {
+		return fmt.Errorf("neural: weights sum to %v, not 1", sum)
+	}
+	return nil
+}
+
+// ScoreRankerProcessor defines a score-ranker-processor, which combines the
+// sub-query results of a hybrid query by reciprocal rank fusion.
+type ScoreRankerProcessor struct {
+	// RankConstant is the k of the RRF formula 1/(k+rank). Zero uses the
+	// plugin default, 60.
+	RankConstant int
+}
+
+// Processor returns the processor definition for a search pipeline's
+// phase_results_processors.
+func (p ScoreRankerProcessor) Processor() (opensearchapi.SearchPipelinePhaseResultsProcessor, error) {
+	if p.RankConstant < 0 {
+		return opensearchapi.SearchPipelinePhaseResultsProcessor{}, errors.New("neural: rank constant must not be negative")
+	}
+	combination := opensearchapi.SearchPipelineScoreRankerCombination{Technique: string(CombinationRRF)}
+	if p.RankConstant > 0 {
+		combination.RankConstant = &p.RankConstant
+	}
+	return opensearchapi.NewSearchPipelinePhaseResultsProcessorFromScoreRankerProcessor(
+		opensearchapi.SearchPipelinePhaseResultsProcessorScoreRankerProcessor{
+			ScoreRankerProcessor: opensearchapi.SearchPipelineScoreRankerPhaseResultsProcessor{Combination: combination},
+		},
+	), nil
+}
+
+// HybridPipeline describes a search pipeline for hybrid queries. Exactly one
+// of Normalization and ScoreRanker combines the sub-query scores.
+type HybridPipeline struct {
+	Description   string
+	Normalization *NormalizationProcessor
+	ScoreRanker   *ScoreRankerProcessor
+	// ScoreExplanation adds the hybrid_score_explanation response processor,
+	// which reports the normalized sub-query scores in the explanation of
+	// each hit when the search sets explain. See [DecodeHybridScore].
+	ScoreExplanation bool
+}
+
+// Structure returns the pipeline definition for
+// [opensearchapi.SearchPipelinePutReq].
+func (p HybridPipeline) Structure() (*opensearchapi.SearchPipelineStructure, error) {
+	if (p.Normalization == nil) == (p.ScoreRanker == nil) {
+		return nil, errors.New("neural: hybrid pipeline requires exactly one of Normalization and ScoreRanker")
+	}
+
+	var (
+		proc opensearchapi.SearchPipelinePhaseResultsProcessor
+		err  error
+	)
+	if p.Normalization != nil {
+		proc, err = p.Normalization.Processor()
+	} else {
+		proc, err = p.ScoreRanker.Processor()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	structure := &opensearchapi.SearchPipelineStructure{
+		Description:            ptr.NonZero(p.Description),
+		PhaseResultsProcessors: []opensearchapi.SearchPipelinePhaseResultsProcessor{proc},
+	}
+	if p.ScoreExplanation {
+		// The generated response processor union has no branch for it.
+		var explanation opensearchapi.SearchPipelineRespProcessor
+		explanation.SetRaw([]byte(`{"` + ProcessorHybridScoreExplanation + `":{}}`))
+		structure.RespProcessors = []opensearchapi.SearchPipelineRespProcessor{explanation}
+	}
+	return structure, nil
+}
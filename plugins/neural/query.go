@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package neural
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// maxHybridQueries is the number of sub-queries the plugin accepts in a
+// hybrid query.
+const maxHybridQueries = 5
+
+// Query describes a neural query, which embeds text or an image with a
+// model and runs a k-NN search with the embedding. Exactly one of K,
+// MinScore, and MaxDistance selects the neighbors.
+type Query struct {
+	// Field is the knn_vector field holding the document embeddings.
+	Field string
+	// QueryText and QueryImage, a base64-encoded image, are the input to
+	// embed. At least one is required.
+	QueryText  string
+	QueryImage string
+	// ModelID is the embedding model. Empty uses the default model of the
+	// index or field set by a neural_query_enricher.
+	ModelID string
+
+	K           int
+	MinScore    *float32
+	MaxDistance *float32
+
+	Filter *opensearchapi.CommonQueryDSLQueryContainer
+	Boost  *float32
+	Name   string
+}
+
+// Build returns the query container for the neural query.
+func (q Query) Build() (*opensearchapi.CommonQueryDSLQueryContainer, error) {
+	if q.Field == "" {
+		return nil, errors.New("neural: query requires a field")
+	}
+	if q.QueryText == "" && q.QueryImage == "" {
+		return nil, errors.New("neural: query requires QueryText or QueryImage")
+	}
+	modes := 0
+	for _, set := range []bool{q.K > 0, q.MinScore != nil, q.MaxDistance != nil} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 || q.K < 0 {
+		return nil, errors.New("neural: query requires exactly one of K, MinScore, and MaxDistance")
+	}
+
+	neural := opensearchapi.CommonQueryDSLNeuralQuery{
+		QueryText:   ptr.NonZero(q.QueryText),
+		QueryImage:  ptr.NonZero(q.QueryImage),
+		ModelID:     ptr.NonZero(q.ModelID),
+		MinScore:    q.MinScore,
+		MaxDistance: q.MaxDistance,
+		Filter:      q.Filter,
+	}
+	neural.Boost = q.Boost
+	neural.Name = ptr.NonZero(q.Name)
+	if q.K > 0 {
+		neural.K = &q.K
+	}
+	return &opensearchapi.CommonQueryDSLQueryContainer{
+		Neural: map[string]opensearchapi.CommonQueryDSLNeuralQuery{q.Field: neural},
+	}, nil
+}
+
+// SparseQuery describes a neural_sparse query on a rank_features field. The
+// query is either QueryText, encoded into tokens by ModelID or Analyzer, or
+// QueryTokens encoded by the caller.
+type SparseQuery struct {
+	Field string
+
+	QueryText string
+	ModelID   string
+	// Analyzer tokenizes QueryText on the cluster instead of a model.
+	Analyzer string
+
+	// QueryTokens are pre-encoded token weights.
+	QueryTokens map[string]float32
+
+	Boost *float32
+	Name  string
+}
+
+type sparseQuery struct {
+	QueryText   string             `json:"query_text,omitempty"`
+	ModelID     string             `json:"model_id,omitempty"`
+	Analyzer    string             `json:"analyzer,omitempty"`
+	QueryTokens map[string]float32 `json:"query_tokens,omitempty"`
+	Boost       *float32           `json:"boost,omitempty"`
+	Name        string             `json:"_name,omitempty"`
+}
+
+// Build returns the query container for the neural_sparse query.
+//
+// The generated query container has no neural_sparse member, so the query
+// is sent inside a wrapper query, which the cluster unwraps before running
+// it, also as a hybrid sub-query.
+func (q SparseQuery) Build() (*opensearchapi.CommonQueryDSLQueryContainer, error) {
+	if q.Field == "" {
+		return nil, errors.New("neural: sparse query requires a field")
+	}
+	if (q.QueryText == "") == (len(q.QueryTokens) == 0) {
+		return nil, errors.New("neural: sparse query requires exactly one of QueryText and QueryTokens")
+	}
+	if q.ModelID != "" && q.Analyzer != "" {
+		return nil, errors.New("neural: sparse query sets both ModelID and Analyzer")
+	}
+
+	inner, err := json.Marshal(map[string]map[string]sparseQuery{
+		"neural_sparse": {q.Field: {
+			QueryText:   q.QueryText,
+			ModelID:     q.ModelID,
+			Analyzer:    q.Analyzer,
+			QueryTokens: q.QueryTokens,
+			Boost:       q.Boost,
+			Name:        q.Name,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &opensearchapi.CommonQueryDSLQueryContainer{
+		Wrapper: &opensearchapi.CommonQueryDSLWrapperQuery{Query: base64.StdEncoding.EncodeToString(inner)},
+	}, nil
+}
+
+// HybridQuery combines up to five sub-queries, typically a lexical and a
+// neural one, whose scores a search pipeline normalizes and combines. See
+// [HybridPipeline].
+type HybridQuery struct {
+	Queries []*opensearchapi.CommonQueryDSLQueryContainer
+	// Filter is applied to every sub-query.
+	Filter *opensearchapi.CommonQueryDSLQueryContainer
+	// PaginationDepth is the number of results each shard retrieves per
+	// sub-query when the search pages with from.
+	PaginationDepth int
+}
+
+// Build returns the query container for the hybrid query.
+func (h HybridQuery) Build() (*opensearchapi.CommonQueryDSLQueryContainer, error) {
+	if len(h.Queries) == 0 || len(h.Queries) > maxHybridQueries {
+		return nil, errors.New("neural: hybrid query requires between 1 and 5 sub-queries")
+	}
+	hybrid := &opensearchapi.CommonQueryDSLHybridQuery{
+		Filter:  h.Filter,
+		Queries: make([]opensearchapi.CommonQueryDSLQueryContainer, len(h.Queries)),
+	}
+	for i, q := range h.Queries {
+		if q == nil {
+			return nil, errors.New("neural: hybrid sub-query is nil")
+		}
+		if q.Hybrid != nil {
+			return nil, errors.New("neural: hybrid queries cannot be nested")
+		}
+		hybrid.Queries[i] = *q
+	}
+	if h.PaginationDepth > 0 {
+		hybrid.PaginationDepth = &h.PaginationDepth
+	}
+	return &opensearchapi.CommonQueryDSLQueryContainer{Hybrid: hybrid}, nil
+}
+
+// SearchBody returns a search body running the hybrid query through the
+// search pipeline. An empty pipeline relies on the index's default
+// pipeline.
+func (h HybridQuery) SearchBody(pipeline string) (*opensearchapi.SearchBody, error) {
+	query, err := h.Build()
+	if err != nil {
+		return nil, err
+	}
+	return &opensearchapi.SearchBody{Query: query, SearchPipeline: ptr.NonZero(pipeline)}, nil
+}
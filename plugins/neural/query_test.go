@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package neural_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/neural"
+)
+
+func TestHybridQuery(t *testing.T) {
+	t.Parallel()
+
+	exists := &opensearchapi.CommonQueryDSLQueryContainer{
+		Exists: &opensearchapi.CommonQueryDSLExistsQuery{Field: "text"},
+	}
+	semantic, err := neural.Query{Field: "embedding", QueryText: "wild west", ModelID: "m1", K: 10, Name: "semantic"}.Build()
+	require.NoError(t, err)
+	sparse, err := neural.SparseQuery{Field: "tokens", QueryTokens: map[string]float32{"west": 1.5}}.Build()
+	require.NoError(t, err)
+
+	body, err := neural.HybridQuery{Queries: []*opensearchapi.CommonQueryDSLQueryContainer{exists, semantic, sparse}, PaginationDepth: 20}.SearchBody("hybrid")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	wrapped := base64.StdEncoding.EncodeToString([]byte(`{"neural_sparse":{"tokens":{"query_tokens":{"west":1.5}}}}`))
+	require.JSONEq(t, `{"search_pipeline":"hybrid","query":{"hybrid":{"pagination_depth":20,"queries":[
+		{"exists":{"field":"text"}},
+		{"neural":{"embedding":{"_name":"semantic","query_text":"wild west","model_id":"m1","k":10}}},
+		{"wrapper":{"query":"`+wrapped+`"}}]}}}`, string(data))
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := neural.HybridQuery{}.Build()
+		require.Error(t, err)
+		_, err = neural.HybridQuery{Queries: make([]*opensearchapi.CommonQueryDSLQueryContainer, 6)}.Build()
+		require.Error(t, err)
+		_, err = neural.HybridQuery{Queries: []*opensearchapi.CommonQueryDSLQueryContainer{nil}}.Build()
+		require.ErrorContains(t, err, "nil")
+		nested, err := neural.HybridQuery{Queries: []*opensearchapi.CommonQueryDSLQueryContainer{exists}}.Build()
+		require.NoError(t, err)
+		_, err = neural.HybridQuery{Queries: []*opensearchapi.CommonQueryDSLQueryContainer{nested}}.Build()
+		require.ErrorContains(t, err, "nested")
+
+		_, err = neural.Query{Field: "e", K: 1}.Build()
+		require.ErrorContains(t, err, "QueryText")
+		_, err = neural.Query{Field: "e", QueryText: "x", K: 1, MinScore: opensearch.ToPointer[float32](0.4)}.Build()
+		require.ErrorContains(t, err, "exactly one")
+		_, err = neural.SparseQuery{Field: "t", QueryText: "x", QueryTokens: map[string]float32{"x": 1}}.Build()
+		require.ErrorContains(t, err, "exactly one")
+		_, err = neural.SparseQuery{Field: "t", QueryText: "x", ModelID: "m", Analyzer: "bert-uncased"}.Build()
+		require.ErrorContains(t, err, "both")
+	})
+}
+
+func TestHybridPipeline(t *testing.T) {
+	t.Parallel()
+
+	t.Run("normalization", func(t *testing.T) {
+		t.Parallel()
+		structure, err := neural.HybridPipeline{
+			Description: "hybrid",
+			Normalization: &neural.NormalizationProcessor{
+				Normalization: neural.NormalizationMinMax,
+				Combination:   neural.CombinationArithmeticMean,
+				Weights:       []float32{0.3, 0.7},
+			},
+			ScoreExplanation: true,
+		}.Structure()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(structure)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"description":"hybrid",
+			"phase_results_processors":[{"normalization-processor":{
+				"normalization":{"technique":"min_max"},
+				"combination":{"technique":"arithmetic_mean","parameters":{"weights":[0.3,0.7]}}}}],
+			"response_processors":[{"hybrid_score_explanation":{}}]}`, string(data))
+	})
+
+	t.Run("score ranker", func(t *testing.T) {
+		t.Parallel()
+		structure, err := neural.HybridPipeline{ScoreRanker: &neural.ScoreRankerProcessor{RankConstant: 40}}.Structure()
+		require.NoError(t, err)
+
+		data, err := json.Marshal(structure)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"phase_results_processors":[{"score-ranker-processor":{"combination":{"technique":"rrf","rank_constant":40}}}]}`, string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := neural.HybridPipeline{}.Structure()
+		require.ErrorContains(t, err, "exactly one")
+		_, err = neural.HybridPipeline{Normalization: &neural.NormalizationProcessor{Weights: []float32{0.5, 0.6}}}.Structure()
+		require.ErrorContains(t, err, "sum to")
+		_, err = neural.NormalizationProcessor{Weights: []float32{-0.5, 1.5}}.Processor()
+		require.ErrorContains(t, err, "negative")
+		_, err = neural.NormalizationProcessor{Combination: neural.CombinationRRF}.Processor()
+		require.ErrorContains(t, err, "score ranker")
+		_, err = neural.ScoreRankerProcessor{RankConstant: -1}.Processor()
+		require.Error(t, err)
+	})
+}
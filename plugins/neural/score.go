@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package neural
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// ErrNoHybridScore is returned by [DecodeHybridScore] for a hit without a
+// hybrid score explanation. The search must set explain and run through a
+// pipeline with the hybrid_score_explanation processor.
+var ErrNoHybridScore = errors.New("neural: hit has no hybrid score explanation")
+
+const (
+	combinationSuffix   = " combination of:"
+	normalizationSuffix = " normalization of:"
+)
+
+// HybridScore is the breakdown of a hit's hybrid query score.
+type HybridScore struct {
+	// Score is the combined score.
+	Score float32
+	// Combination is the combination technique, such as arithmetic_mean
+	// or rrf.
+	Combination string
+	// SubScores are the scores of the sub-queries that matched the hit.
+	// Sub-queries that did not match are left out.
+	SubScores []SubQueryScore
+}
+
+// SubQueryScore is the score of one hybrid sub-query for a hit.
+type SubQueryScore struct {
+	// Normalized is the score after normalization.
+	Normalized float32
+	// Normalization describes the technique, such as min_max, or
+	// "rrf, rank_constant [60]" for reciprocal rank fusion.
+	Normalization string
+	// Raw is the sub-query's score before normalization.
+	Raw float32
+	// Description is the sub-query's own explanation.
+	Description string
+}
+
+// DecodeHybridScore decodes the hybrid score breakdown from the hit's
+// explanation.
+func DecodeHybridScore(hit opensearchapi.SearchHit) (*HybridScore, error) {
+	exp := hit.Explanation
+	if exp == nil {
+		return nil, ErrNoHybridScore
+	}
+	combination, ok := strings.CutSuffix(exp.Description, combinationSuffix)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected explanation %q", ErrNoHybridScore, exp.Description)
+	}
+
+	score := &HybridScore{
+		Score:       exp.Value,
+		Combination: combination,
+		SubScores:   make([]SubQueryScore, 0, len(exp.Details)),
+	}
+	for _, detail := range exp.Details {
+		normalization, ok := strings.CutSuffix(detail.Description, normalizationSuffix)
+		if !ok {
+			return nil, fmt.Errorf("neural: unexpected sub-query explanation %q", detail.Description)
+		}
+		sub := SubQueryScore{Normalized: detail.Value, Normalization: normalization}
+		if len(detail.Details) > 0 {
+			sub.Raw = detail.Details[0].Value
+			sub.Description = detail.Details[0].Description
+		}
+		score.SubScores = append(score.SubScores, sub)
+	}
+	return score, nil
+}
+
+// HybridScores decodes the hybrid score breakdown of every hit of the
+// response, in hit order. A nil response, as left by a failed search,
+// is an error.
+func HybridScores(resp *opensearchapi.SearchResp) ([]*HybridScore, error) {
+	if resp == nil {
+		return nil, errors.New("neural: no search response")
+	}
+	scores := make([]*HybridScore, len(resp.Hits.Hits))
+	for i, hit := range resp.Hits.Hits {
+		score, err := DecodeHybridScore(hit)
+		if err != nil {
+			return nil, fmt.Errorf("hit %d: %w", i, err)
+		}
+		scores[i] = score
+	}
+	return scores, nil
+}
+
+// ProcessorResult is the execution of a hybrid search processor reported by
+// a search with verbose_pipeline.
+type ProcessorResult struct {
+	Processor string
+	Tag       string
+	Status    string
+	Duration  time.Duration
+	// Error is the failure message of a failed processor.
+	Error string
+}
+
+// HybridProcessorResults returns the results of the normalization,
+// score ranker, and hybrid score explanation processors from the
+// response's processor_results, in execution order. A nil response has
+// none.
+func HybridProcessorResults(resp *opensearchapi.SearchResp) []ProcessorResult {
+	if resp == nil {
+		return nil
+	}
+	hybrid := []string{ProcessorNormalization, ProcessorScoreRanker, ProcessorHybridScoreExplanation}
+	var results []ProcessorResult
+	for _, detail := range resp.ProcessorResults {
+		if detail.ProcessorName == nil || !slices.Contains(hybrid, *detail.ProcessorName) {
+			continue
+		}
+		result := ProcessorResult{
+			Processor: *detail.ProcessorName,
+			Tag:       ptr.Deref(detail.Tag),
+			Status:    ptr.Deref(detail.Status),
+			Error:     ptr.Deref(detail.Error),
+		}
+		if detail.DurationMillis != nil {
+			result.Duration = time.Duration(*detail.DurationMillis) * time.Millisecond
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package neural_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/neural"
+)
+
+const hybridResponse = `{
+	"took": 12, "timed_out": false, "_shards": {"total":1,"successful":1,"skipped":0,"failed":0},
+	"hits": {"total":{"value":2,"relation":"eq"},"max_score":0.9251075,"hits":[
+		{"_index":"docs","_id":"1","_score":0.9251075,"_explanation":{
+			"value":0.9251075,"description":"arithmetic_mean, weights [0.3, 0.7] combination of:","details":[
+				{"value":1.0,"description":"min_max normalization of:","details":[
+					{"value":1.2336599,"description":"weight(text:west in 0) [PerFieldSimilarity], result of:","details":[]}]},
+				{"value":0.8930107,"description":"min_max normalization of:","details":[
+					{"value":0.015177966,"description":"within top 10","details":[]}]}]}},
+		{"_index":"docs","_id":"2","_score":0.016393442,"_explanation":{
+			"value":0.016393442,"description":"rrf combination of:","details":[
+				{"value":0.016393442,"description":"rrf, rank_constant [60] normalization of:","details":[
+					{"value":0.4,"description":"within top 10","details":[]}]}]}}
+	]},
+	"processor_results": [
+		{"processor_name":"filter_query","status":"success","duration_millis":1},
+		{"processor_name":"normalization-processor","tag":"norm","status":"success","duration_millis":3},
+		{"processor_name":"hybrid_score_explanation","status":"fail","error":"no explanation","duration_millis":0}
+	]
+}`
+
+func TestHybridScores(t *testing.T) {
+	t.Parallel()
+
+	var resp opensearchapi.SearchResp
+	require.NoError(t, json.Unmarshal([]byte(hybridResponse), &resp))
+
+	scores, err := neural.HybridScores(&resp)
+	require.NoError(t, err)
+	require.Equal(t, []*neural.HybridScore{
+		{
+			Score:       0.9251075,
+			Combination: "arithmetic_mean, weights [0.3, 0.7]",
+			SubScores: []neural.SubQueryScore{
+				{Normalized: 1, Normalization: "min_max", Raw: 1.2336599, Description: "weight(text:west in 0) [PerFieldSimilarity], result of:"},
+				{Normalized: 0.8930107, Normalization: "min_max", Raw: 0.015177966, Description: "within top 10"},
+			},
+		},
+		{
+			Score:       0.016393442,
+			Combination: "rrf",
+			SubScores: []neural.SubQueryScore{
+				{Normalized: 0.016393442, Normalization: "rrf, rank_constant [60]", Raw: 0.4, Description: "within top 10"},
+			},
+		},
+	}, scores)
+
+	require.Equal(t, []neural.ProcessorResult{
+		{Processor: neural.ProcessorNormalization, Tag: "norm", Status: "success", Duration: 3 * time.Millisecond},
+		{Processor: neural.ProcessorHybridScoreExplanation, Status: "fail", Error: "no explanation"},
+	}, neural.HybridProcessorResults(&resp))
+
+	_, err = neural.DecodeHybridScore(opensearchapi.SearchHit{})
+	require.ErrorIs(t, err, neural.ErrNoHybridScore)
+	_, err = neural.DecodeHybridScore(opensearchapi.SearchHit{Explanation: &opensearchapi.ExplainExplanation{Description: "sum of:"}})
+	require.ErrorIs(t, err, neural.ErrNoHybridScore)
+
+	resp.Hits.Hits = append(resp.Hits.Hits, opensearchapi.SearchHit{})
+	_, err = neural.HybridScores(&resp)
+	require.ErrorContains(t, err, "hit 2")
+
+	// Generated methods return a nil response with their error.
+	_, err = neural.HybridScores(nil)
+	require.Error(t, err)
+	require.Nil(t, neural.HybridProcessorResults(nil))
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package ppl
+
+import (
+	"context"
+
+	"github.com/opensearch-project/opensearch-go/v5/plugins/sql"
+)
+
+// QueryRows runs the PPL query and returns its rows as [sql.Rows], which
+// decode values from the schema the same way for SQL and PPL. Cursor
+// follow-ups and cursor close requests go through the SQL plugin endpoints,
+// which serve the cursors of both languages.
+func (c *Client) QueryRows(ctx context.Context, req *QueryReq) (*sql.Rows, error) {
+	resp, err := c.Query(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return sql.NewRows(ctx, sql.NewClient(c.Client), &sql.QueryResp{
+		Cursor:   resp.Cursor,
+		Datarows: resp.Datarows,
+		Schema:   resp.Schema,
+		Size:     resp.Size,
+		Status:   resp.Status,
+		Total:    resp.Total,
+	})
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package ppl_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/ppl"
+)
+
+func TestQueryRows(t *testing.T) {
+	t.Parallel()
+
+	var closed string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_plugins/_ppl":
+			_, _ = io.WriteString(w, `{"schema":[{"name":"host","type":"string"},{"name":"count()","type":"long"}],
+				"datarows":[["web-1",12],["web-2",7]],"total":4,"size":2,"status":200,"cursor":"p1"}`)
+		case "/_plugins/_sql/close":
+			body, _ := io.ReadAll(r.Body)
+			closed = string(body)
+			_, _ = io.WriteString(w, `{"succeeded":true}`)
+		default:
+			_, _ = io.WriteString(w, `{}`)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	client := ppl.NewClient(osClient)
+
+	rows, err := client.QueryRows(t.Context(), &ppl.QueryReq{Body: &opensearchapi.SQLQuery{
+		Query:     opensearch.ToPointer("source=logs | stats count() by host"),
+		FetchSize: opensearch.ToPointer(2),
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "count()", rows.Columns()[1].Name)
+
+	require.True(t, rows.Next())
+	var (
+		host  string
+		count int64
+	)
+	require.NoError(t, rows.Scan(&host, &count))
+	require.Equal(t, "web-1", host)
+	require.Equal(t, int64(12), count)
+
+	require.NoError(t, rows.Close())
+	require.JSONEq(t, `{"cursor":"p1"}`, closed)
+}
@@ -0,0 +1,406 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v5/internal/poll"
+	"github.com/opensearch-project/opensearch-go/v5/internal/ptr"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// defaultManagerPollInterval is used when ManagerConfig.PollInterval is zero.
+const defaultManagerPollInterval = time.Second
+
+// Replication states reported by the status API.
+const (
+	StatusBootstrapping = "BOOTSTRAPPING"
+	StatusSyncing       = "SYNCING"
+	StatusPaused        = "PAUSED"
+	StatusFailed        = "FAILED"
+	StatusNotInProgress = "REPLICATION NOT IN PROGRESS"
+)
+
+// ErrReplicationFailed is returned, wrapped, when a follower index stops
+// replicating while the manager waits for it to sync: it is FAILED, PAUSED,
+// or no longer replicating.
+var ErrReplicationFailed = errors.New("replication: replication failed")
+
+// ManagerConfig represents configuration of the replication manager.
+type ManagerConfig struct {
+	// Leader is a client of the leader cluster. It resolves index patterns
+	// and reports leader stats. Required.
+	Leader *Client
+	// Follower is a client of the follower cluster, which runs the
+	// replication. Required.
+	Follower *Client
+
+	// LeaderAlias is the name of the connection to the leader cluster
+	// configured on the follower cluster (cluster.remote.<alias>). Required.
+	LeaderAlias string
+
+	// UseRoles are the security roles replication runs with on either
+	// cluster. Required when the security plugin is enabled.
+	UseRoles *opensearchapi.ReplicationUseRoles
+
+	// FollowerIndex names the follower index of a leader index. Defaults to
+	// the leader index name.
+	FollowerIndex func(leaderIndex string) string
+
+	// PollInterval is the pause between polls of the replication status.
+	// Defaults to 1s.
+	PollInterval time.Duration
+}
+
+// Manager runs cross-cluster replication workflows that span several calls:
+// following the indices matching a pattern, waiting for them to sync,
+// measuring the replication lag, and failing over to the follower cluster.
+type Manager struct {
+	leader   *Client
+	follower *Client
+	config   ManagerConfig
+}
+
+// NewManager creates a new replication manager.
+func NewManager(cfg ManagerConfig) (*Manager, error) {
+	if cfg.Leader == nil || cfg.Follower == nil {
+		return nil, errors.New("replication: manager requires a leader and a follower client")
+	}
+	if cfg.LeaderAlias == "" {
+		return nil, errors.New("replication: manager requires a leader alias")
+	}
+	if cfg.FollowerIndex == nil {
+		cfg.FollowerIndex = func(leaderIndex string) string { return leaderIndex }
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultManagerPollInterval
+	}
+	return &Manager{leader: cfg.Leader, follower: cfg.Follower, config: cfg}, nil
+}
+
+// FollowReq selects the leader indices for [Manager.Follow].
+type FollowReq struct {
+	// Pattern is a leader index name or wildcard pattern. Required.
+	Pattern string
+	// Rule, when set, also creates an autofollow rule with this name for
+	// Pattern, so that leader indices created later are followed as well.
+	// Autofollowed indices keep the leader index name.
+	Rule string
+}
+
+// FollowedIndex is a follower index set up by [Manager.Follow].
+type FollowedIndex struct {
+	Leader   string
+	Follower string
+	// Status is the replication status after the call.
+	Status string
+	// Started is true when this call started the replication and false when
+	// the index was already replicating.
+	Started bool
+}
+
+// Follow starts replicating every leader index matching the pattern that is
+// not replicated yet. It does not wait for the follower indices to sync;
+// see [Manager.WaitSyncing].
+func (m *Manager) Follow(ctx context.Context, req FollowReq) ([]FollowedIndex, error) {
+	if req.Pattern == "" {
+		return nil, errors.New("replication: follow requires an index pattern")
+	}
+
+	var resolved opensearchapi.IndicesResolveIndexResp
+	if _, err := request(ctx, m.leader, http.MethodGet, opensearchapi.IndicesResolveIndexReq{Name: []string{req.Pattern}}, &resolved); err != nil {
+		return nil, fmt.Errorf("replication: resolve %s on the leader: %w", req.Pattern, err)
+	}
+
+	followed := make([]FollowedIndex, 0, len(resolved.Indices))
+	for _, index := range resolved.Indices {
+		f := FollowedIndex{Leader: index.Name, Follower: m.config.FollowerIndex(index.Name)}
+		status, err := m.status(ctx, f.Follower)
+		if err != nil {
+			return followed, err
+		}
+		if status.Status == nil || *status.Status == StatusNotInProgress {
+			body := &opensearchapi.ReplicationReplication{
+				LeaderAlias: &m.config.LeaderAlias,
+				LeaderIndex: &f.Leader,
+				UseRoles:    m.config.UseRoles,
+			}
+			if _, err := m.follower.Start(ctx, StartReq{Index: f.Follower, Body: body}); err != nil {
+				return followed, fmt.Errorf("replication: start %s: %w", f.Follower, err)
+			}
+			f.Started = true
+			if status, err = m.status(ctx, f.Follower); err != nil {
+				return followed, err
+			}
+		}
+		f.Status = ptr.Deref(status.Status)
+		followed = append(followed, f)
+	}
+
+	if req.Rule != "" {
+		rule := &opensearchapi.ReplicationCreateRule{
+			LeaderAlias: &m.config.LeaderAlias,
+			Name:        &req.Rule,
+			Pattern:     &req.Pattern,
+			UseRoles:    m.config.UseRoles,
+		}
+		if _, err := m.follower.ReplicationRule.CreateReplicationRule(ctx, &CreateReplicationRuleReq{Body: rule}); err != nil {
+			return followed, fmt.Errorf("replication: create autofollow rule %s: %w", req.Rule, err)
+		}
+	}
+	return followed, nil
+}
+
+// WaitSyncing polls the replication status of the follower indices until
+// all of them are SYNCING. It returns an error wrapping ErrReplicationFailed
+// as soon as one of them fails, is paused, or is not replicating.
+func (m *Manager) WaitSyncing(ctx context.Context, followers ...string) error {
+	pending := slices.Clone(followers)
+	for {
+		var next []string
+		for _, index := range pending {
+			status, err := m.status(ctx, index)
+			if err != nil {
+				return err
+			}
+			switch state := ptr.Deref(status.Status); state {
+			case StatusSyncing:
+			case StatusBootstrapping:
+				next = append(next, index)
+			default:
+				return fmt.Errorf("%w: %s is in state %s%s", ErrReplicationFailed, index, state, ptr.ReasonSuffix(status.Reason))
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		pending = next
+		if err := poll.Sleep(ctx, m.config.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// IndexLag is the replication lag of one follower index.
+type IndexLag struct {
+	Follower string
+	Leader   string
+	Status   string
+
+	// LeaderCheckpoint and FollowerCheckpoint are the sequence number
+	// checkpoints of either index, as reported by the follower stats, and
+	// CheckpointLag is their difference.
+	LeaderCheckpoint   int64
+	FollowerCheckpoint int64
+	CheckpointLag      int64
+
+	// OperationsRead is the number of operations the leader cluster served
+	// for the index, OperationsWritten the number the follower cluster
+	// applied, and OperationsLag their difference.
+	OperationsRead    int64
+	OperationsWritten int64
+	OperationsLag     int64
+}
+
+// Lag reports the replication lag of the follower indices, or of every
+// follower index when none is given, by comparing the follower stats of
+// the follower cluster with the leader stats of the leader cluster.
+func (m *Manager) Lag(ctx context.Context, followers ...string) ([]IndexLag, error) {
+	followerStats, err := m.follower.FollowerStats(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("replication: follower stats: %w", err)
+	}
+	leaderStats, err := m.leader.LeaderStats(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("replication: leader stats: %w", err)
+	}
+	if len(followers) == 0 {
+		followers = slices.Sorted(maps.Keys(followerStats.IndexStats))
+	}
+
+	lags := make([]IndexLag, 0, len(followers))
+	for _, index := range followers {
+		status, err := m.status(ctx, index)
+		if err != nil {
+			return lags, err
+		}
+		lag := IndexLag{Follower: index, Leader: ptr.Deref(status.LeaderIndex), Status: ptr.Deref(status.Status)}
+		if fs, ok := followerStats.IndexStats[index]; ok {
+			lag.LeaderCheckpoint = count(fs.LeaderCheckpoint)
+			lag.FollowerCheckpoint = count(fs.FollowerCheckpoint)
+			lag.OperationsWritten = count(fs.OperationsWritten)
+		}
+		if ls, ok := leaderStats.IndexStats[lag.Leader]; ok {
+			lag.OperationsRead = count(ls.OperationsRead)
+		}
+		lag.CheckpointLag = lag.LeaderCheckpoint - lag.FollowerCheckpoint
+		lag.OperationsLag = lag.OperationsRead - lag.OperationsWritten
+		lags = append(lags, lag)
+	}
+	return lags, nil
+}
+
+// FailoverReq describes a controlled failover for [Manager.Failover].
+type FailoverReq struct {
+	// Indices are the follower indices to promote. Required.
+	Indices []string
+	// Aliases maps alias names to the promoted index they should point to
+	// on the follower cluster. Each alias is moved away from the indices
+	// holding it and becomes the write alias of its target, in a single
+	// atomic alias update.
+	Aliases map[string]string
+}
+
+// FailoverResult reports a failover run by [Manager.Failover].
+type FailoverResult struct {
+	// Lag is the replication lag measured once replication was paused: the
+	// operations the promoted indices miss.
+	Lag []IndexLag
+	// Promoted are the follower indices that are now regular, writable
+	// indices.
+	Promoted []string
+}
+
+// Failover promotes the follower indices to regular indices: it pauses
+// their replication, records the remaining lag, stops the replication,
+// lifts any write block left on the indices, and swaps the aliases over to
+// them. On error, the result lists the indices promoted so far.
+func (m *Manager) Failover(ctx context.Context, req FailoverReq) (*FailoverResult, error) {
+	if len(req.Indices) == 0 {
+		return nil, errors.New("replication: failover requires follower indices")
+	}
+	for alias, index := range req.Aliases {
+		if !slices.Contains(req.Indices, index) {
+			return nil, fmt.Errorf("replication: alias %s targets %s, which is not failed over", alias, index)
+		}
+	}
+
+	result := &FailoverResult{}
+	for _, index := range req.Indices {
+		status, err := m.status(ctx, index)
+		if err != nil {
+			return result, err
+		}
+		if state := ptr.Deref(status.Status); state == StatusSyncing || state == StatusBootstrapping {
+			if _, err := m.follower.Pause(ctx, PauseReq{Index: index, Body: strings.NewReader(`{}`)}); err != nil {
+				return result, fmt.Errorf("replication: pause %s: %w", index, err)
+			}
+		}
+	}
+
+	var err error
+	if result.Lag, err = m.Lag(ctx, req.Indices...); err != nil {
+		return result, err
+	}
+
+	for _, index := range req.Indices {
+		if err := m.promote(ctx, index); err != nil {
+			return result, err
+		}
+		result.Promoted = append(result.Promoted, index)
+	}
+
+	if len(req.Aliases) > 0 {
+		if err := m.swapAliases(ctx, req.Aliases); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// promote stops the replication of the follower index, which turns it into
+// a regular index, and clears the write block setting.
+func (m *Manager) promote(ctx context.Context, index string) error {
+	status, err := m.status(ctx, index)
+	if err != nil {
+		return err
+	}
+	if ptr.Deref(status.Status) != StatusNotInProgress {
+		if _, err := m.follower.Stop(ctx, StopReq{Index: index, Body: strings.NewReader(`{}`)}); err != nil {
+			return fmt.Errorf("replication: stop %s: %w", index, err)
+		}
+	}
+
+	writable := "false"
+	settings := opensearchapi.IndicesPutSettingsReq{
+		Indices: []string{index},
+		Body:    &opensearchapi.IndicesIndexSettings{BlocksWrite: &writable},
+	}
+	var resp opensearchapi.IndicesPutSettingsResp
+	if _, err := request(ctx, m.follower, http.MethodPut, settings, &resp); err != nil {
+		return fmt.Errorf("replication: lift write block of %s: %w", index, err)
+	}
+	return nil
+}
+
+// swapAliases points each alias at its target index on the follower
+// cluster, removing it from the indices currently holding it.
+func (m *Manager) swapAliases(ctx context.Context, aliases map[string]string) error {
+	names := slices.Sorted(maps.Keys(aliases))
+
+	var actions []opensearchapi.IndicesUpdateAliasesAction
+	for _, alias := range names {
+		var current opensearchapi.IndicesGetAliasResp
+		resp, err := request(ctx, m.follower, http.MethodGet, opensearchapi.IndicesGetAliasReq{Name: []string{alias}}, &current)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return fmt.Errorf("replication: get alias %s: %w", alias, err)
+		}
+		for _, index := range slices.Sorted(maps.Keys(current.Entries)) {
+			if index != aliases[alias] {
+				actions = append(actions, opensearchapi.IndicesUpdateAliasesAction{
+					Remove: &opensearchapi.IndicesUpdateAliasesRemoveAction{Index: &index, Alias: &alias},
+				})
+			}
+		}
+	}
+	isWriteIndex := true
+	for _, alias := range names {
+		target := aliases[alias]
+		actions = append(actions, opensearchapi.IndicesUpdateAliasesAction{
+			Add: &opensearchapi.IndicesUpdateAliasesAddAction{Index: &target, Alias: &alias, IsWriteIndex: &isWriteIndex},
+		})
+	}
+
+	update := opensearchapi.IndicesUpdateAliasesReq{Body: &opensearchapi.IndicesUpdateAliasesBody{Actions: actions}}
+	var updated opensearchapi.IndicesUpdateAliasesResp
+	if _, err := request(ctx, m.follower, http.MethodPost, update, &updated); err != nil {
+		return fmt.Errorf("replication: swap aliases: %w", err)
+	}
+	return nil
+}
+
+// status returns the replication status of the follower index. An index
+// that does not exist on the follower cluster is not replicating.
+func (m *Manager) status(ctx context.Context, index string) (*StatusResp, error) {
+	resp, err := m.follower.Status(ctx, StatusReq{Index: index})
+	if err != nil {
+		if r := resp.Inspect().Response; r != nil && r.StatusCode == http.StatusNotFound {
+			notInProgress := StatusNotInProgress
+			return &StatusResp{Status: &notInProgress}, nil
+		}
+		return nil, fmt.Errorf("replication: status of %s: %w", index, err)
+	}
+	return resp, nil
+}
+
+func count(f *float64) int64 {
+	if f == nil {
+		return 0
+	}
+	return int64(*f)
+}
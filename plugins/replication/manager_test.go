@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package replication_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/replication"
+)
+
+// leaderStub is the leader cluster, holding two indices matching logs-*.
+type leaderStub struct{}
+
+func (leaderStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/_resolve/index/logs-*":
+		_, _ = io.WriteString(w, `{"indices":[{"name":"logs-1","attributes":["open"]},{"name":"logs-2","attributes":["open"]}],"aliases":[],"data_streams":[]}`)
+	case "/_plugins/_replication/leader_stats":
+		_, _ = io.WriteString(w, `{"num_replicated_indices":2,"operations_read":150,"index_stats":{
+			"logs-1":{"operations_read":100},
+			"logs-2":{"operations_read":50}}}`)
+	default:
+		_, _ = io.WriteString(w, `{}`)
+	}
+}
+
+// followerStub is the follower cluster. logs-2 is replicating already; a
+// started index bootstraps for syncPolls status polls.
+type followerStub struct {
+	mu sync.Mutex
+
+	status    map[string]string
+	polls     map[string]int
+	syncPolls int
+	failed    string
+
+	aliases  map[string][]string
+	requests []string
+	bodies   map[string]string
+}
+
+func newFollowerStub() *followerStub {
+	return &followerStub{
+		status:    map[string]string{"logs-2": replication.StatusSyncing},
+		polls:     map[string]int{},
+		syncPolls: 2,
+		aliases:   map[string][]string{"logs": {"old-logs"}},
+		bodies:    map[string]string{},
+	}
+}
+
+func (s *followerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if r.Method != http.MethodGet {
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.bodies[r.URL.Path] = string(body)
+	}
+
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/_plugins/_replication/") && strings.Count(path, "/") == 4:
+		index, action := strings.Split(path, "/")[3], strings.Split(path, "/")[4]
+		s.replicate(w, index, action)
+	case path == "/_plugins/_replication/follower_stats":
+		_, _ = io.WriteString(w, `{"num_syncing_indices":2,"index_stats":{
+			"logs-1":{"leader_checkpoint":99,"follower_checkpoint":90,"operations_written":91},
+			"logs-2":{"leader_checkpoint":49,"follower_checkpoint":49,"operations_written":50}}}`)
+	case strings.HasPrefix(path, "/_alias/"):
+		alias := strings.TrimPrefix(path, "/_alias/")
+		indices, ok := s.aliases[alias]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"error":"alias [%s] missing","status":404}`, alias)
+			return
+		}
+		entries := map[string]any{}
+		for _, index := range indices {
+			entries[index] = map[string]any{"aliases": map[string]any{alias: map[string]any{}}}
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	default:
+		_, _ = io.WriteString(w, `{"acknowledged":true}`)
+	}
+}
+
+func (s *followerStub) replicate(w http.ResponseWriter, index, action string) {
+	state, ok := s.status[index]
+	switch action {
+	case "_status":
+		if !ok {
+			_, _ = io.WriteString(w, `{"status":"REPLICATION NOT IN PROGRESS"}`)
+			return
+		}
+		if state == replication.StatusBootstrapping {
+			if s.polls[index]++; s.polls[index] > s.syncPolls {
+				state = replication.StatusSyncing
+				if index == s.failed {
+					state = replication.StatusFailed
+				}
+				s.status[index] = state
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"status":%q,"reason":"User initiated","leader_alias":"leader","leader_index":%q,"follower_index":%q}`, state, index, index)
+		return
+	case "_start":
+		s.status[index] = replication.StatusBootstrapping
+	case "_pause":
+		s.status[index] = replication.StatusPaused
+	case "_stop":
+		delete(s.status, index)
+	}
+	_, _ = io.WriteString(w, `{"acknowledged":true}`)
+}
+
+func newManager(t *testing.T, follower *followerStub) *replication.Manager {
+	t.Helper()
+
+	clients := make([]*replication.Client, 2)
+	for i, handler := range []http.Handler{leaderStub{}, follower} {
+		ts := httptest.NewServer(handler)
+		t.Cleanup(ts.Close)
+		osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = osClient.Close() })
+		clients[i] = replication.NewClient(osClient)
+	}
+
+	manager, err := replication.NewManager(replication.ManagerConfig{
+		Leader:       clients[0],
+		Follower:     clients[1],
+		LeaderAlias:  "leader",
+		PollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+	return manager
+}
+
+func TestManagerFollow(t *testing.T) {
+	t.Parallel()
+
+	follower := newFollowerStub()
+	manager := newManager(t, follower)
+
+	followed, err := manager.Follow(t.Context(), replication.FollowReq{Pattern: "logs-*", Rule: "logs"})
+	require.NoError(t, err)
+	require.Equal(t, []replication.FollowedIndex{
+		{Leader: "logs-1", Follower: "logs-1", Status: replication.StatusBootstrapping, Started: true},
+		{Leader: "logs-2", Follower: "logs-2", Status: replication.StatusSyncing},
+	}, followed)
+	require.Equal(t, []string{
+		"PUT /_plugins/_replication/logs-1/_start",
+		"POST /_plugins/_replication/_autofollow",
+	}, follower.requests)
+	require.JSONEq(t, `{"leader_alias":"leader","leader_index":"logs-1"}`, follower.bodies["/_plugins/_replication/logs-1/_start"])
+	require.JSONEq(t, `{"leader_alias":"leader","name":"logs","pattern":"logs-*"}`, follower.bodies["/_plugins/_replication/_autofollow"])
+
+	require.NoError(t, manager.WaitSyncing(t.Context(), "logs-1", "logs-2"))
+	require.Equal(t, 3, follower.polls["logs-1"])
+
+	lags, err := manager.Lag(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []replication.IndexLag{
+		{
+			Follower: "logs-1", Leader: "logs-1", Status: replication.StatusSyncing,
+			LeaderCheckpoint: 99, FollowerCheckpoint: 90, CheckpointLag: 9,
+			OperationsRead: 100, OperationsWritten: 91, OperationsLag: 9,
+		},
+		{
+			Follower: "logs-2", Leader: "logs-2", Status: replication.StatusSyncing,
+			LeaderCheckpoint: 49, FollowerCheckpoint: 49,
+			OperationsRead: 50, OperationsWritten: 50,
+		},
+	}, lags)
+}
+
+func TestManagerWaitSyncingFailed(t *testing.T) {
+	t.Parallel()
+
+	follower := newFollowerStub()
+	follower.failed = "logs-1"
+	manager := newManager(t, follower)
+
+	_, err := manager.Follow(t.Context(), replication.FollowReq{Pattern: "logs-*"})
+	require.NoError(t, err)
+	err = manager.WaitSyncing(t.Context(), "logs-1")
+	require.ErrorIs(t, err, replication.ErrReplicationFailed)
+	require.ErrorContains(t, err, "logs-1 is in state FAILED: User initiated")
+}
+
+func TestManagerFailover(t *testing.T) {
+	t.Parallel()
+
+	follower := newFollowerStub()
+	follower.status["logs-1"] = replication.StatusSyncing
+	manager := newManager(t, follower)
+
+	result, err := manager.Failover(t.Context(), replication.FailoverReq{
+		Indices: []string{"logs-1", "logs-2"},
+		Aliases: map[string]string{"logs": "logs-1", "logs-read": "logs-2"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"logs-1", "logs-2"}, result.Promoted)
+	require.Len(t, result.Lag, 2)
+	require.Equal(t, replication.StatusPaused, result.Lag[0].Status)
+	require.Equal(t, int64(9), result.Lag[0].OperationsLag)
+
+	require.Equal(t, []string{
+		"POST /_plugins/_replication/logs-1/_pause",
+		"POST /_plugins/_replication/logs-2/_pause",
+		"POST /_plugins/_replication/logs-1/_stop",
+		"PUT /logs-1/_settings",
+		"POST /_plugins/_replication/logs-2/_stop",
+		"PUT /logs-2/_settings",
+		"POST /_aliases",
+	}, follower.requests)
+	require.JSONEq(t, `{"blocks.write":"false"}`, follower.bodies["/logs-1/_settings"])
+	require.JSONEq(t, `{"actions":[
+		{"remove":{"index":"old-logs","alias":"logs"}},
+		{"add":{"index":"logs-1","alias":"logs","is_write_index":true}},
+		{"add":{"index":"logs-2","alias":"logs-read","is_write_index":true}}]}`, follower.bodies["/_aliases"])
+	require.Empty(t, follower.status)
+
+	_, err = manager.Failover(t.Context(), replication.FailoverReq{
+		Indices: []string{"logs-1"},
+		Aliases: map[string]string{"logs": "logs-3"},
+	})
+	require.ErrorContains(t, err, "alias logs targets logs-3, which is not failed over")
+}
@@ -0,0 +1,521 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/wI2L/jsondiff"
+	"gopkg.in/yaml.v3"
+)
+
+// ResourceKind identifies a type of security configuration. The values match
+// the REST API path segments and the _meta.type of the plugin's configuration
+// files.
+type ResourceKind string
+
+// Resource kinds managed by Reconcile.
+const (
+	ResourceActionGroups  ResourceKind = "actiongroups"
+	ResourceTenants       ResourceKind = "tenants"
+	ResourceRoles         ResourceKind = "roles"
+	ResourceRolesMapping  ResourceKind = "rolesmapping"
+	ResourceInternalUsers ResourceKind = "internalusers"
+)
+
+// resourceKinds lists the kinds in the order creates and updates are applied,
+// so that roles see their action groups and tenants and mappings see their
+// roles. Deletes are applied in reverse.
+var resourceKinds = []ResourceKind{
+	ResourceActionGroups,
+	ResourceTenants,
+	ResourceRoles,
+	ResourceRolesMapping,
+	ResourceInternalUsers,
+}
+
+// resourceFiles maps the base names of the plugin's configuration files to
+// their kind.
+var resourceFiles = map[string]ResourceKind{
+	"action_groups":  ResourceActionGroups,
+	"tenants":        ResourceTenants,
+	"roles":          ResourceRoles,
+	"roles_mapping":  ResourceRolesMapping,
+	"internal_users": ResourceInternalUsers,
+}
+
+// protectedFlags mark entities that the REST API does not allow to change.
+var protectedFlags = []string{"reserved", "static", "hidden"}
+
+// DesiredConfig is the security configuration Reconcile converges the cluster
+// to. It holds the entities of each kind by name, in the shape of the
+// plugin's roles.yml, roles_mapping.yml, and related files. Kinds without an
+// entry are left alone.
+type DesiredConfig map[ResourceKind]map[string]any
+
+// LoadDesiredConfig reads security configuration files in YAML or JSON. The
+// kind of each file is taken from its _meta.type, or else from its base name,
+// such as roles.yml or roles_mapping.json.
+func LoadDesiredConfig(paths ...string) (DesiredConfig, error) {
+	desired := make(DesiredConfig)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if err := desired.Add(resourceFiles[base], data); err != nil {
+			return nil, fmt.Errorf("security: %s: %w", path, err)
+		}
+	}
+	return desired, nil
+}
+
+// Add parses data, a YAML or JSON document mapping entity names to entities,
+// and adds the entities to d. When kind is empty, the document's _meta.type
+// names it. Adding an entity twice is an error.
+func (d DesiredConfig) Add(kind ResourceKind, data []byte) error {
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	// Round-trip through JSON so values compare equal to the live config.
+	if err := normalizeJSON(&doc); err != nil {
+		return err
+	}
+
+	if meta, ok := doc["_meta"].(map[string]any); ok {
+		if typ, ok := meta["type"].(string); ok {
+			if kind != "" && ResourceKind(typ) != kind {
+				return fmt.Errorf("document of type %s added as %s", typ, kind)
+			}
+			kind = ResourceKind(typ)
+		}
+		delete(doc, "_meta")
+	}
+	if !slices.Contains(resourceKinds, kind) {
+		return fmt.Errorf("unknown security resource kind %q", kind)
+	}
+
+	if d[kind] == nil {
+		d[kind] = make(map[string]any, len(doc))
+	}
+	for name, entity := range doc {
+		if _, ok := d[kind][name]; ok {
+			return fmt.Errorf("%s %s is defined more than once", kind, name)
+		}
+		d[kind][name] = entity
+	}
+	return nil
+}
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	// DryRun computes the changes without applying them.
+	DryRun bool
+	// Prune deletes the entities of a managed kind that are not in the
+	// desired config. Reserved, static, and hidden entities are never
+	// deleted.
+	Prune bool
+}
+
+// ChangeAction is the kind of change Reconcile makes to an entity.
+type ChangeAction string
+
+// Change actions.
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+	// ChangeSkip is reported for a desired entity that differs from the
+	// cluster but is reserved, static, or hidden and cannot be changed.
+	ChangeSkip ChangeAction = "skip"
+)
+
+// Change is a single change to a security entity.
+type Change struct {
+	Kind   ResourceKind
+	Name   string
+	Action ChangeAction
+	// Body is the entity sent for a create.
+	Body json.RawMessage
+	// Patch is the JSON Patch document sent for an update.
+	Patch json.RawMessage
+	// Reason explains a skip.
+	Reason string
+}
+
+// String formats the change as a single line.
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s/%s", c.Action, c.Kind, c.Name)
+	switch c.Action {
+	case ChangeUpdate:
+		s += " " + string(c.Patch)
+	case ChangeSkip:
+		s += " (" + c.Reason + ")"
+	}
+	return s
+}
+
+// ReconcileResult lists the changes Reconcile made, or would make in a dry
+// run, in the order they are applied.
+type ReconcileResult struct {
+	Changes []Change
+	DryRun  bool
+}
+
+// String formats the changes one per line, as dry-run output.
+func (r *ReconcileResult) String() string {
+	var b strings.Builder
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Reconcile converges the security configuration of the cluster to desired.
+// For every kind in desired, it creates missing entities and updates
+// differing ones with a minimal JSON Patch; with Prune it also deletes the
+// entities desired does not list.
+//
+// Entities are compared after dropping null and empty values, so omitting a
+// field and leaving it empty are equivalent. Reserved, static, and hidden
+// entities are never changed. Password hashes cannot be read back, so the
+// hash and password of internal users are only sent when a user is created.
+//
+// The changes are applied in dependency order. When a change fails,
+// Reconcile stops and returns the full plan together with the error.
+func (c *Client) Reconcile(ctx context.Context, desired DesiredConfig, opts *ReconcileOptions) (*ReconcileResult, error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+	result := &ReconcileResult{DryRun: opts.DryRun}
+
+	var deletes []Change
+	for _, kind := range resourceKinds {
+		want, ok := desired[kind]
+		if !ok {
+			continue
+		}
+		api := c.resourceAPI(kind)
+		live, err := api.list(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("security: get %s: %w", kind, err)
+		}
+		changes, err := planKind(kind, want, live, opts.Prune)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			if change.Action == ChangeDelete {
+				deletes = append(deletes, change)
+			} else {
+				result.Changes = append(result.Changes, change)
+			}
+		}
+	}
+	slices.Reverse(deletes)
+	result.Changes = append(result.Changes, deletes...)
+
+	if opts.DryRun {
+		return result, nil
+	}
+	for _, change := range result.Changes {
+		if err := c.apply(ctx, change); err != nil {
+			return result, fmt.Errorf("security: %s %s/%s: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+	return result, nil
+}
+
+// planKind computes the changes for the entities of one kind, sorted by name.
+func planKind(kind ResourceKind, desired, live map[string]any, prune bool) ([]Change, error) {
+	var changes []Change
+	for _, name := range sortedKeys(desired) {
+		want, _ := desired[name].(map[string]any)
+		have, exists := live[name].(map[string]any)
+
+		if flag := protectedFlag(want); flag != "" || exists && protectedFlag(have) != "" {
+			if flag == "" {
+				flag = protectedFlag(have)
+			}
+			if !exists || !equalEntities(kind, want, have) {
+				changes = append(changes, Change{Kind: kind, Name: name, Action: ChangeSkip, Reason: flag})
+			}
+			continue
+		}
+
+		if !exists {
+			body, err := json.Marshal(normalize(want))
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, Change{Kind: kind, Name: name, Action: ChangeCreate, Body: body})
+			continue
+		}
+
+		patch, err := jsondiff.Compare(comparableEntity(kind, have), comparableEntity(kind, want),
+			jsondiff.Equivalent(), jsondiff.LCS(), jsondiff.Rationalize())
+		if err != nil {
+			return nil, fmt.Errorf("security: diff %s/%s: %w", kind, name, err)
+		}
+		if len(patch) == 0 {
+			continue
+		}
+		body, err := json.Marshal(patch)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, Change{Kind: kind, Name: name, Action: ChangeUpdate, Patch: body})
+	}
+
+	if prune {
+		for _, name := range sortedKeys(live) {
+			have, _ := live[name].(map[string]any)
+			if _, ok := desired[name]; ok || protectedFlag(have) != "" {
+				continue
+			}
+			changes = append(changes, Change{Kind: kind, Name: name, Action: ChangeDelete})
+		}
+	}
+	return changes, nil
+}
+
+// protectedFlag returns the first of the reserved, static, and hidden flags
+// set on the entity, or "" when none is.
+func protectedFlag(entity map[string]any) string {
+	for _, flag := range protectedFlags {
+		if v, _ := entity[flag].(bool); v {
+			return flag
+		}
+	}
+	return ""
+}
+
+func equalEntities(kind ResourceKind, a, b map[string]any) bool {
+	patch, err := jsondiff.Compare(comparableEntity(kind, a), comparableEntity(kind, b), jsondiff.Equivalent())
+	return err == nil && len(patch) == 0
+}
+
+// comparableEntity returns the normalized entity without the fields the REST API
+// reports but does not accept or cannot return.
+func comparableEntity(kind ResourceKind, entity map[string]any) any {
+	out, _ := normalize(entity).(map[string]any)
+	if out == nil {
+		out = map[string]any{}
+	}
+	for _, flag := range protectedFlags {
+		delete(out, flag)
+	}
+	if kind == ResourceInternalUsers {
+		delete(out, "hash")
+		delete(out, "password")
+	}
+	return out
+}
+
+// normalize returns a copy of v without null, empty string, empty array,
+// and empty object values in objects.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			e = normalize(e)
+			switch e := e.(type) {
+			case nil:
+				continue
+			case string:
+				if e == "" {
+					continue
+				}
+			case []any:
+				if len(e) == 0 {
+					continue
+				}
+			case map[string]any:
+				if len(e) == 0 {
+					continue
+				}
+			}
+			out[k] = e
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = normalize(e)
+		}
+		return out
+	}
+	return v
+}
+
+func normalizeJSON(v *map[string]any) error {
+	data, err := json.Marshal(*v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (c *Client) apply(ctx context.Context, change Change) error {
+	api := c.resourceAPI(change.Kind)
+	switch change.Action {
+	case ChangeCreate:
+		return api.create(ctx, change.Name, bytes.NewReader(change.Body))
+	case ChangeUpdate:
+		return api.patch(ctx, change.Name, bytes.NewReader(change.Patch))
+	case ChangeDelete:
+		return api.delete(ctx, change.Name)
+	}
+	return nil
+}
+
+// resourceAPI binds the list, create, patch, and delete operations of one
+// kind.
+type resourceAPI struct {
+	list   func(ctx context.Context) (map[string]any, error)
+	create func(ctx context.Context, name string, body io.Reader) error
+	patch  func(ctx context.Context, name string, body io.Reader) error
+	delete func(ctx context.Context, name string) error
+}
+
+func (c *Client) resourceAPI(kind ResourceKind) resourceAPI {
+	switch kind {
+	case ResourceActionGroups:
+		return resourceAPI{
+			list: func(ctx context.Context) (map[string]any, error) {
+				resp, err := c.ActionGroup.GetActionGroups(ctx, nil)
+				return decodeEntities(resp, err)
+			},
+			create: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.ActionGroup.CreateActionGroup(ctx, CreateActionGroupReq{ActionGroup: name, BodyReader: body})
+				return err
+			},
+			patch: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.ActionGroup.PatchActionGroup(ctx, PatchActionGroupReq{ActionGroup: name, Body: body})
+				return err
+			},
+			delete: func(ctx context.Context, name string) error {
+				_, err := c.ActionGroup.DeleteActionGroup(ctx, DeleteActionGroupReq{ActionGroup: name})
+				return err
+			},
+		}
+	case ResourceTenants:
+		return resourceAPI{
+			list: func(ctx context.Context) (map[string]any, error) {
+				resp, err := c.Tenant.GetTenants(ctx, nil)
+				return decodeEntities(resp, err)
+			},
+			create: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.Tenant.CreateTenant(ctx, CreateTenantReq{Tenant: name, BodyReader: body})
+				return err
+			},
+			patch: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.Tenant.PatchTenant(ctx, PatchTenantReq{Tenant: name, Body: body})
+				return err
+			},
+			delete: func(ctx context.Context, name string) error {
+				_, err := c.Tenant.DeleteTenant(ctx, DeleteTenantReq{Tenant: name})
+				return err
+			},
+		}
+	case ResourceRoles:
+		return resourceAPI{
+			list: func(ctx context.Context) (map[string]any, error) {
+				resp, err := c.Role.GetRoles(ctx, nil)
+				return decodeEntities(resp, err)
+			},
+			create: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.Role.CreateRole(ctx, CreateRoleReq{Role: name, BodyReader: body})
+				return err
+			},
+			patch: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.Role.PatchRole(ctx, PatchRoleReq{Role: name, Body: body})
+				return err
+			},
+			delete: func(ctx context.Context, name string) error {
+				_, err := c.Role.DeleteRole(ctx, DeleteRoleReq{Role: name})
+				return err
+			},
+		}
+	case ResourceRolesMapping:
+		return resourceAPI{
+			list: func(ctx context.Context) (map[string]any, error) {
+				resp, err := c.RoleMapping.GetRoleMappings(ctx, nil)
+				return decodeEntities(resp, err)
+			},
+			create: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.RoleMapping.CreateRoleMapping(ctx, CreateRoleMappingReq{Role: name, BodyReader: body})
+				return err
+			},
+			patch: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.RoleMapping.PatchRoleMapping(ctx, PatchRoleMappingReq{Role: name, Body: body})
+				return err
+			},
+			delete: func(ctx context.Context, name string) error {
+				_, err := c.RoleMapping.DeleteRoleMapping(ctx, DeleteRoleMappingReq{Role: name})
+				return err
+			},
+		}
+	default:
+		return resourceAPI{
+			list: func(ctx context.Context) (map[string]any, error) {
+				resp, err := c.User.GetUsers(ctx, nil)
+				return decodeEntities(resp, err)
+			},
+			create: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.User.CreateUser(ctx, CreateUserReq{Username: name, BodyReader: body})
+				return err
+			},
+			patch: func(ctx context.Context, name string, body io.Reader) error {
+				_, err := c.User.PatchUser(ctx, PatchUserReq{Username: name, Body: body})
+				return err
+			},
+			delete: func(ctx context.Context, name string) error {
+				_, err := c.User.DeleteUser(ctx, DeleteUserReq{Username: name})
+				return err
+			},
+		}
+	}
+}
+
+// decodeEntities decodes the raw body of a list response into entities by
+// name.
+func decodeEntities[T interface{ RawBody() io.Reader }](resp T, err error) (map[string]any, error) {
+	if err != nil {
+		return nil, err
+	}
+	entities := make(map[string]any)
+	body := resp.RawBody()
+	if body == nil {
+		return entities, nil
+	}
+	if err := json.NewDecoder(body).Decode(&entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
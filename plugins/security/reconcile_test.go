@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package security_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/security"
+)
+
+// securityStub serves the live security config and records every change
+// request with its body.
+type securityStub struct {
+	mu       sync.Mutex
+	live     map[string]string // list response by resource kind
+	requests []string
+}
+
+func (s *securityStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/_plugins/_security/api/")
+	if !ok {
+		_, _ = io.WriteString(w, `{}`)
+		return
+	}
+	if r.Method == http.MethodGet {
+		_, _ = io.WriteString(w, s.live[strings.TrimSuffix(path, "/")])
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	s.requests = append(s.requests, strings.TrimSpace(r.Method+" "+path+" "+string(body)))
+	_, _ = io.WriteString(w, `{"status":"OK","message":"done"}`)
+}
+
+func newSecurityStub(t *testing.T) (*security.Client, *securityStub) {
+	t.Helper()
+	stub := &securityStub{live: map[string]string{
+		"roles": `{
+			"all_access":{"reserved":true,"hidden":false,"cluster_permissions":["*"],"static":false},
+			"ops":{"reserved":false,"hidden":false,"static":false,"cluster_permissions":["cluster_monitor"],
+				"index_permissions":[{"index_patterns":["logs-*"],"allowed_actions":["read"],"fls":[],"masked_fields":[]}],
+				"tenant_permissions":[],"description":""},
+			"stale":{"reserved":false,"hidden":false,"static":false,"cluster_permissions":["cluster_monitor"]}
+		}`,
+		"rolesmapping": `{
+			"ops":{"reserved":false,"hidden":false,"backend_roles":["ops"],"hosts":[],"users":[]}
+		}`,
+		"internalusers": `{
+			"admin":{"reserved":true,"hidden":false,"backend_roles":["admin"],"attributes":{}},
+			"alice":{"reserved":false,"hidden":false,"backend_roles":["ops"],"attributes":{"team":"sre"}}
+		}`,
+	}}
+	ts := httptest.NewServer(stub)
+	t.Cleanup(ts.Close)
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	return security.NewClient(osClient), stub
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	paths := []string{
+		write("roles.yml", `
+_meta:
+  type: "roles"
+  config_version: 2
+all_access:
+  cluster_permissions: ["cluster_all"]
+ops:
+  cluster_permissions:
+    - cluster_monitor
+    - cluster_composite_ops_ro
+  index_permissions:
+    - index_patterns: ["logs-*"]
+      allowed_actions: ["read"]
+dev:
+  cluster_permissions: ["cluster_monitor"]
+`),
+		write("roles_mapping.json", `{"ops":{"backend_roles":["ops"],"users":[]}}`),
+		write("internal_users.yml", `
+alice:
+  hash: "$2y$12$abc"
+  backend_roles: ["ops"]
+  attributes:
+    team: sre
+bob:
+  hash: "$2y$12$def"
+  backend_roles: ["dev"]
+`),
+	}
+	desired, err := security.LoadDesiredConfig(paths...)
+	require.NoError(t, err)
+	require.Len(t, desired, 3)
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+		client, stub := newSecurityStub(t)
+
+		result, err := client.Reconcile(t.Context(), desired, &security.ReconcileOptions{DryRun: true, Prune: true})
+		require.NoError(t, err)
+		require.True(t, result.DryRun)
+		require.Empty(t, stub.requests)
+		require.Equal(t, `skip roles/all_access (reserved)
+create roles/dev
+update roles/ops [{"value":"cluster_composite_ops_ro","op":"add","path":"/cluster_permissions/1"}]
+create internalusers/bob
+delete roles/stale
+`, result.String())
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		t.Parallel()
+		client, stub := newSecurityStub(t)
+
+		result, err := client.Reconcile(t.Context(), desired, nil)
+		require.NoError(t, err)
+		require.Len(t, result.Changes, 4)
+		require.Equal(t, []string{
+			`PUT roles/dev {"cluster_permissions":["cluster_monitor"]}`,
+			`PATCH roles/ops [{"value":"cluster_composite_ops_ro","op":"add","path":"/cluster_permissions/1"}]`,
+			`PUT internalusers/bob {"backend_roles":["dev"],"hash":"$2y$12$def"}`,
+		}, stub.requests)
+	})
+
+	t.Run("prune keeps reserved entities", func(t *testing.T) {
+		t.Parallel()
+		client, stub := newSecurityStub(t)
+
+		result, err := client.Reconcile(t.Context(), security.DesiredConfig{security.ResourceInternalUsers: {}}, &security.ReconcileOptions{Prune: true})
+		require.NoError(t, err)
+		require.Equal(t, "delete internalusers/alice\n", result.String())
+		require.Equal(t, []string{"DELETE internalusers/alice"}, stub.requests)
+	})
+}
+
+func TestDesiredConfigAdd(t *testing.T) {
+	t.Parallel()
+
+	desired := make(security.DesiredConfig)
+	require.NoError(t, desired.Add("", []byte(`{"_meta":{"type":"tenants"},"team":{"description":"Team tenant"}}`)))
+	require.Contains(t, desired[security.ResourceTenants], "team")
+
+	require.ErrorContains(t, desired.Add(security.ResourceTenants, []byte(`team: {}`)), "more than once")
+	require.ErrorContains(t, desired.Add(security.ResourceRoles, []byte(`{"_meta":{"type":"tenants"}}`)), "added as roles")
+	require.ErrorContains(t, desired.Add("", []byte(`x: {}`)), "unknown security resource kind")
+	require.Error(t, desired.Add(security.ResourceRoles, []byte(`: [`)))
+}
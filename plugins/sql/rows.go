@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+)
+
+// Column describes a result column as reported in the query schema.
+type Column struct {
+	Name  string `json:"name"`
+	Alias string `json:"alias,omitempty"`
+	// Type is the OpenSearch SQL type, such as "keyword", "long", or
+	// "timestamp", in lower case.
+	Type string `json:"type"`
+}
+
+// Label returns the alias of the column, or its name when it has none.
+func (c Column) Label() string {
+	if c.Alias != "" {
+		return c.Alias
+	}
+	return c.Name
+}
+
+// Rows is the result of a query. Its cursor starts before the first row; use
+// Next to advance from row to row. When the query was sent with a fetch_size,
+// Next requests the following pages with the returned cursor as they are
+// needed.
+//
+// Rows must be closed when the caller stops reading before the end, so the
+// server can release an open cursor. Reading to the end closes it
+// automatically.
+type Rows struct {
+	client  *Client
+	ctx     context.Context //nolint:containedctx // used for the cursor follow-ups of Next, as database/sql.Rows does
+	columns []Column
+	total   int
+
+	page   [][]json.RawMessage
+	pos    int
+	cursor string
+	row    []json.RawMessage
+	closed bool
+	err    error
+}
+
+// QueryRows runs the query and returns its rows. The format query parameter
+// must be left at its jdbc default. Set Body.FetchSize to page through large
+// results with a cursor.
+func (c *Client) QueryRows(ctx context.Context, req *QueryReq) (*Rows, error) {
+	resp, err := c.Query(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return NewRows(ctx, c, resp)
+}
+
+// NewRows returns the rows of a query response already received, such as
+// one from the PPL plugin. Any further pages are fetched through client with
+// the response's cursor; ctx applies to those requests.
+func NewRows(ctx context.Context, client *Client, resp *QueryResp) (*Rows, error) {
+	columns := make([]Column, len(resp.Schema))
+	for i, raw := range resp.Schema {
+		if err := json.Unmarshal(raw, &columns[i]); err != nil {
+			return nil, fmt.Errorf("sql: decode schema column %d: %w", i, err)
+		}
+		columns[i].Type = strings.ToLower(columns[i].Type)
+	}
+
+	r := &Rows{
+		client:  client,
+		ctx:     ctx,
+		columns: columns,
+		page:    resp.Datarows,
+		pos:     -1,
+	}
+	if resp.Total != nil {
+		r.total = *resp.Total
+	}
+	if resp.Cursor != nil {
+		r.cursor = *resp.Cursor
+	}
+	return r, nil
+}
+
+// Columns returns the result columns.
+func (r *Rows) Columns() []Column {
+	return r.columns
+}
+
+// Total returns the total number of rows the server reported for the query,
+// which may exceed the rows of the first page.
+func (r *Rows) Total() int {
+	return r.total
+}
+
+// Next prepares the next row for reading with Scan. It returns false at the
+// end of the result or when fetching the next page fails; Err tells the two
+// apart.
+func (r *Rows) Next() bool {
+	if r.closed {
+		return false
+	}
+	r.pos++
+	for r.pos >= len(r.page) {
+		if r.cursor == "" {
+			r.closed = true
+			r.row = nil
+			return false
+		}
+		if err := r.fetch(); err != nil {
+			r.err = err
+			_ = r.Close()
+			return false
+		}
+	}
+	r.row = r.page[r.pos]
+	return true
+}
+
+func (r *Rows) fetch() error {
+	body, err := json.Marshal(map[string]string{"cursor": r.cursor})
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Query(r.ctx, &QueryReq{BodyReader: bytes.NewReader(body)})
+	if err != nil {
+		return err
+	}
+	r.page, r.pos, r.cursor = resp.Datarows, 0, ""
+	if resp.Cursor != nil {
+		r.cursor = *resp.Cursor
+	}
+	return nil
+}
+
+// Err returns the error, if any, that ended the iteration.
+func (r *Rows) Err() error {
+	return r.err
+}
+
+// Close stops the iteration and closes the server-side cursor when the
+// result was not read to the end. It is safe to call more than once.
+func (r *Rows) Close() error {
+	r.closed = true
+	r.row = nil
+	if r.cursor == "" {
+		return nil
+	}
+	cursor := r.cursor
+	r.cursor = ""
+	// Release the cursor even when the iteration stopped because ctx ended.
+	_, err := r.client.Close(context.WithoutCancel(r.ctx), &CloseReq{Body: &opensearchapi.SQLClose{Cursor: &cursor}})
+	return err
+}
+
+// RawValues returns the JSON values of the current row. The slice is valid
+// until the next call to Next.
+func (r *Rows) RawValues() []json.RawMessage {
+	return r.row
+}
+
+// Values returns the current row converted to Go values according to the
+// column types: nil, bool, int64, float64, string, time.Time, or
+// json.RawMessage for objects, arrays, and geo points.
+func (r *Rows) Values() ([]any, error) {
+	if err := r.checkRow(len(r.columns)); err != nil {
+		return nil, err
+	}
+	values := make([]any, len(r.row))
+	for i, raw := range r.row {
+		v, err := Value(r.columns[i], raw)
+		if err != nil {
+			return nil, fmt.Errorf("sql: column %s: %w", r.columns[i].Label(), err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Scan copies the columns of the current row into the values pointed at by
+// dest, one per column. Values are converted to the destination type:
+// numbers to any integer or floating-point type that holds them, date and
+// time types to time.Time, any value to string or []byte as its text, and
+// objects and arrays to maps, slices, or structs through encoding/json. A
+// null is stored as nil into pointers, interfaces, maps, and slices, and is
+// an error for other types. Destinations implementing database/sql.Scanner
+// receive the value Values would report.
+func (r *Rows) Scan(dest ...any) error {
+	if err := r.checkRow(len(dest)); err != nil {
+		return err
+	}
+	for i, d := range dest {
+		if err := assign(d, r.columns[i], r.row[i]); err != nil {
+			return fmt.Errorf("sql: scan column %d (%s): %w", i, r.columns[i].Label(), err)
+		}
+	}
+	return nil
+}
+
+func (r *Rows) checkRow(n int) error {
+	if r.row == nil {
+		if r.closed {
+			return errors.New("sql: rows are closed")
+		}
+		return errors.New("sql: no current row, call Next first")
+	}
+	if len(r.row) != len(r.columns) {
+		return fmt.Errorf("sql: row has %d values for %d columns", len(r.row), len(r.columns))
+	}
+	if n != len(r.columns) {
+		return fmt.Errorf("sql: expected %d destination arguments in Scan, not %d", len(r.columns), n)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package sql_test
+
+import (
+	stdsql "database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/sql"
+)
+
+const rowsSchema = `[
+	{"name":"name","type":"keyword"},
+	{"name":"age","alias":"years","type":"integer"},
+	{"name":"score","type":"double"},
+	{"name":"active","type":"boolean"},
+	{"name":"born","type":"timestamp"},
+	{"name":"nick","type":"text"},
+	{"name":"address","type":"object"}
+]`
+
+// cursorServer serves pages of a paged query: the initial query returns the
+// first page and a cursor, and each cursor request returns the next one.
+type cursorServer struct {
+	mu     sync.Mutex
+	pages  []string
+	closed []string
+	bodies []string
+}
+
+func (s *cursorServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	switch r.URL.Path {
+	case "/_plugins/_sql", "/_plugins/_ppl":
+		s.bodies = append(s.bodies, string(body))
+		var req struct {
+			Cursor string `json:"cursor"`
+		}
+		_ = json.Unmarshal(body, &req)
+		page := 0
+		if req.Cursor != "" {
+			page = int(req.Cursor[1] - '0')
+		}
+		if page >= len(s.pages) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"status":400,"error":{"type":"IllegalArgumentException","reason":"invalid cursor"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, s.pages[page])
+	case "/_plugins/_sql/close":
+		var req struct {
+			Cursor string `json:"cursor"`
+		}
+		_ = json.Unmarshal(body, &req)
+		s.closed = append(s.closed, req.Cursor)
+		_, _ = io.WriteString(w, `{"succeeded":true}`)
+	default:
+		_, _ = io.WriteString(w, `{}`)
+	}
+}
+
+func newRowsClient(t *testing.T, s *cursorServer) *sql.Client {
+	t.Helper()
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	osClient, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = osClient.Close() })
+	return sql.NewClient(osClient)
+}
+
+func pagedServer() *cursorServer {
+	return &cursorServer{pages: []string{
+		`{"schema":` + rowsSchema + `,"total":3,"size":2,"status":200,"cursor":"c1","datarows":[
+			["alice",30,1.5,true,"2000-01-02 03:04:05.123","al",{"city":"Berlin"}],
+			["bob",41,2,false,"1983-06-30 00:00:00",null,null]]}`,
+		`{"total":3,"size":1,"status":200,"datarows":[
+			["carol",25,3.25,true,"1999-12-31 23:59:59",null,{"city":"Oslo"}]]}`,
+	}}
+}
+
+func TestRows(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scans across pages", func(t *testing.T) {
+		t.Parallel()
+		srv := pagedServer()
+		client := newRowsClient(t, srv)
+
+		rows, err := client.QueryRows(t.Context(), &sql.QueryReq{Body: &opensearchapi.SQLQuery{
+			Query:     opensearch.ToPointer("SELECT * FROM people"),
+			FetchSize: opensearch.ToPointer(2),
+		}})
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.Equal(t, 3, rows.Total())
+		require.Len(t, rows.Columns(), 7)
+		require.Equal(t, "years", rows.Columns()[1].Label())
+		require.Equal(t, "integer", rows.Columns()[1].Type)
+
+		var names []string
+		for rows.Next() {
+			var (
+				name    string
+				age     int
+				score   float32
+				active  bool
+				born    time.Time
+				nick    stdsql.NullString
+				address map[string]string
+			)
+			require.NoError(t, rows.Scan(&name, &age, &score, &active, &born, &nick, &address))
+			names = append(names, name)
+			if name == "alice" {
+				require.Equal(t, 30, age)
+				require.InDelta(t, 1.5, score, 0)
+				require.True(t, active)
+				require.Equal(t, time.Date(2000, 1, 2, 3, 4, 5, 123e6, time.UTC), born)
+				require.Equal(t, stdsql.NullString{String: "al", Valid: true}, nick)
+				require.Equal(t, map[string]string{"city": "Berlin"}, address)
+			}
+			if name == "bob" {
+				require.False(t, nick.Valid)
+				require.Nil(t, address)
+			}
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []string{"alice", "bob", "carol"}, names)
+		require.Equal(t, `{"cursor":"c1"}`, srv.bodies[1])
+
+		// Reading to the end leaves no cursor to close.
+		require.NoError(t, rows.Close())
+		require.Empty(t, srv.closed)
+	})
+
+	t.Run("values and conversion errors", func(t *testing.T) {
+		t.Parallel()
+		client := newRowsClient(t, pagedServer())
+
+		rows, err := client.QueryRows(t.Context(), &sql.QueryReq{Body: &opensearchapi.SQLQuery{Query: opensearch.ToPointer("SELECT * FROM people")}})
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var name string
+		require.ErrorContains(t, rows.Scan(&name), "call Next first")
+
+		require.True(t, rows.Next())
+		require.True(t, rows.Next())
+		values, err := rows.Values()
+		require.NoError(t, err)
+		require.Equal(t, []any{
+			"bob", int64(41), 2.0, false, time.Date(1983, 6, 30, 0, 0, 0, 0, time.UTC), nil, nil,
+		}, values)
+
+		var (
+			age    int8
+			nick   string
+			anyVal any
+			raw    json.RawMessage
+			ptr    *string
+		)
+		require.ErrorContains(t, rows.Scan(&name, &age), "expected 7 destination arguments")
+		err = rows.Scan(&name, &age, &anyVal, &anyVal, &raw, &nick, &ptr)
+		require.ErrorContains(t, err, "converting null to string")
+		require.NoError(t, rows.Scan(&name, &age, &anyVal, &anyVal, &raw, &ptr, &ptr))
+		require.Nil(t, ptr)
+		require.JSONEq(t, `"1983-06-30 00:00:00"`, string(raw))
+	})
+
+	t.Run("close releases the cursor", func(t *testing.T) {
+		t.Parallel()
+		srv := pagedServer()
+		client := newRowsClient(t, srv)
+
+		rows, err := client.QueryRows(t.Context(), &sql.QueryReq{Body: &opensearchapi.SQLQuery{
+			Query:     opensearch.ToPointer("SELECT * FROM people"),
+			FetchSize: opensearch.ToPointer(2),
+		}})
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Close())
+		require.NoError(t, rows.Close())
+		require.False(t, rows.Next())
+		require.Equal(t, []string{"c1"}, srv.closed)
+	})
+
+	t.Run("failed follow-up", func(t *testing.T) {
+		t.Parallel()
+		srv := pagedServer()
+		srv.pages = srv.pages[:1]
+		client := newRowsClient(t, srv)
+
+		rows, err := client.QueryRows(t.Context(), &sql.QueryReq{Body: &opensearchapi.SQLQuery{
+			Query:     opensearch.ToPointer("SELECT * FROM people"),
+			FetchSize: opensearch.ToPointer(2),
+		}})
+		require.NoError(t, err)
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		require.Equal(t, 2, n)
+		var structErr *opensearch.StructError
+		require.ErrorAs(t, rows.Err(), &structErr)
+		require.Equal(t, []string{"c1"}, srv.closed)
+	})
+}
+
+type person struct {
+	Name  string
+	Years int `db:"years"`
+	Contact
+	Address *struct {
+		City string `json:"city"`
+	} `db:"address"`
+	Ignored string `db:"-"`
+}
+
+type Contact struct {
+	Nick  *string
+	Email string
+}
+
+func TestScanStruct(t *testing.T) {
+	t.Parallel()
+	client := newRowsClient(t, pagedServer())
+
+	rows, err := client.QueryRows(t.Context(), &sql.QueryReq{Body: &opensearchapi.SQLQuery{Query: opensearch.ToPointer("SELECT * FROM people")}})
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var people []person
+	for rows.Next() {
+		p, err := sql.ScanStruct[person](rows)
+		require.NoError(t, err)
+		people = append(people, p)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, people, 3)
+
+	require.Equal(t, "alice", people[0].Name)
+	require.Equal(t, 30, people[0].Years)
+	require.Equal(t, "al", *people[0].Nick)
+	require.Equal(t, "Berlin", people[0].Address.City)
+	require.Nil(t, people[1].Nick)
+	require.Nil(t, people[1].Address)
+
+	_, err = sql.ScanStruct[int](rows)
+	require.Error(t, err)
+}
@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package sql
+
+import (
+	"bytes"
+	stdsql "database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timeLayouts are the formats the SQL plugin uses for date and time values,
+// most specific first.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// columnKind groups the OpenSearch SQL types by the Go value they map to.
+type columnKind int
+
+const (
+	kindOther columnKind = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindTime
+	kindString
+)
+
+func kindOf(typ string) columnKind {
+	switch typ {
+	case "boolean":
+		return kindBool
+	case "byte", "short", "integer", "int", "long", "unsigned_long":
+		return kindInt
+	case "float", "half_float", "scaled_float", "double":
+		return kindFloat
+	case "date", "time", "timestamp", "datetime", "date_nanos":
+		return kindTime
+	case "keyword", "text", "string", "ip", "binary":
+		return kindString
+	}
+	return kindOther
+}
+
+// ScanType returns the Go type [Value] reports for non-null values of the
+// column, or the empty interface type when that depends on the value.
+func (c Column) ScanType() reflect.Type {
+	switch kindOf(c.Type) {
+	case kindBool:
+		return reflect.TypeFor[bool]()
+	case kindInt:
+		return reflect.TypeFor[int64]()
+	case kindFloat:
+		return reflect.TypeFor[float64]()
+	case kindTime:
+		return timeType
+	case kindString:
+		return reflect.TypeFor[string]()
+	}
+	return reflect.TypeFor[any]()
+}
+
+// Value converts a JSON value of col to a Go value: nil, bool, int64,
+// float64, string, time.Time, or json.RawMessage for objects, arrays, and
+// values of types without a scalar mapping such as geo_point.
+func Value(col Column, raw json.RawMessage) (any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '{', '[':
+		return json.RawMessage(raw), nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		if kindOf(col.Type) == kindTime {
+			return parseTime(s)
+		}
+		return s, nil
+	case 't', 'f':
+		return string(raw) == "true", nil
+	}
+
+	switch kindOf(col.Type) {
+	case kindFloat:
+		return strconv.ParseFloat(string(raw), 64)
+	case kindTime:
+		ms, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	if n, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+		return n, nil
+	}
+	return strconv.ParseFloat(string(raw), 64)
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a date or time", s)
+}
+
+// assign stores the JSON value raw of col into dest.
+func assign(dest any, col Column, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	switch d := dest.(type) {
+	case *json.RawMessage:
+		*d = append((*d)[:0], raw...)
+		return nil
+	case stdsql.Scanner:
+		v, err := Value(col, raw)
+		if err != nil {
+			return err
+		}
+		if rm, ok := v.(json.RawMessage); ok {
+			v = []byte(rm)
+		}
+		return d.Scan(v)
+	case *any:
+		v, err := Value(col, raw)
+		if err != nil {
+			return err
+		}
+		if rm, ok := v.(json.RawMessage); ok {
+			var decoded any
+			if err := json.Unmarshal(rm, &decoded); err != nil {
+				return err
+			}
+			v = decoded
+		}
+		*d = v
+		return nil
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("destination %T is not a non-nil pointer", dest)
+	}
+	return assignValue(rv.Elem(), col, raw)
+}
+
+func assignValue(v reflect.Value, col Column, raw json.RawMessage) error {
+	if v.CanAddr() {
+		switch dest := v.Addr().Interface().(type) {
+		case *json.RawMessage, stdsql.Scanner, *any:
+			return assign(dest, col, raw)
+		}
+	}
+
+	isNull := len(raw) == 0 || string(raw) == "null"
+	switch v.Kind() {
+	case reflect.Pointer:
+		if isNull {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return assign(v.Interface(), col, raw)
+	case reflect.Map, reflect.Slice:
+		if isNull {
+			v.SetZero()
+			return nil
+		}
+	}
+	if isNull {
+		return fmt.Errorf("converting null to %s is unsupported", v.Type())
+	}
+
+	if v.Type() == timeType {
+		t, err := Value(Column{Type: "timestamp"}, raw)
+		if err != nil {
+			return err
+		}
+		tt, ok := t.(time.Time)
+		if !ok {
+			return fmt.Errorf("converting %s to time.Time is unsupported", raw)
+		}
+		v.Set(reflect.ValueOf(tt))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text(raw))
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && raw[0] != '[' {
+			v.SetBytes([]byte(text(raw)))
+			return nil
+		}
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text(raw))
+		if err != nil {
+			return fmt.Errorf("converting %s to bool: %w", raw, err)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text(raw), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %s to %s: %w", raw, v.Type(), err)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text(raw), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %s to %s: %w", raw, v.Type(), err)
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text(raw), v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %s to %s: %w", raw, v.Type(), err)
+		}
+		v.SetFloat(f)
+		return nil
+	}
+	return json.Unmarshal(raw, v.Addr().Interface())
+}
+
+// text returns a JSON string's contents, or the literal of any other value.
+func text(raw json.RawMessage) string {
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	}
+	return string(raw)
+}
+
+// ScanStruct scans the current row into a new T, which must be a struct.
+// Columns are matched by label to the fields tagged `db:"label"`; untagged
+// exported fields match a label equal to their name ignoring case, and the
+// fields of embedded structs are promoted. A field tagged `db:"-"` is
+// skipped. Columns without a matching field are ignored.
+func ScanStruct[T any](rows *Rows) (T, error) {
+	var out T
+	if err := rows.checkRow(len(rows.columns)); err != nil {
+		return out, err
+	}
+	v := reflect.ValueOf(&out).Elem()
+	if v.Kind() != reflect.Struct {
+		return out, fmt.Errorf("sql: ScanStruct destination %s is not a struct", v.Type())
+	}
+
+	fields := structFields(v.Type())
+	for i, col := range rows.columns {
+		index, ok := fields[strings.ToLower(col.Label())]
+		if !ok {
+			continue
+		}
+		if err := assignValue(fieldByIndex(v, index), col, bytes.TrimSpace(rows.row[i])); err != nil {
+			return out, fmt.Errorf("sql: scan column %d (%s): %w", i, col.Label(), err)
+		}
+	}
+	return out, nil
+}
+
+var structFieldCache sync.Map // map[reflect.Type]map[string][]int
+
+// structFields maps lower-cased column labels to field indices of t.
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	collectFields(t, nil, fields)
+	structFieldCache.Store(t, fields)
+	return fields
+}
+
+func collectFields(t reflect.Type, prefix []int, fields map[string][]int) {
+	var embedded []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			embedded = append(embedded, f)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		key := strings.ToLower(name)
+		if _, ok := fields[key]; !ok {
+			fields[key] = append(append([]int(nil), prefix...), i)
+		}
+	}
+	// Fields of embedded structs are shadowed by those of the outer struct.
+	for _, f := range embedded {
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		collectFields(ft, append(append([]int(nil), prefix...), f.Index...), fields)
+	}
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but allocates nil embedded
+// struct pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,309 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package sqldriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchapi"
+	ossql "github.com/opensearch-project/opensearch-go/v5/plugins/sql"
+)
+
+// ExplainDirective, leading a query, marks it as one whose plan should be
+// returned instead of its results. The plan is a single row with one "plan"
+// column holding the JSON response of the explain API. It is a comment, so
+// SQL of the user's own, such as an EXPLAIN statement, is sent as written.
+const ExplainDirective = "/* " + DriverName + ":explain */"
+
+// ExplainQuery returns query prefixed with [ExplainDirective].
+func ExplainQuery(query string) string {
+	return ExplainDirective + " " + query
+}
+
+// ErrTxNotSupported is returned by Begin; the SQL plugin has no
+// transactions.
+var ErrTxNotSupported = errors.New(DriverName + ": transactions are not supported")
+
+var (
+	_ driver.Conn               = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+)
+
+type conn struct {
+	client      *ossql.Client
+	opts        Options
+	closeClient bool
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext returns a statement that interpolates its arguments when
+// executed; nothing is sent to the server.
+func (c *conn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	if c.closeClient {
+		return c.client.Client.Close()
+	}
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, ErrTxNotSupported
+}
+
+func (c *conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, ErrTxNotSupported
+}
+
+// CheckNamedValue accepts the default conversions and json.RawMessage,
+// which is interpolated as a string.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if raw, ok := nv.Value.(json.RawMessage); ok {
+		nv.Value = string(raw)
+		return nil
+	}
+	v, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	nv.Value = v
+	return nil
+}
+
+// Ping reports a node that cannot be reached or answers with a 5xx as
+// driver.ErrBadConn, so database/sql retries on a fresh connection. Any
+// other refusal, such as a 401 or 403, is returned as an ordinary error,
+// matching the opensearch error classes.
+func (c *conn) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, "/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Client.Request(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+	}
+	_ = resp.Body.Close()
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: %s", driver.ErrBadConn, resp.Status)
+	case resp.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("%s: ping: %w", DriverName, &opensearch.StringError{Status: resp.StatusCode, Err: resp.Status})
+	}
+	return nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if plan, ok := cutExplain(query); ok {
+		return c.explain(ctx, plan, args)
+	}
+
+	q, err := interpolate(query, args)
+	if err != nil {
+		return nil, err
+	}
+	body := &opensearchapi.SQLQuery{Query: &q}
+	if c.opts.FetchSize > 0 {
+		body.FetchSize = &c.opts.FetchSize
+	}
+	r, err := c.client.QueryRows(ctx, &ossql.QueryReq{Body: body})
+	if err != nil {
+		return nil, err
+	}
+	return &rows{rows: r}, nil
+}
+
+// deletedRowsColumn is the single column of the plugin's answer to a
+// DELETE, holding the number of deleted documents.
+const deletedRowsColumn = "deleted_rows"
+
+// ExecContext runs the statement. A DELETE reports the documents it deleted
+// as the affected rows; the plugin reports no count for other statements,
+// so they return driver.ResultNoRows.
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	q, err := interpolate(query, args)
+	if err != nil {
+		return nil, err
+	}
+	r, err := c.client.QueryRows(ctx, &ossql.QueryReq{Body: &opensearchapi.SQLQuery{Query: &q}})
+	if err != nil {
+		return nil, err
+	}
+	var result driver.Result = driver.ResultNoRows
+	if cols := r.Columns(); len(cols) == 1 && cols[0].Name == deletedRowsColumn && r.Next() {
+		var deleted int64
+		if err := r.Scan(&deleted); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		result = driver.RowsAffected(deleted)
+	}
+	if err := r.Err(); err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	if err := r.Close(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *conn) explain(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, err := interpolate(query, args)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Explain(ctx, &ossql.ExplainReq{Body: &opensearchapi.SQLExplain{Query: &q}})
+	if err != nil {
+		return nil, err
+	}
+	// RawBody is nil when the server answered with an empty body.
+	var plan []byte
+	if body := resp.RawBody(); body != nil {
+		if plan, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+	return &planRows{plan: plan}, nil
+}
+
+// cutExplain reports whether query starts with ExplainDirective, ignoring
+// leading space, and returns the query after it.
+func cutExplain(query string) (string, bool) {
+	const space = " \t\r\n"
+	query, ok := strings.CutPrefix(strings.TrimLeft(query, space), ExplainDirective)
+	return strings.TrimLeft(query, space), ok
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error { return nil }
+
+// NumInput returns -1; placeholders are counted during interpolation.
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+// rows adapts [ossql.Rows] to driver.Rows.
+type rows struct {
+	rows *ossql.Rows
+}
+
+func (r *rows) Columns() []string {
+	cols := r.rows.Columns()
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.Label()
+	}
+	return names
+}
+
+func (r *rows) Close() error {
+	return r.rows.Close()
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	values, err := r.rows.Values()
+	if err != nil {
+		return err
+	}
+	for i, v := range values {
+		if raw, ok := v.(json.RawMessage); ok {
+			v = []byte(raw)
+		}
+		dest[i] = v
+	}
+	return nil
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return strings.ToUpper(r.rows.Columns()[index].Type)
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	return r.rows.Columns()[index].ScanType()
+}
+
+func (r *rows) ColumnTypeNullable(int) (nullable, ok bool) {
+	return true, true
+}
+
+// planRows holds the single row returned for an explained query.
+type planRows struct {
+	plan []byte
+	done bool
+}
+
+func (r *planRows) Columns() []string { return []string{"plan"} }
+
+func (r *planRows) Close() error { return nil }
+
+func (r *planRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.plan
+	return nil
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package sqldriver_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/plugins/sql/sqldriver"
+)
+
+// sqlServer answers SQL plugin requests with two pages of results and
+// records the queries it receives.
+type sqlServer struct {
+	mu         sync.Mutex
+	queries    []map[string]any
+	closed     int
+	emptyPlan  bool // answer explain requests with an empty body
+	pingStatus int  // status of HEAD /; 0 answers 200
+}
+
+func (s *sqlServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	var req map[string]any
+	_ = json.Unmarshal(body, &req)
+	switch r.URL.Path {
+	case "/":
+		if s.pingStatus != 0 {
+			w.WriteHeader(s.pingStatus)
+		}
+	case "/_plugins/_sql":
+		s.queries = append(s.queries, req)
+		if q, _ := req["query"].(string); strings.HasPrefix(q, "DELETE") {
+			_, _ = io.WriteString(w, `{"schema":[{"name":"deleted_rows","type":"long"}],"total":1,"size":1,"status":200,"datarows":[[4]]}`)
+			return
+		}
+		if req["cursor"] == "c1" {
+			_, _ = io.WriteString(w, `{"total":3,"size":1,"status":200,"datarows":[["carol",25,"1999-12-31 23:59:59"]]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"schema":[{"name":"name","type":"keyword"},{"name":"age","alias":"years","type":"integer"},{"name":"born","type":"timestamp"}],
+			"total":3,"size":2,"status":200,"cursor":"c1","datarows":[["alice",30,"2000-01-02 03:04:05"],["bob",null,null]]}`)
+	case "/_plugins/_sql/_explain":
+		s.queries = append(s.queries, req)
+		if s.emptyPlan {
+			return
+		}
+		_, _ = io.WriteString(w, `{"root":{"name":"ProjectOperator","description":{},"children":[]}}`)
+	case "/_plugins/_sql/close":
+		s.closed++
+		_, _ = io.WriteString(w, `{"succeeded":true}`)
+	default:
+		_, _ = io.WriteString(w, `{}`)
+	}
+}
+
+func TestDriver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connector queries with cursor paging", func(t *testing.T) {
+		t.Parallel()
+		srv := &sqlServer{}
+		ts := httptest.NewServer(srv)
+		t.Cleanup(ts.Close)
+		client, err := opensearch.NewClient(opensearch.Config{Addresses: []string{ts.URL}})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		db := sql.OpenDB(sqldriver.NewConnector(client, sqldriver.Options{FetchSize: 2}))
+		t.Cleanup(func() { _ = db.Close() })
+		require.NoError(t, db.PingContext(t.Context()))
+
+		rows, err := db.QueryContext(t.Context(), "SELECT name, age, born FROM people WHERE team = ? AND age > ?", "o'neil", 20)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		cols, err := rows.Columns()
+		require.NoError(t, err)
+		require.Equal(t, []string{"name", "years", "born"}, cols)
+		types, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		require.Equal(t, "INTEGER", types[1].DatabaseTypeName())
+		require.Equal(t, reflect.TypeFor[time.Time](), types[2].ScanType())
+		nullable, ok := types[0].Nullable()
+		require.True(t, nullable && ok)
+
+		var (
+			names []string
+			ages  []sql.NullInt64
+		)
+		for rows.Next() {
+			var (
+				name string
+				age  sql.NullInt64
+				born sql.NullTime
+			)
+			require.NoError(t, rows.Scan(&name, &age, &born))
+			names = append(names, name)
+			ages = append(ages, age)
+			if name == "alice" {
+				require.Equal(t, time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC), born.Time)
+			}
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []string{"alice", "bob", "carol"}, names)
+		require.Equal(t, []sql.NullInt64{{Int64: 30, Valid: true}, {}, {Int64: 25, Valid: true}}, ages)
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		require.Equal(t, map[string]any{
+			"query":      "SELECT name, age, born FROM people WHERE team = 'o''neil' AND age > 20",
+			"fetch_size": 2.0,
+		}, srv.queries[0])
+		require.Equal(t, map[string]any{"cursor": "c1"}, srv.queries[1])
+	})
+
+	t.Run("data source name, explain, and early close", func(t *testing.T) {
+		t.Parallel()
+		srv := &sqlServer{}
+		ts := httptest.NewServer(srv)
+		t.Cleanup(ts.Close)
+
+		db, err := sql.Open(sqldriver.DriverName, ts.URL+"?fetch_size=2")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		var plan json.RawMessage
+		require.NoError(t, db.QueryRowContext(t.Context(), sqldriver.ExplainQuery("SELECT * FROM people WHERE id = $1"), "7").Scan(&plan))
+		require.JSONEq(t, `{"root":{"name":"ProjectOperator","description":{},"children":[]}}`, string(plan))
+
+		var (
+			name      string
+			age, born any
+		)
+		require.NoError(t, db.QueryRowContext(t.Context(), "SELECT name, age, born FROM people").Scan(&name, &age, &born))
+		require.Equal(t, "alice", name)
+
+		// An EXPLAIN of the user's own is sent to the query API as written.
+		require.NoError(t, db.QueryRowContext(t.Context(), "EXPLAIN SELECT name FROM people").Scan(&name, &age, &born))
+
+		_, err = db.BeginTx(t.Context(), nil)
+		require.ErrorIs(t, err, sqldriver.ErrTxNotSupported)
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		require.Equal(t, "SELECT * FROM people WHERE id = '7'", srv.queries[0]["query"])
+		require.Equal(t, "EXPLAIN SELECT name FROM people", srv.queries[2]["query"])
+		require.Equal(t, 2, srv.closed)
+	})
+
+	t.Run("explain with an empty response", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(&sqlServer{emptyPlan: true})
+		t.Cleanup(ts.Close)
+
+		db, err := sql.Open(sqldriver.DriverName, ts.URL)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		var plan []byte
+		err = db.QueryRowContext(t.Context(), sqldriver.ExplainQuery("SELECT * FROM people")).Scan(&plan)
+		require.ErrorIs(t, err, opensearch.ErrJSONUnmarshalBody)
+	})
+
+	t.Run("exec reports deleted rows only", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(&sqlServer{})
+		t.Cleanup(ts.Close)
+
+		db, err := sql.Open(sqldriver.DriverName, ts.URL)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		res, err := db.ExecContext(t.Context(), "DELETE FROM people WHERE age > ?", 20)
+		require.NoError(t, err)
+		n, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(4), n)
+
+		res, err = db.ExecContext(t.Context(), "SELECT name FROM people")
+		require.NoError(t, err)
+		_, err = res.RowsAffected()
+		require.Error(t, err, "a query result is not a count of affected rows")
+	})
+
+	t.Run("ping reports refusals as ordinary errors", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(&sqlServer{pingStatus: http.StatusUnauthorized})
+		t.Cleanup(ts.Close)
+
+		db, err := sql.Open(sqldriver.DriverName, ts.URL)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		err = db.PingContext(t.Context())
+		require.ErrorIs(t, err, opensearch.ErrSecurityUnauthorized)
+		require.NotErrorIs(t, err, driver.ErrBadConn)
+	})
+
+	t.Run("ping reports server errors as bad connections", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(&sqlServer{pingStatus: http.StatusInternalServerError})
+		t.Cleanup(ts.Close)
+
+		db, err := sql.Open(sqldriver.DriverName, ts.URL)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		require.ErrorIs(t, db.PingContext(t.Context()), driver.ErrBadConn)
+	})
+
+	t.Run("invalid data source name", func(t *testing.T) {
+		t.Parallel()
+		_, err := sqldriver.Driver{}.OpenConnector("http://localhost:9200?fetch_size=many")
+		require.ErrorContains(t, err, "invalid fetch_size")
+		_, err = sqldriver.Driver{}.OpenConnector("http://localhost:9200?timeout=1s")
+		require.ErrorContains(t, err, "unknown data source parameter")
+	})
+}
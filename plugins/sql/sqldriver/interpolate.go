@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// interpolate replaces the ? and $N placeholders of query with the
+// arguments rendered as SQL literals. Placeholders inside string literals,
+// quoted identifiers, and comments are left alone. Every argument must be
+// used.
+func interpolate(query string, args []driver.NamedValue) (string, error) {
+	for _, arg := range args {
+		if arg.Name != "" {
+			return "", fmt.Errorf("%s: named argument %q is not supported", DriverName, arg.Name)
+		}
+	}
+	if len(args) == 0 {
+		return query, nil
+	}
+
+	var (
+		b    strings.Builder
+		next int
+		used = make([]bool, len(args))
+	)
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := quotedEnd(query, i)
+			b.WriteString(query[i:end])
+			i = end - 1
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '?':
+			if next >= len(args) {
+				return "", fmt.Errorf("%s: query has more placeholders than the %d arguments", DriverName, len(args))
+			}
+			if err := writeLiteral(&b, args[next].Value); err != nil {
+				return "", err
+			}
+			used[next] = true
+			next++
+		case c == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			j := i + 1
+			for j < len(query) && isDigit(query[j]) {
+				j++
+			}
+			n, _ := strconv.Atoi(query[i+1 : j])
+			if n < 1 || n > len(args) {
+				return "", fmt.Errorf("%s: placeholder $%d has no argument", DriverName, n)
+			}
+			if err := writeLiteral(&b, args[n-1].Value); err != nil {
+				return "", err
+			}
+			used[n-1] = true
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	for i, ok := range used {
+		if !ok {
+			return "", fmt.Errorf("%s: argument %d is not used by the query", DriverName, i+1)
+		}
+	}
+	return b.String(), nil
+}
+
+// quotedEnd returns the index after the quoted section starting at start.
+// A quote is escaped by doubling it or, except in backtick identifiers, by
+// a backslash. An unterminated section runs to the end of the query.
+func quotedEnd(query string, start int) int {
+	q := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if q != '`' {
+				i++
+			}
+		case q:
+			if i+1 < len(query) && query[i+1] == q {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// writeLiteral renders v, one of the driver.Value types, as a SQL literal.
+func writeLiteral(b *strings.Builder, v driver.Value) error {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("NULL")
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%s: cannot use %v as a query argument", DriverName, v)
+		}
+		b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	case string:
+		writeString(b, v)
+	case []byte:
+		writeString(b, string(v))
+	case time.Time:
+		writeString(b, v.UTC().Format("2006-01-02 15:04:05.999999999"))
+	default:
+		return fmt.Errorf("%s: unsupported argument type %T", DriverName, v)
+	}
+	return nil
+}
+
+// writeString writes s as a single-quoted string literal, escaping quotes
+// and backslashes.
+func writeString(b *strings.Builder, s string) {
+	b.WriteByte('\'')
+	for i := range len(s) {
+		switch c := s[i]; c {
+		case '\'':
+			b.WriteString("''")
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('\'')
+}
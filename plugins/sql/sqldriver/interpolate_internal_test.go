@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// The OpenSearch Contributors require contributions made to
+// this file be licensed under the Apache-2.0 license or a
+// compatible open source license.
+
+//go:build !integration
+
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolate(t *testing.T) {
+	t.Parallel()
+
+	args := func(values ...driver.Value) []driver.NamedValue {
+		named := make([]driver.NamedValue, len(values))
+		for i, v := range values {
+			named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+		}
+		return named
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		args  []driver.NamedValue
+		want  string
+		err   string
+	}{
+		{
+			name:  "no arguments",
+			query: "SELECT * FROM logs WHERE a = '?'",
+			want:  "SELECT * FROM logs WHERE a = '?'",
+		},
+		{
+			name:  "positional",
+			query: "SELECT * FROM logs WHERE host = ? AND code > ? AND ok = ? AND ratio < ? AND x IS ?",
+			args:  args("web-1", int64(499), true, 0.25, nil),
+			want:  "SELECT * FROM logs WHERE host = 'web-1' AND code > 499 AND ok = true AND ratio < 0.25 AND x IS NULL",
+		},
+		{
+			name:  "numbered",
+			query: "SELECT * FROM logs WHERE a = $2 OR b = $1 OR c = $2",
+			args:  args("x", int64(1)),
+			want:  "SELECT * FROM logs WHERE a = 1 OR b = 'x' OR c = 1",
+		},
+		{
+			name:  "escaping",
+			query: "SELECT * FROM logs WHERE msg = ?",
+			args:  args(`it's a \' trap`),
+			want:  `SELECT * FROM logs WHERE msg = 'it''s a \\'' trap'`,
+		},
+		{
+			name:  "quoted sections and comments",
+			query: "SELECT `we?ird`, \"q?\" FROM logs -- why?\nWHERE a = 'it''s?' /* ? */ AND b = ?",
+			args:  args([]byte("y")),
+			want:  "SELECT `we?ird`, \"q?\" FROM logs -- why?\nWHERE a = 'it''s?' /* ? */ AND b = 'y'",
+		},
+		{
+			name:  "time",
+			query: "SELECT * FROM logs WHERE @timestamp > ?",
+			args:  args(time.Date(2024, 5, 6, 7, 8, 9, 5e8, time.FixedZone("CEST", 2*3600))),
+			want:  "SELECT * FROM logs WHERE @timestamp > '2024-05-06 05:08:09.5'",
+		},
+		{
+			name:  "too few arguments",
+			query: "SELECT ? , ?",
+			args:  args(int64(1)),
+			err:   "more placeholders",
+		},
+		{
+			name:  "unused argument",
+			query: "SELECT ?",
+			args:  args(int64(1), int64(2)),
+			err:   "argument 2 is not used",
+		},
+		{
+			name:  "missing numbered argument",
+			query: "SELECT $3",
+			args:  args(int64(1)),
+			err:   "$3 has no argument",
+		},
+		{
+			name:  "named argument",
+			query: "SELECT @a",
+			args:  []driver.NamedValue{{Name: "a", Ordinal: 1, Value: int64(1)}},
+			err:   "named argument",
+		},
+		{
+			name:  "NaN",
+			query: "SELECT ?",
+			args:  args(math.NaN()),
+			err:   "cannot use NaN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := interpolate(tt.query, tt.args)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
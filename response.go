@@ -22,9 +22,12 @@
 package opensearch
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
 )
 
 const httpStatusCodeThreshold = 299
@@ -40,6 +43,18 @@ type Response struct {
 	// (such as the one fmt makes) share one cache and String can use a value
 	// receiver while staying non-consuming. Set by NewResponse and Client.Do.
 	render *renderCache
+
+	// redaction masks the body String renders. Set by Client.Do; the zero
+	// value applies the default rules without operation matching.
+	redaction redaction
+}
+
+// redaction is what String needs to mask a body: the Redactor and the
+// method and path of the request.
+type redaction struct {
+	redactor *opensearchtransport.Redactor
+	method   string
+	path     string
 }
 
 // renderCache holds the bytes String renders, filled at most once. It is
@@ -91,7 +106,21 @@ func NewResponse(statusCode int, body io.ReadCloser, header http.Header) *Respon
 	}
 }
 
-// String returns the response status and body as a string.
+// NewBufferedResponse creates a Response over a body already read into
+// memory, as Client.Do returns it: RawBody returns body, and Body reads it.
+// The Response takes ownership of body.
+func NewBufferedResponse(statusCode int, header http.Header, body []byte) *Response {
+	return &Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		rawBody:    body,
+		render:     &renderCache{},
+	}
+}
+
+// String returns the response status and body as a string, with secrets
+// masked by the client's Config.Redactor, as the loggers mask them.
 //
 // String uses a value receiver, so both Response and *Response satisfy
 // fmt.Stringer. For any Response returned by Client.Do it renders from the
@@ -109,6 +138,7 @@ func (r Response) String() string {
 	if rerr != nil {
 		return fmt.Sprintf("%s <error reading response body: %v>", r.Status(), rerr)
 	}
+	body = r.redaction.redactor.RedactBody(r.redaction.method, r.redaction.path, body)
 	return fmt.Sprintf("%s %s", r.Status(), body)
 }
 
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -33,6 +34,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/opensearch-project/opensearch-go/v5"
+	"github.com/opensearch-project/opensearch-go/v5/opensearchtransport"
 )
 
 // String uses a value receiver and is non-consuming, so both Response and
@@ -88,3 +90,47 @@ func TestResponse(t *testing.T) {
 		require.Equal(t, `[200 OK] {"test": true}`, resp.String())
 	})
 }
+
+// pathRequest is a Request for a fixed path.
+type pathRequest string
+
+func (p pathRequest) GetRequest(method string) (*http.Request, error) {
+	return http.NewRequest(method, string(p), nil)
+}
+
+func TestResponseStringRedacts(t *testing.T) {
+	t.Run("default fields", func(t *testing.T) {
+		resp := opensearch.NewResponse(http.StatusOK, io.NopCloser(strings.NewReader(`{"user":"bob","password":"hunter2"}`)), nil)
+		require.Equal(t, `[200 OK] {"password":"[redacted]","user":"bob"}`, resp.String())
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/_plugins/") {
+			_, _ = io.WriteString(w, `{"alice":{"hash":"$2y$12$abc"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"name":"A","ssn":"123-45-6789"}`)
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := opensearch.NewClient(opensearch.Config{
+		Addresses: []string{ts.URL},
+		Redactor:  &opensearchtransport.Redactor{Fields: []string{"ssn"}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	t.Run("operation", func(t *testing.T) {
+		resp, err := opensearch.Execute(t.Context(), client, http.MethodGet,
+			pathRequest("/_plugins/_security/api/internalusers"), (*opensearch.NoBody)(nil))
+		require.NoError(t, err)
+		require.Equal(t, "[200 OK] [redacted]", resp.String())
+		require.Contains(t, string(resp.RawBody()), "hash", "RawBody is not redacted")
+	})
+
+	t.Run("config fields", func(t *testing.T) {
+		resp, err := opensearch.Execute(t.Context(), client, http.MethodGet, pathRequest("/people/_doc/1"), (*opensearch.NoBody)(nil))
+		require.NoError(t, err)
+		require.Equal(t, `[200 OK] {"name":"A","ssn":"[redacted]"}`, resp.String())
+	})
+}